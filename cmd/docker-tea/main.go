@@ -2,14 +2,19 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
+	"io"
+	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/klejdi94/docker-tea/internal/config"
 	"github.com/klejdi94/docker-tea/internal/docker"
+	"github.com/klejdi94/docker-tea/internal/metrics"
 	"github.com/klejdi94/docker-tea/internal/ui"
 )
 
@@ -20,6 +25,11 @@ var (
 )
 
 func main() {
+	skipChecks := flag.Bool("skip-checks", false, "skip the startup environment self-check")
+	host := flag.String("host", "", "Docker daemon to connect to, e.g. tcp://host:2376 or ssh://user@host (overrides dockerHost and DOCKER_HOST)")
+	metricsAddr := flag.String("metrics-addr", "", "serve container stats in Prometheus format at /metrics on this address, e.g. :9090 (off by default)")
+	flag.Parse()
+
 	// Create a cancellable context for the app
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -35,21 +45,82 @@ func main() {
 	}()
 
 	// Load configuration
-	cfg, err := config.LoadConfig()
-	if err != nil {
-		fmt.Printf("Failed to load config: %v\n", err)
-		os.Exit(1)
-	}
+	cfg, configErr := config.LoadConfig()
 
 	// Create the docker service
-	dockerService, err := docker.NewDockerService()
-	if err != nil {
-		fmt.Printf("Failed to connect to Docker: %v\n", err)
+	var clientOptions docker.ClientOptions
+	if cfg != nil {
+		clientOptions.APIVersion = cfg.DockerAPIVersion
+		clientOptions.Host = cfg.DockerHost
+	}
+	if *host != "" {
+		clientOptions.Host = *host
+	}
+	dockerService, clientErr := docker.NewDockerService(clientOptions)
+
+	// Check the environment, so common setup problems get an actionable
+	// message instead of a bare connection failure
+	var checks []checkResult
+	if !*skipChecks {
+		checks = runSelfCheck(ctx, configErr, dockerService, clientErr)
+		if checksFailed(checks) {
+			fmt.Print(formatCheckReport(checks))
+			fmt.Println("\nRun with --skip-checks to start anyway.")
+			os.Exit(1)
+		}
+	}
+
+	if configErr != nil {
+		fmt.Printf("Failed to load config: %v\n", configErr)
 		os.Exit(1)
 	}
+	if err := ui.ApplyKeyBindings(cfg.Keybindings); err != nil {
+		fmt.Printf("Invalid keybindings in config: %v\n", err)
+		os.Exit(1)
+	}
+	if clientErr != nil {
+		fmt.Printf("Failed to connect to Docker: %v\n", clientErr)
+		os.Exit(1)
+	}
+	dockerService.SetComposeSearch(cfg.ComposeSearchPaths, cfg.ComposeSearchDepth)
+
+	// The app's own logs go to a file, anything printed would corrupt the UI
+	log.SetOutput(io.Discard)
+	var logWarning string
+	if path := cfg.LogPath(); path != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			logWarning = fmt.Sprintf("Logging disabled, can't create the log directory: %v", err)
+		} else if logFile, err := tea.LogToFile(path, "docker-tea"); err != nil {
+			logWarning = fmt.Sprintf("Logging disabled, can't open %s: %v", path, err)
+		} else {
+			defer logFile.Close()
+		}
+	}
+
+	// Serve the stats for Prometheus until the app exits, if asked to
+	var metricsDone <-chan struct{}
+	if *metricsAddr != "" {
+		done, err := metrics.Serve(ctx, *metricsAddr, dockerService)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		metricsDone = done
+	}
+
+	// The event listener is created up front so the model can restart it
+	events := ui.NewEventListener(ctx, dockerService)
 
 	// Create the model for Bubble Tea
-	model := ui.NewFullModel(dockerService, cfg, ctx)
+	model := ui.NewFullModel(dockerService, cfg, ctx).WithEventListener(events)
+	if logWarning != "" {
+		model = model.WithWarning(logWarning)
+	}
+	for _, check := range checks {
+		if check.status == checkWarn {
+			model = model.WithWarning(fmt.Sprintf("%s: %s. %s", check.name, check.detail, check.hint))
+		}
+	}
 
 	// Initialize the Bubble Tea program
 	p := tea.NewProgram(
@@ -59,10 +130,18 @@ func main() {
 	)
 
 	// Set up Docker event listener
-	ui.SetupEventListener(ctx, dockerService, p)
+	events.Start(p)
 
 	// Run the program
-	if _, err := p.Run(); err != nil {
+	_, err := p.Run()
+
+	// Let the metrics server finish the scrapes in progress
+	cancel()
+	if metricsDone != nil {
+		<-metricsDone
+	}
+
+	if err != nil {
 		fmt.Printf("Error running program: %v\n", err)
 		os.Exit(1)
 	}
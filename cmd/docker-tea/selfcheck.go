@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/klejdi94/docker-tea/internal/config"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// checkStatus is the outcome of a single startup check
+type checkStatus int
+
+const (
+	checkOK checkStatus = iota
+	checkWarn
+	checkFail
+)
+
+// checkResult describes what a startup check found and, if something is
+// wrong, what the user can do about it
+type checkResult struct {
+	name   string
+	status checkStatus
+	detail string
+	hint   string
+}
+
+// selfCheckTimeout bounds how long the checks may delay startup
+const selfCheckTimeout = 5 * time.Second
+
+// runSelfCheck checks that the environment is usable before the UI starts.
+// The service may be nil if the Docker client couldn't be created.
+func runSelfCheck(ctx context.Context, configErr error, dockerService *docker.Service, clientErr error) []checkResult {
+	ctx, cancel := context.WithTimeout(ctx, selfCheckTimeout)
+	defer cancel()
+
+	return []checkResult{
+		checkConfig(configErr),
+		checkDaemon(ctx, dockerService, clientErr),
+		checkCompose(ctx),
+	}
+}
+
+// checkConfig reports whether the config file could be loaded
+func checkConfig(configErr error) checkResult {
+	result := checkResult{name: "Config file"}
+	path, err := config.ConfigPath()
+	if err != nil {
+		path = "(no config directory)"
+	}
+
+	if configErr != nil {
+		result.status = checkFail
+		result.detail = configErr.Error()
+		result.hint = fmt.Sprintf("Fix %s, or remove it to use the defaults", path)
+		return result
+	}
+	result.detail = path
+	return result
+}
+
+// checkDaemon reports whether the Docker daemon can be reached, telling
+// apart a missing socket, a permission problem and a daemon that's down
+func checkDaemon(ctx context.Context, dockerService *docker.Service, clientErr error) checkResult {
+	result := checkResult{name: "Docker daemon"}
+	if clientErr != nil {
+		result.status = checkFail
+		result.detail = clientErr.Error()
+		result.hint = "Check --host or dockerHost in the config file, and the DOCKER_HOST, DOCKER_TLS_VERIFY and DOCKER_CERT_PATH environment variables"
+		return result
+	}
+
+	host := dockerService.Host()
+	if u, err := url.Parse(host); err == nil && u.Scheme == "unix" {
+		if failed, ok := checkSocket(u.Path); !ok {
+			return failed
+		}
+	}
+
+	if _, err := dockerService.Ping(ctx); err != nil {
+		result.status = checkFail
+		result.detail = fmt.Sprintf("%s: %v", host, err)
+		result.hint = "Make sure the Docker daemon is running, and that --host, dockerHost or DOCKER_HOST points to it"
+		return result
+	}
+
+	var mismatch *docker.APIVersionError
+	if err := dockerService.CheckAPIVersion(ctx); errors.As(err, &mismatch) {
+		result.status = checkFail
+		result.detail = mismatch.Error()
+		result.hint = "Upgrade the Docker daemon, or pin an API version it supports with dockerAPIVersion in the config file"
+		return result
+	}
+
+	result.detail = fmt.Sprintf("reachable at %s", host)
+	return result
+}
+
+// checkSocket looks for the usual problems with a local Docker socket
+func checkSocket(path string) (checkResult, bool) {
+	result := checkResult{name: "Docker daemon", status: checkFail}
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		result.detail = fmt.Sprintf("no Docker socket at %s", path)
+		result.hint = "Start Docker (e.g. `sudo systemctl start docker`, or open Docker Desktop), or set DOCKER_HOST"
+		return result, false
+	}
+
+	conn, err := net.DialTimeout("unix", path, selfCheckTimeout)
+	switch {
+	case err == nil:
+		conn.Close()
+		return checkResult{}, true
+	case errors.Is(err, syscall.EACCES), errors.Is(err, os.ErrPermission):
+		result.detail = fmt.Sprintf("permission denied on %s", path)
+		result.hint = "Add your user to the docker group (`sudo usermod -aG docker $USER`), then log out and back in"
+	case errors.Is(err, syscall.ECONNREFUSED):
+		result.detail = fmt.Sprintf("nothing is listening on %s", path)
+		result.hint = "The Docker daemon isn't running; start it (e.g. `sudo systemctl start docker`)"
+	default:
+		result.detail = err.Error()
+		result.hint = "Make sure the Docker daemon is running"
+	}
+	return result, false
+}
+
+// checkCompose reports whether the docker compose plugin is installed. The
+// rest of the app works without it, so it's only a warning.
+func checkCompose(ctx context.Context) checkResult {
+	result := checkResult{name: "Docker Compose"}
+	version, err := docker.ComposeVersion(ctx)
+	if err != nil {
+		result.status = checkWarn
+		result.detail = fmt.Sprintf("`docker compose` is not available: %v", err)
+		result.hint = "Install the Docker Compose plugin to use the Compose tab"
+		return result
+	}
+	result.detail = "version " + version
+	return result
+}
+
+// checksFailed reports whether any check found a problem that stops the app from working
+func checksFailed(results []checkResult) bool {
+	for _, r := range results {
+		if r.status == checkFail {
+			return true
+		}
+	}
+	return false
+}
+
+// formatCheckReport renders the check results for the terminal
+func formatCheckReport(results []checkResult) string {
+	var sb strings.Builder
+	for _, r := range results {
+		mark := "✓"
+		switch r.status {
+		case checkWarn:
+			mark = "!"
+		case checkFail:
+			mark = "✗"
+		}
+		sb.WriteString(fmt.Sprintf("%s %s: %s\n", mark, r.name, r.detail))
+		if r.status != checkOK && r.hint != "" {
+			sb.WriteString(fmt.Sprintf("    → %s\n", r.hint))
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,33 @@
+// Package clipboard copies text to the system clipboard, falling back to a
+// temporary file on machines without one, such as headless servers.
+package clipboard
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/atotto/clipboard"
+)
+
+// Copy puts text on the system clipboard. If no clipboard is available, the
+// text is written to a temporary file instead and its path is returned.
+func Copy(text string) (fallbackPath string, err error) {
+	if !clipboard.Unsupported {
+		if err := clipboard.WriteAll(text); err == nil {
+			return "", nil
+		}
+	}
+
+	file, err := os.CreateTemp("", "docker-tea-clipboard-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("no clipboard available, and saving to a file failed: %w", err)
+	}
+	if _, err := file.WriteString(text); err != nil {
+		file.Close()
+		return "", fmt.Errorf("no clipboard available, and saving to a file failed: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("no clipboard available, and saving to a file failed: %w", err)
+	}
+	return file.Name(), nil
+}
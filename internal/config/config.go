@@ -1,48 +1,448 @@
 package config
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+	"text/template"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Config holds application configuration settings
 type Config struct {
-	RefreshInterval time.Duration
-	Theme           Theme
-	LogFilePath     string
+	RefreshInterval time.Duration `yaml:"refreshInterval"`
+	Theme           Theme         `yaml:"theme"`
+	LogFilePath     string        `yaml:"logFilePath"`
+
+	// MaxContentWidth caps the width of the details/inspect panel. Zero means no cap.
+	MaxContentWidth int `yaml:"maxContentWidth"`
+
+	// AutoSelectFirstRow selects the first row of a list as soon as it loads,
+	// so actions have a target without pressing an arrow key first
+	AutoSelectFirstRow bool `yaml:"autoSelectFirstRow"`
+
+	// RestoreSession reopens the tab that was open at quit, with the same
+	// resource selected, instead of starting on the Containers tab
+	RestoreSession bool `yaml:"restoreSession"`
+
+	// SizeUnits selects how sizes are displayed: "iec" (KiB, MiB) or "si" (kB, MB)
+	SizeUnits string `yaml:"sizeUnits"`
+
+	// LogTailLines is how many lines of history the logs view starts with.
+	// Zero means the full log.
+	LogTailLines int `yaml:"logTailLines"`
+
+	// LogTimestamps sets how the timestamp at the start of each log line is
+	// shown: "utc" (as Docker sends it), "local" (compact, in local time) or
+	// "off". Cycling it in the logs view saves it here.
+	LogTimestamps string `yaml:"logTimestamps"`
+
+	// SyntaxHighlight colors the JSON of the inspect views by keys, strings,
+	// numbers, booleans and nulls
+	SyntaxHighlight bool `yaml:"syntaxHighlight"`
+
+	// DockerAPIVersion pins the Docker API version (e.g. "1.40") instead of
+	// negotiating it, for daemons too old to negotiate. Empty means negotiate.
+	DockerAPIVersion string `yaml:"dockerAPIVersion"`
+
+	// DockerHost is the daemon to connect to, e.g. "tcp://build-box:2376" or
+	// "ssh://me@build-box". Empty means DOCKER_HOST, or the local socket.
+	DockerHost string `yaml:"dockerHost"`
+
+	// StopTimeoutSeconds is how long a container being stopped or restarted
+	// gets to exit before Docker kills it with SIGKILL. Zero kills it right away.
+	StopTimeoutSeconds int `yaml:"stopTimeoutSeconds"`
+
+	// ComposeSearchPaths are directories where compose projects that aren't
+	// running are looked for, besides the one docker-tea was started in,
+	// e.g. "~/projects". Paths added from the Compose tab are saved here.
+	ComposeSearchPaths []string `yaml:"composeSearchPaths"`
+
+	// ComposeSearchDepth is how many directories below each search path the
+	// search goes. Zero means the paths themselves only.
+	ComposeSearchDepth int `yaml:"composeSearchDepth"`
+
+	// Confirmations sets how bulk destructive actions are confirmed, by action
+	// name (see confirmationActions), with "default" covering the rest: "yes"
+	// (press y), "count" (type the number of affected resources) or "hold"
+	// (hold y down). Unset actions ask for the count only when many resources
+	// are affected.
+	Confirmations map[string]string `yaml:"confirmations"`
+
+	// CustomActions are user-defined shell commands run against the selected resource
+	CustomActions []CustomAction `yaml:"customActions"`
+
+	// Notifications shows a desktop notification when a container exits
+	// with a non-zero code without having been stopped from docker-tea
+	Notifications bool `yaml:"notifications"`
+
+	// Keybindings replaces the keys of actions by name, e.g. "pause: z" or
+	// "down: [j, ctrl+n]". Actions left out keep their default keys.
+	Keybindings map[string]KeyList `yaml:"keybindings"`
+}
+
+// KeyList is the keys bound to an action, written as a single key or a list
+type KeyList []string
+
+// UnmarshalYAML accepts both a single key and a list of keys
+func (k *KeyList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*k = KeyList{value.Value}
+		return nil
+	}
+	var keys []string
+	if err := value.Decode(&keys); err != nil {
+		return err
+	}
+	*k = keys
+	return nil
+}
+
+// CustomAction binds a key to a shell command. The command is a Go template
+// filled in with the selected resource, e.g. "mybackup.sh {{quote .Name}}".
+type CustomAction struct {
+	Key     string `yaml:"key"`
+	Label   string `yaml:"label"`
+	Command string `yaml:"command"`
+
+	// Tabs limits the action to some tabs (containers, images, volumes,
+	// networks, compose). Empty means every tab.
+	Tabs []string `yaml:"tabs"`
+}
+
+// Template parses the action's command, with the quote function for passing
+// values to the shell as single arguments
+func (a CustomAction) Template() (*template.Template, error) {
+	return template.New(a.Label).Funcs(template.FuncMap{"quote": shellQuote}).Parse(a.Command)
+}
+
+// shellQuote quotes a value for the shell custom actions run in, so spaces
+// and metacharacters in names and paths are passed through literally
+func shellQuote(value string) string {
+	if runtime.GOOS == "windows" {
+		return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
 }
 
-// Theme represents UI theme settings
-type Theme struct {
-	ContainerRunning string
-	ContainerStopped string
-	ContainerPaused  string
-	HeaderColor      string
-	BorderColor      string
-	TitleColor       string
-	TextColor        string
-	StatusBarColor   string
+// apiVersionPattern matches a Docker API version such as "1.40"
+var apiVersionPattern = regexp.MustCompile(`^\d+\.\d+$`)
+
+// dockerHostPattern matches the daemon addresses the client can connect to
+var dockerHostPattern = regexp.MustCompile(`^(unix|tcp|ssh|npipe)://.+`)
+
+// Confirmation strengths for bulk destructive actions
+const (
+	ConfirmYes   = "yes"
+	ConfirmCount = "count"
+	ConfirmHold  = "hold"
+)
+
+// Ways of showing log timestamps
+const (
+	LogTimestampsUTC   = "utc"
+	LogTimestampsLocal = "local"
+	LogTimestampsOff   = "off"
+)
+
+// customActionTabs are the tab names a custom action can be limited to
+var customActionTabs = map[string]bool{
+	"containers": true,
+	"images":     true,
+	"volumes":    true,
+	"networks":   true,
+	"compose":    true,
+}
+
+// BuiltinKeys returns the built-in actions bound to each key on a tab, with
+// the config's keybindings applied, so custom actions can be kept off them.
+// It's set by the UI, which owns the built-in actions.
+var BuiltinKeys func(keybindings map[string]KeyList, tab string) map[string][]string
+
+// confirmationActions are the actions whose confirmation can be set, with
+// "default" for the ones not set
+var confirmationActions = map[string]bool{
+	"default":          true,
+	"prune-containers": true,
+	"prune-images":     true,
+	"prune-volumes":    true,
+	"prune-all":        true,
+	"remove-orphans":   true,
+	"force-remove":     true,
 }
 
 // NewConfig creates and returns a new Config instance with default values
 func NewConfig() *Config {
 	return &Config{
 		RefreshInterval: 5 * time.Second,
-		Theme: Theme{
-			ContainerRunning: "#4caf50", // Bright green
-			ContainerStopped: "#f44336", // Bright red
-			ContainerPaused:  "#ff9800", // Bright orange
-			HeaderColor:      "#2e3440", // Dark slate blue
-			BorderColor:      "#4c566a", // Medium slate blue
-			TitleColor:       "#88c0d0", // Light blue
-			TextColor:        "#d8dee9", // Off-white
-			StatusBarColor:   "#2e3440", // Dark slate blue
-		},
-		LogFilePath: "docker-tui.log",
+		Theme:           Theme{Name: DefaultTheme},
+		LogFilePath:     "docker-tui.log",
+		MaxContentWidth: 0,
+		SizeUnits:       "iec",
+		LogTailLines:    100,
+		LogTimestamps:   LogTimestampsUTC,
+		SyntaxHighlight: true,
+
+		StopTimeoutSeconds: 10,
+		ComposeSearchDepth: 3,
 	}
 }
 
-// LoadConfig loads the configuration from the config file
+// ConfigPath returns the location of the config file. It can be overridden
+// with the DOCKER_TEA_CONFIG environment variable.
+func ConfigPath() (string, error) {
+	if path := os.Getenv("DOCKER_TEA_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docker-tea", "config.yaml"), nil
+}
+
+// Confirmation returns the configured confirmation strength for an action,
+// or an empty string if neither it nor the default is configured
+func (c *Config) Confirmation(action string) string {
+	if strength, ok := c.Confirmations[action]; ok {
+		return strength
+	}
+	return c.Confirmations["default"]
+}
+
+// LogPath returns where the application writes its own logs, or an empty
+// string if logging is disabled. A relative logFilePath is resolved against
+// the config file's directory, so it doesn't depend on where the app is started.
+func (c *Config) LogPath() string {
+	if c.LogFilePath == "" || filepath.IsAbs(c.LogFilePath) {
+		return c.LogFilePath
+	}
+
+	configPath, err := ConfigPath()
+	if err != nil {
+		return c.LogFilePath
+	}
+	return filepath.Join(filepath.Dir(configPath), c.LogFilePath)
+}
+
+// LoadConfig loads the configuration from the config file. Settings missing
+// from the file keep their default values, and a missing file is not an error.
 func LoadConfig() (*Config, error) {
-	// Currently just using default config, but can be extended to load from file
-	return NewConfig(), nil
+	cfg := NewConfig()
+
+	path, err := ConfigPath()
+	if err != nil {
+		// No config location available, just use the defaults
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read config file %s: %v", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %v", path, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+
+	return cfg, nil
+}
+
+// AddComposeSearchPath adds a directory to composeSearchPaths in the config
+// file, creating the file if there's none. The rest of the file, comments
+// included, is kept as it is.
+func AddComposeSearchPath(dir string) error {
+	return editConfigFile(func(path string, settings *yaml.Node) error {
+		paths := settingValue(settings, "composeSearchPaths")
+		if paths == nil {
+			paths = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			settings.Content = append(settings.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "composeSearchPaths"}, paths)
+		}
+		if paths.Kind != yaml.SequenceNode {
+			return fmt.Errorf("composeSearchPaths in %s isn't a list", path)
+		}
+		paths.Content = append(paths.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: dir})
+		return nil
+	})
+}
+
+// SaveLogTimestamps sets logTimestamps in the config file, the same way
+// AddComposeSearchPath edits it
+func SaveLogTimestamps(mode string) error {
+	return editConfigFile(func(path string, settings *yaml.Node) error {
+		value := settingValue(settings, "logTimestamps")
+		if value == nil {
+			value = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str"}
+			settings.Content = append(settings.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "logTimestamps"}, value)
+		}
+		if value.Kind != yaml.ScalarNode {
+			return fmt.Errorf("logTimestamps in %s isn't a single value", path)
+		}
+		value.Value = mode
+		return nil
+	})
+}
+
+// settingValue returns the value of a top-level setting, or nil if it isn't set
+func settingValue(settings *yaml.Node, name string) *yaml.Node {
+	for i := 0; i+1 < len(settings.Content); i += 2 {
+		if settings.Content[i].Value == name {
+			return settings.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// editConfigFile applies edit to the settings in the config file and writes
+// it back, creating the file if there's none. Working on the YAML nodes keeps
+// the rest of the file, comments included, as it is.
+func editConfigFile(edit func(path string, settings *yaml.Node) error) error {
+	path, err := ConfigPath()
+	if err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read config file %s: %v", path, err)
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %v", path, err)
+	}
+	if len(doc.Content) == 0 {
+		// A missing or empty file
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+
+	settings := doc.Content[0]
+	if settings.Kind != yaml.MappingNode {
+		return fmt.Errorf("config file %s doesn't hold settings", path)
+	}
+	if err := edit(path, settings); err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %v", err)
+	}
+	if err := os.WriteFile(path, out.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write config file %s: %v", path, err)
+	}
+	return nil
+}
+
+// Validate checks that the configuration values are usable
+func (c *Config) Validate() error {
+	if c.RefreshInterval <= 0 {
+		return fmt.Errorf("refreshInterval must be positive, got %s", c.RefreshInterval)
+	}
+	if c.MaxContentWidth < 0 {
+		return fmt.Errorf("maxContentWidth can't be negative, got %d", c.MaxContentWidth)
+	}
+	if c.LogTailLines < 0 {
+		return fmt.Errorf("logTailLines can't be negative, got %d", c.LogTailLines)
+	}
+	if c.LogTimestamps != LogTimestampsUTC && c.LogTimestamps != LogTimestampsLocal && c.LogTimestamps != LogTimestampsOff {
+		return fmt.Errorf("logTimestamps must be \"utc\", \"local\" or \"off\", got %q", c.LogTimestamps)
+	}
+	if c.StopTimeoutSeconds < 0 {
+		return fmt.Errorf("stopTimeoutSeconds can't be negative, got %d", c.StopTimeoutSeconds)
+	}
+	if slices.Contains(c.ComposeSearchPaths, "") {
+		return fmt.Errorf("composeSearchPaths can't contain an empty path")
+	}
+	if c.ComposeSearchDepth < 0 {
+		return fmt.Errorf("composeSearchDepth can't be negative, got %d", c.ComposeSearchDepth)
+	}
+	if c.SizeUnits != "iec" && c.SizeUnits != "si" {
+		return fmt.Errorf("sizeUnits must be \"iec\" or \"si\", got %q", c.SizeUnits)
+	}
+	if c.DockerAPIVersion != "" && !apiVersionPattern.MatchString(c.DockerAPIVersion) {
+		return fmt.Errorf("dockerAPIVersion must look like \"1.40\", got %q", c.DockerAPIVersion)
+	}
+	if c.DockerHost != "" && !dockerHostPattern.MatchString(c.DockerHost) {
+		return fmt.Errorf("dockerHost must be a unix://, tcp://, ssh:// or npipe:// address, got %q", c.DockerHost)
+	}
+	for action, strength := range c.Confirmations {
+		if !confirmationActions[action] {
+			return fmt.Errorf("confirmations has an unknown action %q", action)
+		}
+		if strength != ConfirmYes && strength != ConfirmCount && strength != ConfirmHold {
+			return fmt.Errorf("confirmations.%s must be \"yes\", \"count\" or \"hold\", got %q", action, strength)
+		}
+	}
+	for action, keys := range c.Keybindings {
+		if len(keys) == 0 || slices.Contains(keys, "") {
+			return fmt.Errorf("keybindings.%s needs at least one key, and no empty ones", action)
+		}
+	}
+	if err := c.Theme.Validate(); err != nil {
+		return err
+	}
+	for i, action := range c.CustomActions {
+		if action.Key == "" || action.Label == "" || action.Command == "" {
+			return fmt.Errorf("customActions[%d] needs a key, a label and a command", i)
+		}
+		if _, err := action.Template(); err != nil {
+			return fmt.Errorf("customActions[%d] has an invalid command template: %v", i, err)
+		}
+		for _, tab := range action.Tabs {
+			if !customActionTabs[tab] {
+				return fmt.Errorf("customActions[%d] has an unknown tab %q", i, tab)
+			}
+		}
+	}
+	return c.customKeyConflicts()
+}
+
+// customKeyConflicts rejects custom actions bound to a key a built-in action
+// or an earlier custom action already uses on one of their tabs
+func (c *Config) customKeyConflicts() error {
+	owners := make(map[string]map[string]int)
+	for i, action := range c.CustomActions {
+		tabs := action.Tabs
+		if len(tabs) == 0 {
+			tabs = slices.Sorted(maps.Keys(customActionTabs))
+		}
+		for _, tab := range tabs {
+			if BuiltinKeys != nil {
+				if names := BuiltinKeys(c.Keybindings, tab)[action.Key]; len(names) > 0 {
+					return fmt.Errorf("customActions[%d] key %q is bound to %s on the %s tab", i, action.Key, strings.Join(names, " and "), tab)
+				}
+			}
+			if owners[tab] == nil {
+				owners[tab] = make(map[string]int)
+			}
+			if j, ok := owners[tab][action.Key]; ok {
+				return fmt.Errorf("customActions[%d] key %q is bound to customActions[%d] on the %s tab", i, action.Key, j, tab)
+			}
+			owners[tab][action.Key] = i
+		}
+	}
+	return nil
 }
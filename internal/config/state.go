@@ -0,0 +1,75 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// State holds UI state that is remembered between sessions. Unlike Config,
+// it is written by the application itself.
+type State struct {
+	// TabFilters maps a tab name to the status filter last used on it
+	TabFilters map[string]string `json:"tabFilters,omitempty"`
+
+	// Tab and Selection are the tab open at quit and the name of the resource
+	// selected on it, restored on the next start when restoreSession is set
+	Tab       string `json:"tab,omitempty"`
+	Selection string `json:"selection,omitempty"`
+}
+
+// StatePath returns the location of the state file, next to the config file
+func StatePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "docker-tea", "state.json"), nil
+}
+
+// LoadState loads the saved UI state. A missing or unreadable state file
+// just means starting from a clean slate.
+func LoadState() *State {
+	state := &State{TabFilters: make(map[string]string)}
+
+	path, err := StatePath()
+	if err != nil {
+		return state
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return state
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return &State{TabFilters: make(map[string]string)}
+	}
+	if state.TabFilters == nil {
+		state.TabFilters = make(map[string]string)
+	}
+	return state
+}
+
+// Save writes the state to the state file
+func (s *State) Save() error {
+	path, err := StatePath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %v", err)
+	}
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write state file %s: %v", path, err)
+	}
+	return nil
+}
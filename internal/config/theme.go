@@ -0,0 +1,177 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultTheme is the theme used when the config doesn't name one
+const DefaultTheme = "nord"
+
+// Theme holds the colors the UI is drawn with. Name picks one of the
+// built-in themes; colors set next to it replace the theme's own.
+type Theme struct {
+	Name string `yaml:"name"`
+
+	TitleColor         string `yaml:"titleColor"`         // view headers
+	AccentColor        string `yaml:"accentColor"`        // section titles and the active tab
+	TextColor          string `yaml:"textColor"`          // column headers and labels
+	MutedColor         string `yaml:"mutedColor"`         // values and hints
+	BorderColor        string `yaml:"borderColor"`        // borders and timestamps
+	EmphasisColor      string `yaml:"emphasisColor"`      // keys and the selected item
+	HighlightColor     string `yaml:"highlightColor"`     // sections of the inspect views
+	SpecialColor       string `yaml:"specialColor"`       // restarting containers, the spinner
+	SuccessColor       string `yaml:"successColor"`       // successful actions, added files
+	WarningColor       string `yaml:"warningColor"`       // warnings and search matches
+	ErrorColor         string `yaml:"errorColor"`         // errors and failed actions
+	SelectedTextColor  string `yaml:"selectedTextColor"`  // text of the selected row
+	SelectedBackground string `yaml:"selectedBackground"` // background of the selected row
+	HeaderColor        string `yaml:"headerColor"`        // dark text on highlighted backgrounds
+	StatusBarColor     string `yaml:"statusBarColor"`     // the footer
+	ContainerRunning   string `yaml:"containerRunning"`
+	ContainerStopped   string `yaml:"containerStopped"`
+	ContainerPaused    string `yaml:"containerPaused"`
+}
+
+// ThemePresets are the built-in themes, by name
+var ThemePresets = map[string]Theme{
+	"nord": {
+		TitleColor:         "#88c0d0",
+		AccentColor:        "#5f87ff",
+		TextColor:          "#d8dee9",
+		MutedColor:         "#aaaaaa",
+		BorderColor:        "#4c566a",
+		EmphasisColor:      "#ffffff",
+		HighlightColor:     "#ffdd00",
+		SpecialColor:       "#b48ead",
+		SuccessColor:       "#a3be8c",
+		WarningColor:       "#ebcb8b",
+		ErrorColor:         "#bf616a",
+		SelectedTextColor:  "#ffffaf",
+		SelectedBackground: "#5f00ff",
+		HeaderColor:        "#2e3440",
+		StatusBarColor:     "#4c566a",
+		ContainerRunning:   "#a3be8c",
+		ContainerStopped:   "#bf616a",
+		ContainerPaused:    "#ebcb8b",
+	},
+	"dracula": {
+		TitleColor:         "#8be9fd",
+		AccentColor:        "#bd93f9",
+		TextColor:          "#f8f8f2",
+		MutedColor:         "#bfbfbf",
+		BorderColor:        "#6272a4",
+		EmphasisColor:      "#f8f8f2",
+		HighlightColor:     "#f1fa8c",
+		SpecialColor:       "#ff79c6",
+		SuccessColor:       "#50fa7b",
+		WarningColor:       "#ffb86c",
+		ErrorColor:         "#ff5555",
+		SelectedTextColor:  "#282a36",
+		SelectedBackground: "#bd93f9",
+		HeaderColor:        "#282a36",
+		StatusBarColor:     "#6272a4",
+		ContainerRunning:   "#50fa7b",
+		ContainerStopped:   "#ff5555",
+		ContainerPaused:    "#ffb86c",
+	},
+	"solarized": {
+		TitleColor:         "#2aa198",
+		AccentColor:        "#268bd2",
+		TextColor:          "#93a1a1",
+		MutedColor:         "#839496",
+		BorderColor:        "#586e75",
+		EmphasisColor:      "#eee8d5",
+		HighlightColor:     "#b58900",
+		SpecialColor:       "#6c71c4",
+		SuccessColor:       "#859900",
+		WarningColor:       "#cb4b16",
+		ErrorColor:         "#dc322f",
+		SelectedTextColor:  "#fdf6e3",
+		SelectedBackground: "#268bd2",
+		HeaderColor:        "#002b36",
+		StatusBarColor:     "#586e75",
+		ContainerRunning:   "#859900",
+		ContainerStopped:   "#dc322f",
+		ContainerPaused:    "#b58900",
+	},
+}
+
+// hexColorPattern matches colors such as "#88c0d0" or "#fff"
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ThemeNames returns the names of the built-in themes, sorted
+func ThemeNames() []string {
+	names := make([]string, 0, len(ThemePresets))
+	for name := range ThemePresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// colors returns the theme's colors by their name in the config file
+func (t *Theme) colors() map[string]*string {
+	return map[string]*string{
+		"titleColor":         &t.TitleColor,
+		"accentColor":        &t.AccentColor,
+		"textColor":          &t.TextColor,
+		"mutedColor":         &t.MutedColor,
+		"borderColor":        &t.BorderColor,
+		"emphasisColor":      &t.EmphasisColor,
+		"highlightColor":     &t.HighlightColor,
+		"specialColor":       &t.SpecialColor,
+		"successColor":       &t.SuccessColor,
+		"warningColor":       &t.WarningColor,
+		"errorColor":         &t.ErrorColor,
+		"selectedTextColor":  &t.SelectedTextColor,
+		"selectedBackground": &t.SelectedBackground,
+		"headerColor":        &t.HeaderColor,
+		"statusBarColor":     &t.StatusBarColor,
+		"containerRunning":   &t.ContainerRunning,
+		"containerStopped":   &t.ContainerStopped,
+		"containerPaused":    &t.ContainerPaused,
+	}
+}
+
+// Resolve returns the complete theme: the named built-in theme, or the
+// default one, with the colors set in t in place of its own
+func (t Theme) Resolve() Theme {
+	name := t.Name
+	if _, ok := ThemePresets[name]; !ok {
+		name = DefaultTheme
+	}
+	resolved := ThemePresets[name]
+	resolved.Name = name
+
+	colors := resolved.colors()
+	for field, color := range t.colors() {
+		if *color != "" {
+			*colors[field] = *color
+		}
+	}
+	return resolved
+}
+
+// Validate checks that the theme names a built-in theme and that its colors
+// are hex colors
+func (t Theme) Validate() error {
+	if _, ok := ThemePresets[t.Name]; t.Name != "" && !ok {
+		return fmt.Errorf("theme.name must be one of %s, got %q", strings.Join(ThemeNames(), ", "), t.Name)
+	}
+
+	colors := t.colors()
+	fields := make([]string, 0, len(colors))
+	for field := range colors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if color := *colors[field]; color != "" && !hexColorPattern.MatchString(color) {
+			return fmt.Errorf("theme.%s must be a hex color such as \"#88c0d0\", got %q", field, color)
+		}
+	}
+	return nil
+}
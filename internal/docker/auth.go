@@ -0,0 +1,261 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/registry"
+)
+
+// dockerHubServer is the key the Docker CLI stores Docker Hub credentials under
+const dockerHubServer = "https://index.docker.io/v1/"
+
+// sessionAuths holds the credentials of registries logged in to from
+// docker-tea, by server, used before any stored by the Docker CLI
+var sessionAuths = struct {
+	sync.Mutex
+	byServer map[string]registry.AuthConfig
+}{byServer: make(map[string]registry.AuthConfig)}
+
+// dockerConfigFile is the part of the Docker CLI's config.json holding registry credentials
+type dockerConfigFile struct {
+	Auths map[string]struct {
+		Auth          string `json:"auth"`
+		IdentityToken string `json:"identitytoken"`
+	} `json:"auths"`
+	CredsStore     string            `json:"credsStore"`
+	CredHelpers    map[string]string `json:"credHelpers"`
+	CurrentContext string            `json:"currentContext"`
+}
+
+// dockerConfigDir returns the Docker CLI's configuration directory
+func dockerConfigDir() (string, error) {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".docker"), nil
+}
+
+// RegistryAuth returns the encoded credentials for the registry an image
+// reference points to, read from the Docker CLI's config.json and credential
+// helpers so existing `docker login` sessions are used. Without stored
+// credentials it returns an empty (anonymous) auth.
+func RegistryAuth(ctx context.Context, ref string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid image reference %q: %w", ref, err)
+	}
+	server := registryServer(reference.Domain(named))
+
+	auth, err := lookupCredentials(ctx, server)
+	if err != nil {
+		return "", err
+	}
+	return registry.EncodeAuthConfig(auth)
+}
+
+// registryServer returns the key credentials for a registry are kept under:
+// its host, or dockerHubServer for Docker Hub
+func registryServer(address string) string {
+	host := strings.TrimPrefix(strings.TrimPrefix(address, "https://"), "http://")
+	host = strings.TrimSuffix(host, "/")
+	switch host {
+	case "", "docker.io", "index.docker.io", "index.docker.io/v1", "registry-1.docker.io":
+		return dockerHubServer
+	}
+	return host
+}
+
+// RegistryLogin logs in to a registry, Docker Hub if serverAddress is empty,
+// and returns the registry's status message. The credentials are used for
+// pulls and pushes for the rest of the session; SaveRegistryLogin keeps them
+// for later ones.
+func (s *Service) RegistryLogin(ctx context.Context, serverAddress, username, password string) (string, error) {
+	server := registryServer(serverAddress)
+	auth := registry.AuthConfig{ServerAddress: server, Username: username, Password: password}
+
+	body, err := s.cli().RegistryLogin(ctx, auth)
+	if err != nil {
+		return "", fmt.Errorf("failed to log in to %s: %w", serverAddress, err)
+	}
+	if body.IdentityToken != "" {
+		auth.Password = ""
+		auth.IdentityToken = body.IdentityToken
+	}
+
+	sessionAuths.Lock()
+	sessionAuths.byServer[server] = auth
+	sessionAuths.Unlock()
+	return body.Status, nil
+}
+
+// SaveRegistryLogin stores the credentials of a registry logged in to this
+// session with the Docker CLI's credential helper for it, the way
+// `docker login` does. Without a credential helper configured nothing is
+// saved, rather than writing the password to config.json.
+func SaveRegistryLogin(ctx context.Context, serverAddress string) error {
+	server := registryServer(serverAddress)
+	sessionAuths.Lock()
+	auth, ok := sessionAuths.byServer[server]
+	sessionAuths.Unlock()
+	if !ok {
+		return fmt.Errorf("not logged in to %s", serverAddress)
+	}
+
+	dir, err := dockerConfigDir()
+	if err != nil {
+		return err
+	}
+	var cfg dockerConfigFile
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read Docker config: %w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return fmt.Errorf("failed to parse Docker config: %w", err)
+		}
+	}
+	helper := cfg.CredHelpers[server]
+	if helper == "" {
+		helper = cfg.CredsStore
+	}
+	if helper == "" {
+		return errors.New("no credential helper (credsStore) is set up in the Docker config")
+	}
+
+	creds := struct {
+		ServerURL string
+		Username  string
+		Secret    string
+	}{ServerURL: server, Username: auth.Username, Secret: auth.Password}
+	if auth.IdentityToken != "" {
+		creds.Username, creds.Secret = "<token>", auth.IdentityToken
+	}
+	input, err := json.Marshal(creds)
+	if err != nil {
+		return err
+	}
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker-credential-"+helper, "store")
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		return &CommandError{Err: err, Stderr: strings.TrimSpace(output.String())}
+	}
+	return nil
+}
+
+// lookupCredentials finds the stored credentials for a registry server,
+// preferring a login from this session
+func lookupCredentials(ctx context.Context, server string) (registry.AuthConfig, error) {
+	sessionAuths.Lock()
+	auth, ok := sessionAuths.byServer[server]
+	sessionAuths.Unlock()
+	if ok {
+		return auth, nil
+	}
+
+	anonymous := registry.AuthConfig{ServerAddress: server}
+
+	dir, err := dockerConfigDir()
+	if err != nil {
+		return anonymous, nil
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return anonymous, nil
+		}
+		return anonymous, fmt.Errorf("failed to read Docker config: %w", err)
+	}
+
+	var cfg dockerConfigFile
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return anonymous, fmt.Errorf("failed to parse Docker config: %w", err)
+	}
+
+	helper := cfg.CredHelpers[server]
+	if helper == "" {
+		helper = cfg.CredsStore
+	}
+	if helper != "" {
+		auth, found, err := helperCredentials(ctx, helper, server)
+		if err != nil {
+			return anonymous, err
+		}
+		if found {
+			return auth, nil
+		}
+	}
+
+	// Credentials stored in the file itself, possibly keyed with a scheme
+	for _, key := range []string{server, "https://" + server, "http://" + server} {
+		entry, ok := cfg.Auths[key]
+		if !ok {
+			continue
+		}
+		auth := anonymous
+		auth.IdentityToken = entry.IdentityToken
+		if entry.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+			if err != nil {
+				return anonymous, fmt.Errorf("invalid credentials for %s in Docker config: %w", server, err)
+			}
+			auth.Username, auth.Password, _ = strings.Cut(string(decoded), ":")
+		}
+		return auth, nil
+	}
+	return anonymous, nil
+}
+
+// helperCredentials asks a docker-credential-* helper for a server's credentials
+func helperCredentials(ctx context.Context, helper, server string) (registry.AuthConfig, bool, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker-credential-"+helper, "get")
+	cmd.Stdin = strings.NewReader(server)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		// Helpers report unknown servers on stdout and exit with an error
+		if strings.Contains(stdout.String(), "credentials not found") {
+			return registry.AuthConfig{}, false, nil
+		}
+		return registry.AuthConfig{}, false, &CommandError{Err: err, Stderr: strings.TrimSpace(stderr.String() + stdout.String())}
+	}
+
+	var creds struct {
+		Username string
+		Secret   string
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &creds); err != nil {
+		return registry.AuthConfig{}, false, fmt.Errorf("unexpected output from docker-credential-%s: %w", helper, err)
+	}
+
+	auth := registry.AuthConfig{ServerAddress: server}
+	if creds.Username == "<token>" {
+		auth.IdentityToken = creds.Secret
+	} else {
+		auth.Username = creds.Username
+		auth.Password = creds.Secret
+	}
+	return auth, true, nil
+}
@@ -0,0 +1,210 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/docker/cli/cli/connhelper"
+	"github.com/docker/docker/api"
+	"github.com/docker/docker/api/types/versions"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/tlsconfig"
+)
+
+// ClientOptions configures how the Docker client is created
+type ClientOptions struct {
+	// APIVersion pins the Docker API version instead of negotiating it with
+	// the daemon, for daemons too old to negotiate properly
+	APIVersion string
+
+	// Host is the daemon endpoint (unix://, tcp:// or ssh://user@host).
+	// Empty means DOCKER_HOST, or the local socket.
+	Host string
+}
+
+// newClient creates a client talking to the given host, falling back to the
+// configured one and then to the environment. ssh:// hosts are reached by
+// running `docker system dial-stdio` over ssh, like the Docker CLI does, and
+// other hosts with the TLS material that goes with them (see tlsFor).
+func (o ClientOptions) newClient(host string) (*client.Client, error) {
+	if host == "" {
+		host = o.Host
+	}
+	if host == "" {
+		host = os.Getenv(client.EnvOverrideHost)
+	}
+
+	var opts []client.Opt
+	if strings.HasPrefix(host, "ssh://") {
+		helper, err := connhelper.GetConnectionHelper(host)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ssh host %s: %w", host, err)
+		}
+		opts = append(opts,
+			client.WithHTTPClient(&http.Client{Transport: &http.Transport{DialContext: helper.Dialer}}),
+			client.WithHost(helper.Host),
+			client.WithDialContext(helper.Dialer),
+		)
+	} else {
+		tlsOpt, err := o.tlsFor(host)
+		if err != nil {
+			return nil, err
+		}
+		if tlsOpt != nil {
+			opts = append(opts, tlsOpt)
+		}
+		if host != "" {
+			opts = append(opts, client.WithHost(host))
+		}
+	}
+
+	if o.APIVersion != "" {
+		opts = append(opts, client.WithVersion(o.APIVersion))
+	} else {
+		opts = append(opts, client.WithVersionFromEnv(), client.WithAPIVersionNegotiation())
+	}
+	return client.NewClientWithOpts(opts...)
+}
+
+// tlsFor returns the TLS configuration to reach host with: the environment's
+// for the configured or environment's own host, and for any other the one
+// stored with the Docker context it belongs to. It returns nil for hosts
+// reached without TLS.
+func (o ClientOptions) tlsFor(host string) (client.Opt, error) {
+	if host == "" || host == o.Host || host == os.Getenv(client.EnvOverrideHost) {
+		return tlsFromEnv()
+	}
+	if dockerCtx, ok := contextForHost(host); ok {
+		return tlsFromContext(dockerCtx)
+	}
+	return nil, nil
+}
+
+// tlsFromContext configures TLS from the certificates stored with a Docker
+// context, any of which may be missing, the way the Docker CLI does. It
+// returns nil when the context has neither certificates nor skipTLSVerify.
+func tlsFromContext(dockerCtx DockerContext) (client.Opt, error) {
+	options := tlsconfig.Options{InsecureSkipVerify: dockerCtx.skipTLSVerify}
+	found := dockerCtx.skipTLSVerify
+	for _, file := range []struct {
+		name  string
+		field *string
+	}{
+		{"ca.pem", &options.CAFile},
+		{"cert.pem", &options.CertFile},
+		{"key.pem", &options.KeyFile},
+	} {
+		path := filepath.Join(dockerCtx.tlsDir, file.name)
+		if _, err := os.Stat(path); err == nil {
+			*file.field = path
+			found = true
+		}
+	}
+	if !found {
+		return nil, nil
+	}
+
+	config, err := tlsconfig.Client(options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS material of context %s: %w", dockerCtx.Name, err)
+	}
+	return client.WithHTTPClient(&http.Client{
+		Transport:     &http.Transport{TLSClientConfig: config},
+		CheckRedirect: client.CheckRedirect,
+	}), nil
+}
+
+// tlsCertFiles are the files the Docker CLI expects in DOCKER_CERT_PATH
+var tlsCertFiles = []string{"ca.pem", "cert.pem", "key.pem"}
+
+// tlsFromEnv configures TLS from DOCKER_TLS_VERIFY and DOCKER_CERT_PATH the
+// way the Docker CLI does, making sure the certificates exist so a missing
+// one is reported up front rather than as a handshake failure. It returns
+// nil when TLS isn't enabled.
+func tlsFromEnv() (client.Opt, error) {
+	certPath := os.Getenv(client.EnvOverrideCertPath)
+	verify := os.Getenv(client.EnvTLSVerify) != ""
+	if certPath == "" && !verify {
+		return nil, nil
+	}
+	if certPath == "" {
+		dir, err := dockerConfigDir()
+		if err != nil {
+			return nil, fmt.Errorf("%s is set but DOCKER_CERT_PATH isn't: %w", client.EnvTLSVerify, err)
+		}
+		certPath = dir
+	}
+
+	paths := make([]string, len(tlsCertFiles))
+	for i, name := range tlsCertFiles {
+		paths[i] = filepath.Join(certPath, name)
+		if _, err := os.Stat(paths[i]); err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, fmt.Errorf("TLS is enabled but %s is missing; set DOCKER_CERT_PATH to the directory holding %s", paths[i], strings.Join(tlsCertFiles, ", "))
+			}
+			return nil, fmt.Errorf("can't read TLS certificate %s: %w", paths[i], err)
+		}
+	}
+
+	// Without DOCKER_TLS_VERIFY the certificates are used but the daemon's isn't checked
+	if !verify {
+		return client.WithTLSClientConfigFromEnv(), nil
+	}
+	return client.WithTLSClientConfig(paths[0], paths[1], paths[2]), nil
+}
+
+// APIVersionError reports that the client and the daemon can't agree on an API version
+type APIVersionError struct {
+	Client string
+	Server string
+	Reason string
+}
+
+func (e *APIVersionError) Error() string {
+	return fmt.Sprintf("Docker API version mismatch (client %s, daemon %s): %s", e.Client, e.Server, e.Reason)
+}
+
+// CheckAPIVersion makes sure the client and the daemon speak a common API
+// version, so incompatibilities are reported clearly up front instead of as
+// confusing errors from individual operations
+func (s *Service) CheckAPIVersion(ctx context.Context) error {
+	cli := s.cli()
+	ping, err := cli.Ping(ctx)
+	if err != nil {
+		return err
+	}
+	if s.options.APIVersion == "" {
+		cli.NegotiateAPIVersionPing(ping)
+	}
+	if ping.APIVersion == "" {
+		// Daemons that don't report a version predate 1.25, treat them as the
+		// oldest supported one like the client does
+		ping.APIVersion = api.MinSupportedAPIVersion
+	}
+
+	clientVersion := cli.ClientVersion()
+	mismatch := &APIVersionError{Client: clientVersion, Server: ping.APIVersion}
+
+	if versions.LessThan(ping.APIVersion, api.MinSupportedAPIVersion) {
+		mismatch.Reason = fmt.Sprintf("the daemon is older than the oldest supported API version %s, upgrade Docker", api.MinSupportedAPIVersion)
+		return mismatch
+	}
+
+	if s.options.APIVersion != "" && versions.GreaterThan(s.options.APIVersion, ping.APIVersion) {
+		mismatch.Reason = fmt.Sprintf("the pinned API version %s is newer than the daemon supports, lower dockerAPIVersion to %s or upgrade Docker", s.options.APIVersion, ping.APIVersion)
+		return mismatch
+	}
+
+	// The daemon reports the oldest version it still accepts
+	if version, err := cli.ServerVersion(ctx); err == nil && version.MinAPIVersion != "" &&
+		versions.LessThan(clientVersion, version.MinAPIVersion) {
+		mismatch.Reason = fmt.Sprintf("the daemon requires API version %s or newer, raise or remove dockerAPIVersion", version.MinAPIVersion)
+		return mismatch
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// CommandError is returned when an external command such as `docker compose`
+// fails. It keeps what the command printed to stderr, which usually explains
+// the failure far better than the exit status does.
+type CommandError struct {
+	Err    error
+	Stderr string
+}
+
+func (e *CommandError) Error() string {
+	if e.Stderr == "" {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%v: %s", e.Err, e.Stderr)
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// Reason picks the line of stderr that best explains the failure, short
+// enough for the status bar. Compose prints progress output to stderr too, so
+// the last line mentioning an error is preferred over the last line overall.
+func (e *CommandError) Reason() string {
+	lines := strings.Split(e.Stderr, "\n")
+	reason := ""
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			continue
+		}
+		if strings.Contains(strings.ToLower(line), "error") {
+			return line
+		}
+		if reason == "" {
+			reason = line
+		}
+	}
+	if reason == "" {
+		return e.Err.Error()
+	}
+	return reason
+}
+
+// commandReason describes why a command failed in a single line
+func commandReason(err error) string {
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) {
+		return cmdErr.Reason()
+	}
+	return err.Error()
+}
+
+const (
+	// commandTimeout bounds commands that only look things up, such as
+	// compose ls, ps or config, so a stalled daemon or plugin can't leave an
+	// action waiting forever
+	commandTimeout = 30 * time.Second
+
+	// actionCommandTimeout bounds commands that change things, such as
+	// compose up or pull, which may have images to download first
+	actionCommandTimeout = 10 * time.Minute
+)
+
+// runCommand runs an external command that looks something up and returns
+// its stdout. Stderr is captured separately so warnings can't corrupt output
+// that gets parsed, and is included in the returned error if the command fails.
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return runCommandWithin(ctx, commandTimeout, name, args...)
+}
+
+// runActionCommand runs an external command that changes things, allowing
+// it more time than runCommand does
+func runActionCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return runCommandWithin(ctx, actionCommandTimeout, name, args...)
+}
+
+// runCommandWithin runs an external command, killing it if it hasn't
+// finished within the timeout
+func runCommandWithin(ctx context.Context, timeout time.Duration, name string, args ...string) ([]byte, error) {
+	cmdCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(cmdCtx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(cmdCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+			return stdout.Bytes(), fmt.Errorf("%s didn't finish within %s and was stopped", describeCommand(name, args), timeout)
+		}
+		return stdout.Bytes(), &CommandError{Err: err, Stderr: strings.TrimSpace(stderr.String())}
+	}
+	return stdout.Bytes(), nil
+}
+
+// composeValueFlags are the compose flags followed by a value
+var composeValueFlags = map[string]bool{
+	"-f": true, "--file": true, "-p": true, "--project-name": true,
+	"--project-directory": true, "--workdir": true,
+}
+
+// describeCommand names a command for messages by its subcommands, leaving
+// out flags and paths, e.g. "docker compose up"
+func describeCommand(name string, args []string) string {
+	words := []string{name}
+	for i := 0; i < len(args) && len(words) < 3; i++ {
+		switch {
+		case composeValueFlags[args[i]]:
+			i++
+		case !strings.HasPrefix(args[i], "-"):
+			words = append(words, args[i])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// commandOutput is the stdout of a running external command. Closing it stops
+// the command if it's still running.
+type commandOutput struct {
+	io.ReadCloser
+	ctx      context.Context
+	cmd      *exec.Cmd
+	stderr   *bytes.Buffer
+	finished atomic.Bool // stdout reached its end, so the command exited by itself
+}
+
+// Read reads the command's stdout, noting when it ends
+func (c *commandOutput) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	if errors.Is(err, io.EOF) {
+		c.finished.Store(true)
+	}
+	return n, err
+}
+
+// Close stops the command if it's still running. A command that exited by
+// itself, e.g. because the project doesn't exist, reports why it failed,
+// unless its context was cancelled.
+func (c *commandOutput) Close() error {
+	if !c.finished.Load() {
+		_ = c.cmd.Process.Kill()
+		_ = c.cmd.Wait()
+		return nil
+	}
+
+	err := c.cmd.Wait()
+	if err == nil || c.ctx.Err() != nil {
+		return nil
+	}
+	return &CommandError{Err: err, Stderr: strings.TrimSpace(c.stderr.String())}
+}
+
+// streamCommand starts an external command and returns its stdout to be read
+// as it's written, for commands that keep running such as `logs --follow`
+func streamCommand(ctx context.Context, name string, args ...string) (io.ReadCloser, error) {
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stderr = &stderr
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &commandOutput{ReadCloser: stdout, ctx: ctx, cmd: cmd, stderr: &stderr}, nil
+}
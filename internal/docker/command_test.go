@@ -0,0 +1,91 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestHelperProcess isn't a real test: it's the fake command run by the tests
+// below, which start the test binary again with GO_WANT_HELPER_PROCESS set
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+
+	switch os.Args[len(os.Args)-1] {
+	case "fail":
+		// Progress output first, as compose prints it, then the error
+		fmt.Fprintln(os.Stdout, "partial output")
+		fmt.Fprintln(os.Stderr, " Container shop-web-1  Creating")
+		fmt.Fprintln(os.Stderr, "Error response from daemon: driver failed programming external connectivity: Bind for 0.0.0.0:8080 failed: port is already allocated")
+		fmt.Fprintln(os.Stderr, " Container shop-web-1  Created")
+		os.Exit(1)
+	case "fail-quietly":
+		os.Exit(3)
+	default:
+		fmt.Fprintln(os.Stdout, "ok")
+		fmt.Fprintln(os.Stderr, "WARN: the attribute `version` is obsolete")
+	}
+	os.Exit(0)
+}
+
+// runHelper runs the fake command, making it behave as mode says
+func runHelper(t *testing.T, mode string) ([]byte, error) {
+	t.Helper()
+	t.Setenv("GO_WANT_HELPER_PROCESS", "1")
+	return runCommand(context.Background(), os.Args[0], "-test.run=TestHelperProcess", "--", mode)
+}
+
+func TestRunCommandFailure(t *testing.T) {
+	output, err := runHelper(t, "fail")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if string(output) != "partial output\n" {
+		t.Errorf("stdout = %q, want only what was printed to stdout", output)
+	}
+
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) {
+		t.Fatalf("error %T isn't a *CommandError", err)
+	}
+	if !strings.Contains(err.Error(), "port is already allocated") {
+		t.Errorf("Error() = %q, want it to include stderr", err.Error())
+	}
+
+	// The line mentioning the error wins over the last line of progress
+	want := "Error response from daemon: driver failed programming external connectivity: Bind for 0.0.0.0:8080 failed: port is already allocated"
+	if reason := commandReason(err); reason != want {
+		t.Errorf("commandReason() = %q, want %q", reason, want)
+	}
+}
+
+func TestRunCommandFailureWithoutStderr(t *testing.T) {
+	_, err := runHelper(t, "fail-quietly")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if reason := commandReason(err); reason != "exit status 3" {
+		t.Errorf("commandReason() = %q, want the exit status", reason)
+	}
+}
+
+func TestRunCommandWarningsStayOutOfStdout(t *testing.T) {
+	output, err := runHelper(t, "succeed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(output) != "ok\n" {
+		t.Errorf("stdout = %q, want %q", output, "ok\n")
+	}
+}
+
+func TestCommandReasonOfOtherErrors(t *testing.T) {
+	if reason := commandReason(errors.New("not a command error")); reason != "not a command error" {
+		t.Errorf("commandReason() = %q", reason)
+	}
+}
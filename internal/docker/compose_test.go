@@ -0,0 +1,90 @@
+package docker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestListComposeServices(t *testing.T) {
+	tests := []struct {
+		name    string
+		compose string
+		want    []string
+		wantErr bool
+	}{
+		{"services", "services:\n  web:\n    image: nginx\n  db:\n    image: postgres\n", []string{"db", "web"}, false},
+		{"empty services", "services: {}\n", nil, false},
+		{"no services section", "volumes:\n  data: {}\n", nil, true},
+		{"empty file", "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte(tt.compose), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			services, err := (&Service{}).ListComposeServices(context.Background(), dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ListComposeServices() error = %v, want error %v", err, tt.wantErr)
+			}
+			var names []string
+			for _, service := range services {
+				names = append(names, service.Name)
+			}
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("ListComposeServices() = %q, want %q", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestListComposeServicesMissingPath(t *testing.T) {
+	services, err := (&Service{}).ListComposeServices(context.Background(), filepath.Join(t.TempDir(), "missing"))
+	if err == nil {
+		t.Fatal("ListComposeServices() of a missing path succeeded")
+	}
+	if len(services) != 0 {
+		t.Errorf("ListComposeServices() of a missing path = %v, want none", services)
+	}
+}
+
+func TestParseComposeTextOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []ContainerInfo
+	}{
+		{"empty", "", nil},
+		{"header only", "NAME   IMAGE   COMMAND   SERVICE   CREATED   STATUS   PORTS\n", nil},
+		{
+			"containers",
+			"ID             NAME          STATUS\n" +
+				"0123456789abcdef   test_web_1   Up 2 hours\n" +
+				"fedcba9876543210   test_db_1    Exited (0) 3 minutes ago\n",
+			[]ContainerInfo{
+				{ID: "0123456789ab", Name: "test_web_1 (web)", Status: "Up 2 hours", State: "running"},
+				{ID: "fedcba987654", Name: "test_db_1 (db)", Status: "Exited (0) 3 minutes ago", State: "exited"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := (&Service{}).parseComposeTextOutput([]byte(tt.output))
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseComposeTextOutput() returned %d containers, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, c := range got {
+				want := tt.want[i]
+				if c.ID != want.ID || c.Name != want.Name || c.Status != want.Status || c.State != want.State || c.Image != "" {
+					t.Errorf("container %d = %+v, want %+v", i, c, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package docker
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// composeBaseFiles are the names compose looks for, in order of preference
+var composeBaseFiles = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// DefaultComposeSearchDepth is how many directories below the search root
+// compose projects are looked for, unless configured otherwise
+const DefaultComposeSearchDepth = 3
+
+// ComposeFile is a compose file found in a project directory
+type ComposeFile struct {
+	Path     string
+	Override bool // false for the base file, which is always used
+	Active   bool
+}
+
+// ComposeFiles lists the compose files of a project directory: the base file
+// first, then the files that can be layered on top of it, such as
+// docker-compose.override.yml or docker-compose.prod.yml. Like compose
+// itself, the .override file is active unless turned off; other variants
+// only when turned on. It returns nil if the directory has no base file.
+func (s *Service) ComposeFiles(projectPath string) []ComposeFile {
+	base := ""
+	for _, name := range composeBaseFiles {
+		if _, err := os.Stat(filepath.Join(projectPath, name)); err == nil {
+			base = name
+			break
+		}
+	}
+	if base == "" {
+		return nil
+	}
+
+	files := []ComposeFile{{Path: filepath.Join(projectPath, base), Active: true}}
+
+	// Overrides share the base file's stem, e.g. docker-compose.<variant>.yml
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+	var overrides []string
+	for _, pattern := range []string{stem + ".*.yml", stem + ".*.yaml"} {
+		matches, _ := filepath.Glob(filepath.Join(projectPath, pattern))
+		overrides = append(overrides, matches...)
+	}
+	sort.Strings(overrides)
+
+	s.mu.RLock()
+	toggled := s.overrides[projectPath]
+	s.mu.RUnlock()
+
+	for _, path := range overrides {
+		name := filepath.Base(path)
+		active, ok := toggled[name]
+		if !ok {
+			active = strings.HasPrefix(name, stem+".override.")
+		}
+		files = append(files, ComposeFile{Path: path, Override: true, Active: active})
+	}
+	return files
+}
+
+// SetComposeOverride turns an override file of a project on or off for every
+// compose command run afterwards
+func (s *Service) SetComposeOverride(projectPath, file string, active bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.overrides == nil {
+		s.overrides = make(map[string]map[string]bool)
+	}
+	if s.overrides[projectPath] == nil {
+		s.overrides[projectPath] = make(map[string]bool)
+	}
+	s.overrides[projectPath][filepath.Base(file)] = active
+}
+
+// activeComposeFiles returns the paths of the compose files in use for a project
+func (s *Service) activeComposeFiles(projectPath string) []string {
+	var paths []string
+	for _, file := range s.ComposeFiles(projectPath) {
+		if file.Active {
+			paths = append(paths, file.Path)
+		}
+	}
+	return paths
+}
+
+// composeArgs builds the arguments of a `docker compose` command for a
+// project, passing its active compose files explicitly. Without any, compose
+// finds the files in the project directory itself.
+func (s *Service) composeArgs(projectPath string, args ...string) []string {
+	composeArgs := []string{"compose", "--project-directory", projectPath}
+	for _, path := range s.activeComposeFiles(projectPath) {
+		composeArgs = append(composeArgs, "-f", path)
+	}
+	return append(composeArgs, args...)
+}
+
+// SetComposeSearch sets where compose projects that aren't running are
+// looked for: in the working directory and the given paths, and the
+// directories up to depth levels below them. A leading ~ in a path stands
+// for the home directory.
+func (s *Service) SetComposeSearch(paths []string, depth int) {
+	roots := []string{"."}
+	for _, path := range paths {
+		roots = append(roots, ExpandHome(path))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.composeRoots = roots
+	s.composeDepth = depth
+}
+
+// ExpandHome replaces a leading ~ in a path with the home directory
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
+// findComposeDirs returns the directories holding a compose file, searching
+// each root and the directories up to depth levels below it. Directories
+// reached from several roots are only listed once.
+func findComposeDirs(ctx context.Context, roots []string, depth int) []string {
+	var dirs []string
+	seen := make(map[string]bool)
+	for _, root := range roots {
+		for _, dir := range findComposeDirsIn(ctx, root, depth) {
+			abs, err := filepath.Abs(dir)
+			if err != nil {
+				abs = dir
+			}
+			if !seen[abs] {
+				seen[abs] = true
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	return dirs
+}
+
+// findComposeDirsIn returns the directories holding a compose file, searching
+// root and the directories up to depth levels below it. Hidden directories
+// and node_modules are skipped, as are directories that can't be read.
+func findComposeDirsIn(ctx context.Context, root string, depth int) []string {
+	var dirs []string
+	filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if ctx.Err() != nil {
+			return filepath.SkipAll
+		}
+		if err != nil {
+			return nil
+		}
+
+		if !entry.IsDir() {
+			dir := filepath.Dir(path)
+			if slices.Contains(composeBaseFiles, entry.Name()) && !slices.Contains(dirs, dir) {
+				dirs = append(dirs, dir)
+			}
+			return nil
+		}
+
+		if path == root {
+			return nil
+		}
+		if name := entry.Name(); strings.HasPrefix(name, ".") || name == "node_modules" {
+			return filepath.SkipDir
+		}
+		if rel, err := filepath.Rel(root, path); err != nil || strings.Count(rel, string(filepath.Separator)) >= depth {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	return dirs
+}
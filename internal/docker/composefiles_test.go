@@ -0,0 +1,103 @@
+package docker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// writeTree creates the given files, with their directories, under root
+func writeTree(t *testing.T, root string, files ...string) {
+	t.Helper()
+	for _, file := range files {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("services: {}\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// relDirs returns dirs relative to root, with forward slashes, sorted
+func relDirs(t *testing.T, root string, dirs []string) []string {
+	t.Helper()
+	rel := make([]string, len(dirs))
+	for i, dir := range dirs {
+		r, err := filepath.Rel(root, dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rel[i] = filepath.ToSlash(r)
+	}
+	slices.Sort(rel)
+	return rel
+}
+
+func TestFindComposeDirsIn(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root,
+		"compose.yaml",
+		"shop/docker-compose.yml",
+		"shop/README.md",
+		"apps/blog/compose.yml",
+		"apps/blog/docker-compose.yaml",
+		"apps/api/v2/deep/compose.yaml",
+		".git/compose.yaml",
+		"web/node_modules/pkg/compose.yaml",
+	)
+
+	tests := []struct {
+		depth int
+		want  []string
+	}{
+		{0, []string{"."}},
+		{1, []string{".", "shop"}},
+		{2, []string{".", "apps/blog", "shop"}},
+		{4, []string{".", "apps/api/v2/deep", "apps/blog", "shop"}},
+	}
+	for _, tt := range tests {
+		got := relDirs(t, root, findComposeDirsIn(context.Background(), root, tt.depth))
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("depth %d: got %v, want %v", tt.depth, got, tt.want)
+		}
+	}
+}
+
+func TestFindComposeDirsInSymlinks(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	writeTree(t, outside, "elsewhere/compose.yaml")
+	writeTree(t, root, "linked/placeholder")
+
+	// A symlinked directory isn't followed, so a link back up can't loop
+	if err := os.Symlink(filepath.Join(outside, "elsewhere"), filepath.Join(root, "dirlink")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	if err := os.Symlink(root, filepath.Join(root, "linked", "loop")); err != nil {
+		t.Fatal(err)
+	}
+	// A symlinked compose file counts like any other
+	if err := os.Symlink(filepath.Join(outside, "elsewhere", "compose.yaml"), filepath.Join(root, "linked", "compose.yaml")); err != nil {
+		t.Fatal(err)
+	}
+
+	got := relDirs(t, root, findComposeDirsIn(context.Background(), root, 5))
+	if want := []string{"linked"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFindComposeDirsInCancelled(t *testing.T) {
+	root := t.TempDir()
+	writeTree(t, root, "compose.yaml", "a/compose.yaml")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if got := findComposeDirsIn(ctx, root, 3); len(got) != 0 {
+		t.Errorf("got %v after cancelling, want nothing", got)
+	}
+}
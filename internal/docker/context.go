@@ -0,0 +1,158 @@
+package docker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/client"
+)
+
+// DockerContext represents a Docker CLI context (see `docker context ls`)
+type DockerContext struct {
+	Name        string
+	Description string
+	Host        string
+	Current     bool
+
+	tlsDir        string // the context's ca.pem, cert.pem and key.pem, if it has any
+	skipTLSVerify bool
+}
+
+// ListContexts returns the Docker CLI contexts available to the user, read
+// from the CLI's context store. `docker context ls` is only asked when the
+// store can't be read.
+func ListContexts(ctx context.Context) ([]DockerContext, error) {
+	contexts, storeErr := readContextStore()
+	if storeErr == nil {
+		return contexts, nil
+	}
+
+	output, err := runCommand(ctx, "docker", "context", "ls", "--format", "{{json .}}")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Docker contexts: %v", storeErr)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		// Each line is a JSON object describing one context
+		var entry struct {
+			Name           string
+			Description    string
+			DockerEndpoint string
+			Current        bool
+		}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			continue
+		}
+
+		contexts = append(contexts, DockerContext{
+			Name:        entry.Name,
+			Description: entry.Description,
+			Host:        entry.DockerEndpoint,
+			Current:     entry.Current,
+		})
+	}
+
+	return contexts, nil
+}
+
+// readContextStore reads the contexts the Docker CLI keeps under
+// ~/.docker/contexts, plus the implicit "default" context. The TLS material
+// of each is under contexts/tls, in a directory named like its metadata's.
+func readContextStore() ([]DockerContext, error) {
+	dir, err := dockerConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Docker contexts: %v", err)
+	}
+
+	current := os.Getenv("DOCKER_CONTEXT")
+	if current == "" {
+		if data, err := os.ReadFile(filepath.Join(dir, "config.json")); err == nil {
+			var cfg dockerConfigFile
+			if json.Unmarshal(data, &cfg) == nil {
+				current = cfg.CurrentContext
+			}
+		}
+	}
+	if current == "" {
+		current = "default"
+	}
+
+	defaultHost := os.Getenv(client.EnvOverrideHost)
+	if defaultHost == "" {
+		defaultHost = client.DefaultDockerHost
+	}
+	contexts := []DockerContext{{
+		Name:        "default",
+		Description: "Current DOCKER_HOST based configuration",
+		Host:        defaultHost,
+	}}
+
+	// Each context lives in a directory named after the digest of its name
+	files, err := filepath.Glob(filepath.Join(dir, "contexts", "meta", "*", "meta.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Docker contexts: %v", err)
+	}
+	var stored []DockerContext
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var meta struct {
+			Name     string
+			Metadata struct {
+				Description string
+			}
+			Endpoints map[string]struct {
+				Host          string
+				SkipTLSVerify bool
+			}
+		}
+		if err := json.Unmarshal(data, &meta); err != nil || meta.Name == "" {
+			continue
+		}
+		endpoint := meta.Endpoints["docker"]
+		stored = append(stored, DockerContext{
+			Name:          meta.Name,
+			Description:   meta.Metadata.Description,
+			Host:          endpoint.Host,
+			tlsDir:        filepath.Join(dir, "contexts", "tls", filepath.Base(filepath.Dir(file)), "docker"),
+			skipTLSVerify: endpoint.SkipTLSVerify,
+		})
+	}
+	sort.Slice(stored, func(i, j int) bool { return stored[i].Name < stored[j].Name })
+	contexts = append(contexts, stored...)
+
+	for i := range contexts {
+		contexts[i].Current = contexts[i].Name == current
+	}
+	return contexts, nil
+}
+
+// contextForHost returns the stored context whose Docker endpoint is host,
+// if there is one
+func contextForHost(host string) (DockerContext, bool) {
+	contexts, err := readContextStore()
+	if err != nil {
+		return DockerContext{}, false
+	}
+	for _, c := range contexts {
+		if c.Name != "default" && c.Host == host {
+			return c, true
+		}
+	}
+	return DockerContext{}, false
+}
@@ -0,0 +1,76 @@
+package docker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeContext stores a Docker context the way the CLI does, under a
+// directory standing in for the digest of its name
+func writeContext(t *testing.T, configDir, digest, meta string) {
+	t.Helper()
+	dir := filepath.Join(configDir, "contexts", "meta", digest)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "meta.json"), []byte(meta), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadContextStore(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", configDir)
+	t.Setenv("DOCKER_CONTEXT", "remote")
+	t.Setenv("DOCKER_HOST", "")
+	writeContext(t, configDir, "b1", `{"Name":"remote","Metadata":{"Description":"build box"},"Endpoints":{"docker":{"Host":"tcp://build:2376","SkipTLSVerify":true}}}`)
+	writeContext(t, configDir, "a1", `{"Name":"lab","Endpoints":{"docker":{"Host":"ssh://me@lab"}}}`)
+
+	contexts, err := readContextStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, c := range contexts {
+		names = append(names, c.Name)
+	}
+	if len(contexts) != 3 || names[0] != "default" || names[1] != "lab" || names[2] != "remote" {
+		t.Fatalf("readContextStore() = %q, want default, lab and remote", names)
+	}
+
+	remote := contexts[2]
+	if !remote.Current || remote.Host != "tcp://build:2376" || remote.Description != "build box" || !remote.skipTLSVerify {
+		t.Errorf("remote context = %+v", remote)
+	}
+	if want := filepath.Join(configDir, "contexts", "tls", "b1", "docker"); remote.tlsDir != want {
+		t.Errorf("remote context TLS dir = %s, want %s", remote.tlsDir, want)
+	}
+}
+
+func TestTLSFor(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("DOCKER_CONFIG", configDir)
+	t.Setenv("DOCKER_HOST", "tcp://env:2376")
+	t.Setenv("DOCKER_TLS_VERIFY", "1")
+	t.Setenv("DOCKER_CERT_PATH", filepath.Join(configDir, "missing"))
+	writeContext(t, configDir, "b1", `{"Name":"insecure","Endpoints":{"docker":{"Host":"tcp://insecure:2376","SkipTLSVerify":true}}}`)
+	writeContext(t, configDir, "c1", `{"Name":"plain","Endpoints":{"docker":{"Host":"tcp://plain:2375"}}}`)
+
+	// The environment's TLS only applies to the environment's host
+	if _, err := (ClientOptions{}).tlsFor("tcp://env:2376"); err == nil {
+		t.Error("tlsFor() of the environment's host ignored its missing certificates")
+	}
+
+	opt, err := ClientOptions{}.tlsFor("tcp://insecure:2376")
+	if err != nil || opt == nil {
+		t.Errorf("tlsFor() of a context skipping verification = %v, %v, want a TLS option", opt, err)
+	}
+
+	for _, host := range []string{"tcp://plain:2375", "tcp://unknown:2375"} {
+		opt, err := ClientOptions{}.tlsFor(host)
+		if err != nil || opt != nil {
+			t.Errorf("tlsFor(%s) = %v, %v, want no TLS", host, opt, err)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package docker
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types"
+)
+
+// DiskUsageCategory is the space used by one kind of resource
+type DiskUsageCategory struct {
+	Total       int   // resources of this kind
+	Active      int   // resources in use
+	Size        int64 // bytes used
+	Reclaimable int64 // bytes freed by pruning the unused ones
+}
+
+// DiskUsage is the space Docker uses on the host, as reported by `docker system df`
+type DiskUsage struct {
+	Images     DiskUsageCategory
+	Containers DiskUsageCategory
+	Volumes    DiskUsageCategory
+	BuildCache DiskUsageCategory
+}
+
+// Total returns the combined size and reclaimable space of all categories
+func (d DiskUsage) Total() (size, reclaimable int64) {
+	for _, category := range []DiskUsageCategory{d.Images, d.Containers, d.Volumes, d.BuildCache} {
+		size += category.Size
+		reclaimable += category.Reclaimable
+	}
+	return size, reclaimable
+}
+
+// DiskUsage returns how much space images, containers, volumes and the build
+// cache take up, and how much of it could be reclaimed. The daemon has to
+// walk every layer and volume, so this can take a while on busy hosts.
+func (s *Service) DiskUsage(ctx context.Context) (DiskUsage, error) {
+	du, err := s.cli().DiskUsage(ctx, types.DiskUsageOptions{})
+	if err != nil {
+		return DiskUsage{}, err
+	}
+
+	var usage DiskUsage
+
+	// Layers shared with other images stay in use as long as one of them is
+	usage.Images.Total = len(du.Images)
+	usage.Images.Size = du.LayersSize
+	var imagesInUse int64
+	for _, img := range du.Images {
+		if img.Containers <= 0 {
+			continue
+		}
+		usage.Images.Active++
+		if img.Size != -1 && img.SharedSize != -1 {
+			imagesInUse += img.Size - img.SharedSize
+		}
+	}
+	usage.Images.Reclaimable = max(0, usage.Images.Size-imagesInUse)
+
+	usage.Containers.Total = len(du.Containers)
+	for _, c := range du.Containers {
+		usage.Containers.Size += c.SizeRw
+		if c.State == "running" || c.State == "paused" || c.State == "restarting" {
+			usage.Containers.Active++
+		} else {
+			usage.Containers.Reclaimable += c.SizeRw
+		}
+	}
+
+	usage.Volumes.Total = len(du.Volumes)
+	for _, v := range du.Volumes {
+		if v.UsageData == nil || v.UsageData.Size == -1 {
+			continue
+		}
+		usage.Volumes.Size += v.UsageData.Size
+		if v.UsageData.RefCount > 0 {
+			usage.Volumes.Active++
+		} else {
+			usage.Volumes.Reclaimable += v.UsageData.Size
+		}
+	}
+
+	usage.BuildCache.Total = len(du.BuildCache)
+	for _, record := range du.BuildCache {
+		if record.InUse {
+			usage.BuildCache.Active++
+		}
+		if record.Shared {
+			continue
+		}
+		usage.BuildCache.Size += record.Size
+		if !record.InUse {
+			usage.BuildCache.Reclaimable += record.Size
+		}
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,10 @@
+package docker
+
+// ShortID returns the first n characters of a container, image or network
+// ID, or the whole ID if it's shorter, so a malformed ID can't cause a panic
+func ShortID(id string, n int) string {
+	if len(id) <= n {
+		return id
+	}
+	return id[:n]
+}
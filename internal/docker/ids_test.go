@@ -0,0 +1,31 @@
+package docker
+
+import "testing"
+
+func TestShortID(t *testing.T) {
+	full := "4f66ad9a0b2e8c1d7e3f5a6b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d"
+
+	tests := []struct {
+		name string
+		id   string
+		n    int
+		want string
+	}{
+		{"empty", "", 12, ""},
+		{"shorter than n", "4f66ad", 12, "4f66ad"},
+		{"exactly n", "4f66ad9a0b2e", 12, "4f66ad9a0b2e"},
+		{"full ID", full, 12, "4f66ad9a0b2e"},
+		{"zero length", full, 0, ""},
+		// The prefix isn't special; callers trim it first to get the hex part
+		{"sha256 prefix", "sha256:" + full, 12, "sha256:4f66a"},
+		{"sha256 prefix only", "sha256:", 12, "sha256:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShortID(tt.id, tt.n); got != tt.want {
+				t.Errorf("ShortID(%q, %d) = %q, want %q", tt.id, tt.n, got, tt.want)
+			}
+		})
+	}
+}
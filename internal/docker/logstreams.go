@@ -0,0 +1,93 @@
+package docker
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// LogStreams selects which of a container's output streams logs are read from
+type LogStreams int
+
+const (
+	LogsBoth LogStreams = iota
+	LogsStdout
+	LogsStderr
+)
+
+// String names the streams for the status bar
+func (l LogStreams) String() string {
+	switch l {
+	case LogsStdout:
+		return "stdout"
+	case LogsStderr:
+		return "stderr"
+	default:
+		return "stdout and stderr"
+	}
+}
+
+// Next returns the streams to switch to after l: both, stdout, then stderr
+func (l LogStreams) Next() LogStreams {
+	return (l + 1) % 3
+}
+
+func (l LogStreams) showStdout() bool { return l != LogsStderr }
+func (l LogStreams) showStderr() bool { return l != LogsStdout }
+
+// LogWindow limits logs to the lines written within a time range. A zero
+// Since or Until leaves that end of the range open.
+type LogWindow struct {
+	Since, Until time.Time
+}
+
+// IsZero reports whether the window lets every line through
+func (w LogWindow) IsZero() bool {
+	return w.Since.IsZero() && w.Until.IsZero()
+}
+
+// LogOptions selects the logs StreamContainerLogs reads. The zero value
+// reads the full log of both streams, without timestamps.
+type LogOptions struct {
+	Tail       int // start from the last Tail lines, or all of them if zero
+	Timestamps bool
+	Streams    LogStreams
+	Window     LogWindow // only lines written within it; a followed stream ends at its Until
+}
+
+// logsTime formats a time the way the logs Since and Until options take it,
+// as a Unix timestamp, or an empty string for an open end
+func logsTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%d.%09d", t.Unix(), t.Nanosecond())
+}
+
+// copyLogs copies a container's log stream to w. Without a TTY, stdout and
+// stderr are multiplexed into a single stream with a binary header before
+// every chunk, which is stripped here. A TTY container's output is one raw
+// stream, all of it reported as stdout.
+func copyLogs(w io.Writer, logs io.Reader, tty bool) error {
+	var err error
+	if tty {
+		_, err = io.Copy(w, logs)
+	} else {
+		_, err = stdcopy.StdCopy(w, w, logs)
+	}
+	return err
+}
+
+// logReader reads a container's demultiplexed logs. Closing it closes the
+// daemon's stream too, so a followed stream waiting for output ends at once.
+type logReader struct {
+	*io.PipeReader
+	logs io.Closer
+}
+
+func (r logReader) Close() error {
+	r.PipeReader.Close()
+	return r.logs.Close()
+}
@@ -0,0 +1,153 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/registry"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+// PullImage pulls an image, calling progress with a summary of every layer's
+// status as the download goes on. progress may be nil. Credentials from
+// `docker login` are used for private repositories.
+func (s *Service) PullImage(ctx context.Context, ref string, progress func(status string)) error {
+	auth, err := RegistryAuth(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	reader, err := s.cli().ImagePull(ctx, ref, image.PullOptions{RegistryAuth: auth})
+	if err != nil {
+		return registryError("pull", ref, err)
+	}
+	defer reader.Close()
+
+	// The pull only completes once its progress stream has been read
+	if err := decodeProgress(reader, progress); err != nil {
+		return registryError("pull", ref, err)
+	}
+	return nil
+}
+
+// SearchImages searches Docker Hub, or the registry named at the start of
+// term, for up to limit images matching term, the most starred first
+func (s *Service) SearchImages(ctx context.Context, term string, limit int) ([]registry.SearchResult, error) {
+	// Logged-in searches are rate limited less; a term that isn't an image
+	// name is searched anonymously
+	auth, _ := RegistryAuth(ctx, term)
+
+	results, err := s.cli().ImageSearch(ctx, term, registry.SearchOptions{RegistryAuth: auth, Limit: limit})
+	if err != nil {
+		return nil, registryError("search for", term, err)
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].StarCount > results[j].StarCount
+	})
+	return results, nil
+}
+
+// TagImage gives an image an additional repository and tag
+func (s *Service) TagImage(ctx context.Context, source, target string) error {
+	if err := s.cli().ImageTag(ctx, source, target); err != nil {
+		return fmt.Errorf("failed to tag %s as %s: %w", source, target, err)
+	}
+	return nil
+}
+
+// PushImage pushes an image to its registry using the given encoded auth
+// (see RegistryAuth), calling progress with a summary of every layer's status
+// as the upload goes on. progress may be nil.
+func (s *Service) PushImage(ctx context.Context, ref string, auth string, progress func(status string)) error {
+	reader, err := s.cli().ImagePush(ctx, ref, image.PushOptions{RegistryAuth: auth})
+	if err != nil {
+		return registryError("push", ref, err)
+	}
+	defer reader.Close()
+
+	if err := decodeProgress(reader, progress); err != nil {
+		return registryError("push", ref, err)
+	}
+	return nil
+}
+
+// HasImage reports whether an image is available locally
+func (s *Service) HasImage(ctx context.Context, ref string) bool {
+	_, err := s.cli().ImageInspect(ctx, ref)
+	return err == nil
+}
+
+// decodeProgress reads a pull or push progress stream until it ends, keeping
+// the latest status of each layer and reporting them in the order they appeared
+func decodeProgress(r io.Reader, progress func(status string)) error {
+	var (
+		order  []string
+		layers = make(map[string]string)
+		header string
+	)
+
+	decoder := json.NewDecoder(r)
+	for {
+		var msg jsonmessage.JSONMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if msg.Error != nil {
+			return msg.Error
+		}
+		if progress == nil {
+			continue
+		}
+
+		status := msg.Status
+		if msg.Progress != nil {
+			if bar := msg.Progress.String(); bar != "" {
+				status += " " + bar
+			}
+		}
+
+		// Messages without an ID describe the whole image rather than a layer
+		if msg.ID == "" {
+			header = status
+		} else {
+			if _, ok := layers[msg.ID]; !ok {
+				order = append(order, msg.ID)
+			}
+			layers[msg.ID] = status
+		}
+
+		lines := make([]string, 0, len(order)+1)
+		if header != "" {
+			lines = append(lines, header)
+		}
+		for _, id := range order {
+			lines = append(lines, fmt.Sprintf("%s: %s", id, layers[id]))
+		}
+		progress(strings.Join(lines, "\n"))
+	}
+}
+
+// registryError explains the errors users can act on when talking to a registry
+func registryError(action, ref string, err error) error {
+	message := strings.ToLower(err.Error())
+	if errdefs.IsUnauthorized(err) || errdefs.IsForbidden(err) ||
+		strings.Contains(message, "unauthorized") ||
+		strings.Contains(message, "authentication required") ||
+		strings.Contains(message, "access denied") {
+		return fmt.Errorf("not allowed to %s %s: the registry needs you to log in (`docker login`), or the repository doesn't exist: %w", action, ref, err)
+	}
+	if strings.Contains(message, "toomanyrequests") || strings.Contains(message, "too many requests") {
+		return fmt.Errorf("couldn't %s %s: the registry is rate limiting requests, try again in a while or log in (`docker login`) for a higher limit: %w", action, ref, err)
+	}
+	return fmt.Errorf("failed to %s %s: %w", action, ref, err)
+}
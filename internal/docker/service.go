@@ -1,30 +1,54 @@
 package docker
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/versions"
 	"github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+	"github.com/docker/go-connections/nat"
 	"gopkg.in/yaml.v3"
 )
 
 // Service provides methods for interacting with Docker
 type Service struct {
-	client *client.Client
+	mu      sync.RWMutex
+	client  *client.Client
+	host    string // the endpoint as requested, e.g. ssh://user@host
+	options ClientOptions
+
+	// overrides holds the compose override files turned on or off per project
+	// directory, by file name; files not in it use their default
+	overrides map[string]map[string]bool
+
+	// composeRoots and composeDepth bound the search for compose projects
+	// that aren't running, see SetComposeSearch
+	composeRoots []string
+	composeDepth int
 }
 
 // ContainerInfo represents the container data we're interested in displaying
@@ -37,6 +61,8 @@ type ContainerInfo struct {
 	State   string
 	Created time.Time
 	Ports   []types.Port
+	Labels  map[string]string
+	Health  string // healthy, unhealthy or starting; empty without a healthcheck
 }
 
 // Port represents a port mapping
@@ -127,6 +153,10 @@ type ComposeServiceInfo struct {
 	Status      string   `json:"status"`
 	Image       string   `json:"image"`
 	Ports       []string `json:"ports"`
+	Environment []string `json:"environment"` // KEY=value, sorted by key
+	DependsOn   []string `json:"dependsOn"`
+	Volumes     []string `json:"volumes"`
+	Networks    []string `json:"networks"`
 	Containers  []string `json:"containers"`
 	CPU         float64  `json:"cpu"`
 	Memory      int64    `json:"memory"`
@@ -139,7 +169,11 @@ type DockerEvent struct {
 	Action   string
 	ID       string
 	Time     time.Time
-	Resource string
+	Resource string // name of the container, image, volume or network
+
+	// Attributes holds the details Docker attaches to the event, such as
+	// the exit code of a container that died
+	Attributes map[string]string
 }
 
 // EventCallback is a function type that gets called when a Docker event occurs
@@ -163,24 +197,134 @@ type SystemInfo struct {
 // NewService creates a new Docker service with a given client
 func NewService(client *client.Client) *Service {
 	return &Service{
-		client: client,
+		client:       client,
+		composeRoots: []string{"."},
+		composeDepth: DefaultComposeSearchDepth,
 	}
 }
 
-// NewDockerService creates a new Docker service with the default client
-func NewDockerService() (*Service, error) {
-	// Initialize Docker client with default options
-	dockerClient, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+// NewDockerService creates a new Docker service with a client configured
+// from the environment and the given options
+func NewDockerService(options ClientOptions) (*Service, error) {
+	dockerClient, err := options.newClient("")
 	if err != nil {
 		return nil, err
 	}
 
-	return NewService(dockerClient), nil
+	service := NewService(dockerClient)
+	service.host = options.Host
+	if service.host == "" {
+		service.host = os.Getenv(client.EnvOverrideHost)
+	}
+	service.options = options
+	return service, nil
+}
+
+// NewDockerServiceForHost creates a new Docker service talking to the given
+// daemon endpoint (e.g. unix:///var/run/docker.sock or tcp://host:2376)
+// instead of the one from the environment. The host of a Docker context is
+// reached with the context's TLS material.
+func NewDockerServiceForHost(host string) (*Service, error) {
+	return ClientOptions{}.serviceForHost(host)
+}
+
+// serviceForHost creates a service talking to host with these options, as
+// NewDockerServiceForHost does with the default ones
+func (o ClientOptions) serviceForHost(host string) (*Service, error) {
+	dockerClient, err := o.newClient(host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", host, err)
+	}
+
+	service := NewService(dockerClient)
+	service.host = host
+	service.options = o
+	return service, nil
+}
+
+// cli returns the Docker client currently in use. The client can be swapped at
+// runtime (e.g. when switching contexts), so always go through this accessor.
+func (s *Service) cli() *client.Client {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.client
+}
+
+// Host returns the daemon endpoint the service is connected to
+func (s *Service) Host() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.host != "" {
+		return s.host
+	}
+	return s.client.DaemonHost()
+}
+
+// IsRemote reports whether the service is connected to a daemon on another machine
+func (s *Service) IsRemote() bool {
+	return IsRemoteHost(s.Host())
+}
+
+// IsRemoteHost reports whether a daemon endpoint points to another machine.
+// Unix sockets, named pipes and loopback addresses are considered local.
+func IsRemoteHost(host string) bool {
+	u, err := url.Parse(host)
+	if err != nil {
+		return false
+	}
+
+	switch u.Scheme {
+	case "", "unix", "npipe":
+		return false
+	}
+
+	hostname := u.Hostname()
+	if hostname == "localhost" {
+		return false
+	}
+	if ip := net.ParseIP(hostname); ip != nil && ip.IsLoopback() {
+		return false
+	}
+	return true
+}
+
+// SwitchHost reconnects the service to a different Docker daemon endpoint.
+// The current connection is kept if the new endpoint can't be reached.
+func (s *Service) SwitchHost(ctx context.Context, host string) error {
+	// Built like NewDockerServiceForHost, keeping the pinned API version
+	next, err := s.options.serviceForHost(host)
+	if err != nil {
+		return err
+	}
+	newClient := next.client
+
+	// Make sure the new daemon actually responds before switching over
+	if _, err := newClient.Ping(ctx); err != nil {
+		newClient.Close()
+		return fmt.Errorf("failed to connect to %s: %w", host, err)
+	}
+
+	s.mu.Lock()
+	oldClient := s.client
+	s.client = newClient
+	s.host = host
+	s.mu.Unlock()
+
+	if oldClient != nil {
+		oldClient.Close()
+	}
+	return nil
+}
+
+// Reconnect recreates the Docker client for the current endpoint, e.g. after
+// the daemon has been restarted and the old connection has gone stale
+func (s *Service) Reconnect(ctx context.Context) error {
+	return s.SwitchHost(ctx, s.Host())
 }
 
 // ListContainers returns a list of all containers
 func (s *Service) ListContainers(ctx context.Context, all bool) ([]ContainerInfo, error) {
-	containers, err := s.client.ContainerList(ctx, container.ListOptions{All: all})
+	containers, err := s.cli().ContainerList(ctx, container.ListOptions{All: all})
 	if err != nil {
 		return nil, err
 	}
@@ -192,10 +336,7 @@ func (s *Service) ListContainers(ctx context.Context, all bool) ([]ContainerInfo
 			name = c.Names[0][1:] // Remove leading slash
 		}
 
-		id := c.ID
-		if len(id) > 12 {
-			id = id[:12] // Short ID
-		}
+		id := ShortID(c.ID, 12)
 
 		containerInfos = append(containerInfos, ContainerInfo{
 			ID:      id,
@@ -206,15 +347,32 @@ func (s *Service) ListContainers(ctx context.Context, all bool) ([]ContainerInfo
 			State:   c.State,
 			Created: time.Unix(c.Created, 0),
 			Ports:   c.Ports,
+			Labels:  c.Labels,
+			Health:  healthFromStatus(c.Status),
 		})
 	}
 
 	return containerInfos, nil
 }
 
+// healthFromStatus reads the health of a container from its status, such as
+// "Up 2 hours (healthy)". The daemon only adds it for containers with a
+// healthcheck, so no inspect call is needed to tell them apart.
+func healthFromStatus(status string) string {
+	switch {
+	case strings.HasSuffix(status, "(healthy)"):
+		return "healthy"
+	case strings.HasSuffix(status, "(unhealthy)"):
+		return "unhealthy"
+	case strings.HasSuffix(status, "(health: starting)"):
+		return "starting"
+	}
+	return ""
+}
+
 // GetContainerStats returns the stats for a container
 func (s *Service) GetContainerStats(ctx context.Context, containerID string) (map[string]interface{}, error) {
-	stats, err := s.client.ContainerStats(ctx, containerID, false)
+	stats, err := s.cli().ContainerStats(ctx, containerID, false)
 	if err != nil {
 		return nil, err
 	}
@@ -230,7 +388,7 @@ func (s *Service) GetContainerStats(ctx context.Context, containerID string) (ma
 
 // GetContainerStatsStream returns a stream of stats for a container
 func (s *Service) GetContainerStatsStream(ctx context.Context, containerID string) (io.ReadCloser, error) {
-	stats, err := s.client.ContainerStats(ctx, containerID, true)
+	stats, err := s.cli().ContainerStats(ctx, containerID, true)
 	if err != nil {
 		return nil, err
 	}
@@ -249,6 +407,78 @@ type ContainerStats struct {
 	BlockWrite       int64
 }
 
+// maxStatsRequests limits how many stats requests are made at once when
+// sampling several containers
+const maxStatsRequests = 8
+
+// AggregateStats sums the CPU and memory usage of the given containers. It
+// returns the totals and how many containers could be sampled; containers
+// whose stats can't be read are skipped.
+func (s *Service) AggregateStats(ctx context.Context, containerIDs []string) (ContainerStats, int) {
+	var total ContainerStats
+	all := s.processedStatsOf(ctx, containerIDs)
+	for _, stats := range all {
+		total.CPUPercentage += stats.CPUPercentage
+		total.MemoryUsage += stats.MemoryUsage
+		total.NetworkRx += stats.NetworkRx
+		total.NetworkTx += stats.NetworkTx
+		total.BlockRead += stats.BlockRead
+		total.BlockWrite += stats.BlockWrite
+	}
+	return total, len(all)
+}
+
+// GetAllProcessedStats samples the stats of every running container, keyed
+// by container ID. Containers that stop while being sampled are left out.
+func (s *Service) GetAllProcessedStats(ctx context.Context) (map[string]ContainerStats, error) {
+	containers, err := s.cli().ContainerList(ctx, container.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list running containers: %w", err)
+	}
+
+	ids := make([]string, 0, len(containers))
+	for _, c := range containers {
+		ids = append(ids, c.ID)
+	}
+	return s.processedStatsOf(ctx, ids), nil
+}
+
+// processedStatsOf samples the given containers concurrently, at most
+// maxStatsRequests at a time, skipping those whose stats can't be read
+func (s *Service) processedStatsOf(ctx context.Context, containerIDs []string) map[string]ContainerStats {
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		all = make(map[string]ContainerStats, len(containerIDs))
+	)
+
+	limit := make(chan struct{}, maxStatsRequests)
+	for _, id := range containerIDs {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+
+			stats, err := s.GetProcessedStats(ctx, id)
+			if err != nil {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			all[id] = stats
+		}(id)
+	}
+	wg.Wait()
+
+	return all
+}
+
+// cpuSampleInterval is how long to wait for a second stats sample when a
+// one-off read doesn't come with the previous one CPU usage is measured against
+const cpuSampleInterval = 500 * time.Millisecond
+
 // GetProcessedStats returns processed container stats in a more usable format
 func (s *Service) GetProcessedStats(ctx context.Context, containerID string) (ContainerStats, error) {
 	// Check if context is already done before making the API call
@@ -263,15 +493,46 @@ func (s *Service) GetProcessedStats(ctx context.Context, containerID string) (Co
 		defer cancel()
 	}
 
+	statsJSON, err := s.readStatsSample(ctx, containerID)
+	if err != nil {
+		return ContainerStats{}, err
+	}
+	processed := processStats(statsJSON)
+
+	// The daemon usually includes the previous sample, but not always (e.g.
+	// right after the container started). Without it, take a second sample
+	// and measure the CPU usage between the two.
+	cpu, _ := statsJSON["cpu_stats"].(map[string]interface{})
+	preCPU, _ := statsJSON["precpu_stats"].(map[string]interface{})
+	_, system := cpuUsage(cpu)
+	_, preSystem := cpuUsage(preCPU)
+	if system > 0 && preSystem == 0 {
+		select {
+		case <-time.After(cpuSampleInterval):
+		case <-ctx.Done():
+			return processed, nil
+		}
+		if next, err := s.readStatsSample(ctx, containerID); err == nil {
+			nextCPU, _ := next["cpu_stats"].(map[string]interface{})
+			if percent, ok := cpuPercentBetween(nextCPU, cpu); ok {
+				processed.CPUPercentage = percent
+			}
+		}
+	}
+
+	return processed, nil
+}
+
+// readStatsSample reads a single raw stats sample of a container
+func (s *Service) readStatsSample(ctx context.Context, containerID string) (map[string]interface{}, error) {
 	// Get container stats (non-streaming mode)
-	stats, err := s.client.ContainerStats(ctx, containerID, false)
+	stats, err := s.cli().ContainerStats(ctx, containerID, false)
 	if err != nil {
-		return ContainerStats{}, fmt.Errorf("failed to get container stats: %w", err)
+		return nil, fmt.Errorf("failed to get container stats: %w", err)
 	}
 	defer stats.Body.Close()
 
 	// Read with timeout to prevent blocking indefinitely
-	var statsJSON map[string]interface{}
 	decodeErr := make(chan error, 1)
 	decodeJSON := make(chan map[string]interface{}, 1)
 
@@ -294,46 +555,52 @@ func (s *Service) GetProcessedStats(ctx context.Context, containerID string) (Co
 	// Wait for decode or timeout
 	select {
 	case err := <-decodeErr:
-		return ContainerStats{}, fmt.Errorf("failed to decode stats JSON: %w", err)
-	case statsJSON = <-decodeJSON:
-		// Decode successful
+		return nil, fmt.Errorf("failed to decode stats JSON: %w", err)
+	case statsJSON := <-decodeJSON:
+		return statsJSON, nil
 	case <-ctx.Done():
-		return ContainerStats{}, fmt.Errorf("timeout decoding stats: %w", ctx.Err())
+		return nil, fmt.Errorf("timeout decoding stats: %w", ctx.Err())
 	}
+}
 
-	// Extract CPU data
-	cpuPercent := 0.0
-	if cpu, ok := statsJSON["cpu_stats"].(map[string]interface{}); ok {
-		if preCPU, ok := statsJSON["precpu_stats"].(map[string]interface{}); ok {
-			cpuDelta := float64(0)
-			if cpuUsage, ok := cpu["cpu_usage"].(map[string]interface{}); ok {
-				if preCPUUsage, ok := preCPU["cpu_usage"].(map[string]interface{}); ok {
-					if totalUsage, ok := cpuUsage["total_usage"].(float64); ok {
-						if preTotalUsage, ok := preCPUUsage["total_usage"].(float64); ok {
-							cpuDelta = totalUsage - preTotalUsage
-						}
-					}
-				}
-			}
+// StreamProcessedStats streams a container's stats, sending a processed
+// sample every time the daemon reports one (about once a second). CPU usage
+// is measured between consecutive samples, so it's steadier than separate
+// one-off requests. The channel is closed when the stream ends or ctx is
+// cancelled.
+func (s *Service) StreamProcessedStats(ctx context.Context, containerID string) (<-chan ContainerStats, error) {
+	body, err := s.GetContainerStatsStream(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stream container stats: %w", err)
+	}
 
-			systemDelta := float64(0)
-			if systemUsage, ok := cpu["system_cpu_usage"].(float64); ok {
-				if preSystemUsage, ok := preCPU["system_cpu_usage"].(float64); ok {
-					systemDelta = systemUsage - preSystemUsage
-				}
+	updates := make(chan ContainerStats)
+	go func() {
+		defer close(updates)
+		defer body.Close()
+
+		decoder := json.NewDecoder(body)
+		for {
+			var statsJSON map[string]interface{}
+			if err := decoder.Decode(&statsJSON); err != nil {
+				return
 			}
-
-			if systemDelta > 0.0 && cpuDelta > 0.0 {
-				percpuLen := 0
-				if cpuUsage, ok := cpu["cpu_usage"].(map[string]interface{}); ok {
-					if percpu, ok := cpuUsage["percpu_usage"].([]interface{}); ok {
-						percpuLen = len(percpu)
-					}
-				}
-				cpuPercent = (cpuDelta / systemDelta) * float64(percpuLen) * 100.0
+			select {
+			case updates <- processStats(statsJSON):
+			case <-ctx.Done():
+				return
 			}
 		}
-	}
+	}()
+	return updates, nil
+}
+
+// processStats extracts the figures we display from a raw stats sample
+func processStats(statsJSON map[string]interface{}) ContainerStats {
+	// Extract CPU data
+	cpu, _ := statsJSON["cpu_stats"].(map[string]interface{})
+	preCPU, _ := statsJSON["precpu_stats"].(map[string]interface{})
+	cpuPercent, _ := cpuPercentBetween(cpu, preCPU)
 
 	// Extract memory data
 	memUsage := int64(0)
@@ -366,7 +633,48 @@ func (s *Service) GetProcessedStats(ctx context.Context, containerID string) (Co
 		NetworkTx:        networkTx,
 		BlockRead:        blockRead,
 		BlockWrite:       blockWrite,
-	}, nil
+	}
+}
+
+// cpuPercentBetween computes the CPU usage between two samples of
+// cpu_stats the way docker stats does: the container's share of the CPU
+// time the host spent in between, times the number of CPUs. ok is false if
+// the earlier sample is empty, as it can be in a one-off read.
+func cpuPercentBetween(cpu, preCPU map[string]interface{}) (percent float64, ok bool) {
+	preTotal, preSystem := cpuUsage(preCPU)
+	if preSystem == 0 {
+		return 0, false
+	}
+	total, system := cpuUsage(cpu)
+
+	// Counters can go backwards when a container restarts; report no usage
+	// rather than a negative one
+	cpuDelta := total - preTotal
+	systemDelta := system - preSystem
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0, true
+	}
+
+	// cgroup v2 hosts don't report per-CPU usage, only the CPU count
+	cpus := 0.0
+	if online, ok := cpu["online_cpus"].(float64); ok && online > 0 {
+		cpus = online
+	} else if usage, ok := cpu["cpu_usage"].(map[string]interface{}); ok {
+		if percpu, ok := usage["percpu_usage"].([]interface{}); ok {
+			cpus = float64(len(percpu))
+		}
+	}
+	return cpuDelta / systemDelta * cpus * 100.0, true
+}
+
+// cpuUsage returns the container's and the host's total CPU time from a
+// sample of cpu_stats
+func cpuUsage(cpu map[string]interface{}) (total, system float64) {
+	if usage, ok := cpu["cpu_usage"].(map[string]interface{}); ok {
+		total, _ = usage["total_usage"].(float64)
+	}
+	system, _ = cpu["system_cpu_usage"].(float64)
+	return total, system
 }
 
 // Helper function to safely extract network stats
@@ -430,33 +738,86 @@ func extractBlockIOStats(statsJSON map[string]interface{}) (int64, int64) {
 	return blockRead, blockWrite
 }
 
-// GetContainerLogs retrieves logs for a container
-func (s *Service) GetContainerLogs(ctx context.Context, containerID string) (string, error) {
-	options := container.LogsOptions{
+// tailOption converts a line count to the logs Tail option, where an empty
+// value asks for the full log
+func tailOption(lines int) string {
+	if lines <= 0 {
+		return ""
+	}
+	return strconv.Itoa(lines)
+}
+
+// DownloadContainerLogs writes a container's full log history to w, calling
+// progress with the number of bytes written so far. Cancel ctx to stop early.
+func (s *Service) DownloadContainerLogs(ctx context.Context, containerID string, w io.Writer, progress func(written int64)) error {
+	info, err := s.cli().ContainerInspect(ctx, containerID)
+	if err != nil {
+		return err
+	}
+
+	logs, err := s.cli().ContainerLogs(ctx, containerID, container.LogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
 		Timestamps: true,
-		Tail:       "100",
+		Tail:       "all",
+	})
+	if err != nil {
+		return err
+	}
+	defer logs.Close()
+
+	pw := &progressWriter{w: w, progress: progress}
+	return copyLogs(pw, logs, info.Config != nil && info.Config.Tty)
+}
+
+// progressWriter reports how many bytes have been written through it
+type progressWriter struct {
+	w        io.Writer
+	written  int64
+	progress func(written int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	if p.progress != nil {
+		p.progress(p.written)
 	}
+	return n, err
+}
 
-	logs, err := s.client.ContainerLogs(ctx, containerID, options)
+// StreamContainerLogs streams a container's logs as plain text, with stdout
+// and stderr already demultiplexed. With follow, new lines keep arriving
+// until the reader is closed or ctx is cancelled.
+func (s *Service) StreamContainerLogs(ctx context.Context, containerID string, follow bool, options LogOptions) (io.ReadCloser, error) {
+	info, err := s.cli().ContainerInspect(ctx, containerID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	defer logs.Close()
 
-	buf := new(strings.Builder)
-	_, err = io.Copy(buf, logs)
+	logs, err := s.cli().ContainerLogs(ctx, containerID, container.LogsOptions{
+		ShowStdout: options.Streams.showStdout(),
+		ShowStderr: options.Streams.showStderr(),
+		Since:      logsTime(options.Window.Since),
+		Until:      logsTime(options.Window.Until),
+		Follow:     follow,
+		Timestamps: options.Timestamps,
+		Tail:       tailOption(options.Tail),
+	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return buf.String(), nil
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(copyLogs(pw, logs, info.Config != nil && info.Config.Tty))
+	}()
+	return logReader{PipeReader: pr, logs: logs}, nil
 }
 
 // ListImages returns a list of all images
 func (s *Service) ListImages(ctx context.Context) ([]ImageInfo, error) {
-	images, err := s.client.ImageList(ctx, image.ListOptions{})
+	images, err := s.cli().ImageList(ctx, image.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -468,14 +829,7 @@ func (s *Service) ListImages(ctx context.Context) ([]ImageInfo, error) {
 			repoTags = []string{"<none>:<none>"}
 		}
 
-		id := img.ID
-		if len(id) > 12 {
-			if len(id) > 7 && id[:7] == "sha256:" {
-				id = id[7:19] // Remove "sha256:" prefix and shorten
-			} else {
-				id = id[:12]
-			}
-		}
+		id := ShortID(strings.TrimPrefix(img.ID, "sha256:"), 12)
 
 		imageInfos = append(imageInfos, ImageInfo{
 			ID:          id,
@@ -494,13 +848,13 @@ func (s *Service) RemoveImage(ctx context.Context, imageID string, force bool) e
 	options := image.RemoveOptions{
 		Force: force,
 	}
-	_, err := s.client.ImageRemove(ctx, imageID, options)
+	_, err := s.cli().ImageRemove(ctx, imageID, options)
 	return err
 }
 
 // InspectImage returns detailed info about an image
 func (s *Service) InspectImage(ctx context.Context, imageID string) (string, error) {
-	info, _, err := s.client.ImageInspectWithRaw(ctx, imageID)
+	info, _, err := s.cli().ImageInspectWithRaw(ctx, imageID)
 	if err != nil {
 		return "", err
 	}
@@ -513,9 +867,19 @@ func (s *Service) InspectImage(ctx context.Context, imageID string) (string, err
 	return string(data), nil
 }
 
+// ImageHistory returns the layers of an image, newest first, with the
+// instruction that created each of them
+func (s *Service) ImageHistory(ctx context.Context, imageID string) ([]image.HistoryResponseItem, error) {
+	history, err := s.cli().ImageHistory(ctx, imageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the history of %s: %w", imageID, err)
+	}
+	return history, nil
+}
+
 // ListVolumes returns a list of all volumes
 func (s *Service) ListVolumes(ctx context.Context) ([]VolumeInfo, error) {
-	volumes, err := s.client.VolumeList(ctx, volume.ListOptions{Filters: filters.Args{}})
+	volumes, err := s.cli().VolumeList(ctx, volume.ListOptions{Filters: filters.Args{}})
 	if err != nil {
 		return nil, err
 	}
@@ -532,14 +896,36 @@ func (s *Service) ListVolumes(ctx context.Context) ([]VolumeInfo, error) {
 	return volumeInfos, nil
 }
 
-// RemoveVolume removes a volume
+// CreateVolume creates a volume with the given driver and labels, returning
+// its name. With an empty name, Docker generates one (an anonymous volume).
+func (s *Service) CreateVolume(ctx context.Context, name, driver string, labels map[string]string) (string, error) {
+	vol, err := s.cli().VolumeCreate(ctx, volume.CreateOptions{
+		Name:   name,
+		Driver: driver,
+		Labels: labels,
+	})
+	if err != nil {
+		if name == "" {
+			return "", fmt.Errorf("failed to create volume: %w", err)
+		}
+		return "", fmt.Errorf("failed to create volume %s: %w", name, err)
+	}
+	return vol.Name, nil
+}
+
+// RemoveVolume removes a volume. A volume still used by a container can't be
+// removed, force or not.
 func (s *Service) RemoveVolume(ctx context.Context, volumeName string, force bool) error {
-	return s.client.VolumeRemove(ctx, volumeName, force)
+	err := s.cli().VolumeRemove(ctx, volumeName, force)
+	if err != nil && errdefs.IsConflict(err) {
+		return fmt.Errorf("volume %s is in use, remove the containers using it first: %w", volumeName, err)
+	}
+	return err
 }
 
 // InspectVolume returns detailed info about a volume
 func (s *Service) InspectVolume(ctx context.Context, volumeName string) (string, error) {
-	info, err := s.client.VolumeInspect(ctx, volumeName)
+	info, err := s.cli().VolumeInspect(ctx, volumeName)
 	if err != nil {
 		return "", err
 	}
@@ -554,17 +940,14 @@ func (s *Service) InspectVolume(ctx context.Context, volumeName string) (string,
 
 // ListNetworks returns a list of all networks
 func (s *Service) ListNetworks(ctx context.Context) ([]NetworkInfo, error) {
-	networks, err := s.client.NetworkList(ctx, network.ListOptions{Filters: filters.Args{}})
+	networks, err := s.cli().NetworkList(ctx, network.ListOptions{Filters: filters.Args{}})
 	if err != nil {
 		return nil, err
 	}
 
 	var networkInfos []NetworkInfo
 	for _, nw := range networks {
-		id := nw.ID
-		if len(id) > 12 {
-			id = id[:12] // Short ID
-		}
+		id := ShortID(nw.ID, 12)
 
 		// Convert from Docker network containers to our simplified type
 		containers := make(map[string]NetworkContainer)
@@ -587,14 +970,47 @@ func (s *Service) ListNetworks(ctx context.Context) ([]NetworkInfo, error) {
 	return networkInfos, nil
 }
 
+// CreateNetwork creates a network with the given driver and driver options,
+// returning its ID. An internal network has no outside connectivity; an
+// attachable one also accepts standalone containers when it's an overlay.
+func (s *Service) CreateNetwork(ctx context.Context, name, driver string, internal, attachable bool, options map[string]string) (string, error) {
+	resp, err := s.cli().NetworkCreate(ctx, name, network.CreateOptions{
+		Driver:     driver,
+		Internal:   internal,
+		Attachable: attachable,
+		Options:    options,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create network %s: %w", name, err)
+	}
+	return resp.ID, nil
+}
+
 // RemoveNetwork removes a network
 func (s *Service) RemoveNetwork(ctx context.Context, networkID string) error {
-	return s.client.NetworkRemove(ctx, networkID)
+	return s.cli().NetworkRemove(ctx, networkID)
+}
+
+// ConnectContainerToNetwork attaches a container to a network
+func (s *Service) ConnectContainerToNetwork(ctx context.Context, networkID, containerID string) error {
+	if err := s.cli().NetworkConnect(ctx, networkID, containerID, nil); err != nil {
+		return fmt.Errorf("failed to connect %s to network %s: %w", containerID, networkID, err)
+	}
+	return nil
+}
+
+// DisconnectContainerFromNetwork detaches a container from a network. With
+// force set, the container is disconnected even if it's not running.
+func (s *Service) DisconnectContainerFromNetwork(ctx context.Context, networkID, containerID string, force bool) error {
+	if err := s.cli().NetworkDisconnect(ctx, networkID, containerID, force); err != nil {
+		return fmt.Errorf("failed to disconnect %s from network %s: %w", containerID, networkID, err)
+	}
+	return nil
 }
 
 // InspectNetwork returns detailed info about a network
 func (s *Service) InspectNetwork(ctx context.Context, networkID string) (string, error) {
-	info, err := s.client.NetworkInspect(ctx, networkID, network.InspectOptions{})
+	info, err := s.cli().NetworkInspect(ctx, networkID, network.InspectOptions{})
 	if err != nil {
 		return "", err
 	}
@@ -609,49 +1025,117 @@ func (s *Service) InspectNetwork(ctx context.Context, networkID string) (string,
 
 // PruneContainers removes all stopped containers
 func (s *Service) PruneContainers(ctx context.Context) (uint64, error) {
-	report, err := s.client.ContainersPrune(ctx, filters.Args{})
+	report, err := s.cli().ContainersPrune(ctx, filters.Args{})
 	if err != nil {
 		return 0, err
 	}
 	return report.SpaceReclaimed, nil
 }
 
-// PruneImages removes all unused images
-func (s *Service) PruneImages(ctx context.Context) (uint64, error) {
-	report, err := s.client.ImagesPrune(ctx, filters.Args{})
+// PruneImages removes the images no container uses. With dangling set, only
+// untagged images are removed, otherwise tagged ones go too.
+func (s *Service) PruneImages(ctx context.Context, dangling bool) (uint64, error) {
+	report, err := s.cli().ImagesPrune(ctx, filters.NewArgs(filters.Arg("dangling", strconv.FormatBool(dangling))))
 	if err != nil {
 		return 0, err
 	}
 	return report.SpaceReclaimed, nil
 }
 
-// PruneVolumes removes all unused volumes
+// PruneVolumes removes all volumes no container uses, named ones included
 func (s *Service) PruneVolumes(ctx context.Context) (uint64, error) {
-	report, err := s.client.VolumesPrune(ctx, filters.Args{})
+	report, err := s.cli().VolumesPrune(ctx, unusedVolumesFilter(s.cli()))
 	if err != nil {
 		return 0, err
 	}
 	return report.SpaceReclaimed, nil
 }
 
+// UnusedImages returns the images PruneImages would remove
+func (s *Service) UnusedImages(ctx context.Context, dangling bool) ([]ImageInfo, error) {
+	containers, err := s.cli().ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[string]bool, len(containers))
+	for _, c := range containers {
+		used[c.ImageID] = true
+	}
+
+	options := image.ListOptions{}
+	if dangling {
+		options.Filters = filters.NewArgs(filters.Arg("dangling", "true"))
+	}
+	images, err := s.cli().ImageList(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var unused []ImageInfo
+	for _, img := range images {
+		if used[img.ID] {
+			continue
+		}
+		repoTags := img.RepoTags
+		if len(repoTags) == 0 {
+			repoTags = []string{"<none>:<none>"}
+		}
+		unused = append(unused, ImageInfo{
+			ID:        ShortID(strings.TrimPrefix(img.ID, "sha256:"), 12),
+			RepoTags:  repoTags,
+			Size:      img.Size,
+			CreatedAt: time.Unix(img.Created, 0),
+		})
+	}
+	return unused, nil
+}
+
+// UnusedVolumes returns the volumes PruneVolumes would remove. Listing
+// volumes only takes the dangling filter, which covers named ones too.
+func (s *Service) UnusedVolumes(ctx context.Context) ([]VolumeInfo, error) {
+	args := filters.NewArgs(filters.Arg("dangling", "true"))
+	volumes, err := s.cli().VolumeList(ctx, volume.ListOptions{Filters: args})
+	if err != nil {
+		return nil, err
+	}
+
+	var unused []VolumeInfo
+	for _, vol := range volumes.Volumes {
+		unused = append(unused, VolumeInfo{Name: vol.Name, Driver: vol.Driver, Mountpoint: vol.Mountpoint})
+	}
+	return unused, nil
+}
+
+// unusedVolumesFilter makes volume prunes cover named volumes. Since API 1.42
+// the daemon only prunes anonymous volumes unless asked for all of them. The
+// all filter is only accepted by prunes, not by volume lists.
+func unusedVolumesFilter(cli *client.Client) filters.Args {
+	args := filters.NewArgs()
+	if versions.GreaterThanOrEqualTo(cli.ClientVersion(), "1.42") {
+		args.Add("all", "true")
+	}
+	return args
+}
+
 // PauseContainer pauses a container
 func (s *Service) PauseContainer(ctx context.Context, containerID string) error {
-	return s.client.ContainerPause(ctx, containerID)
+	return s.cli().ContainerPause(ctx, containerID)
 }
 
 // UnpauseContainer unpauses a container
 func (s *Service) UnpauseContainer(ctx context.Context, containerID string) error {
-	return s.client.ContainerUnpause(ctx, containerID)
+	return s.cli().ContainerUnpause(ctx, containerID)
 }
 
-// KillContainer kills a container
-func (s *Service) KillContainer(ctx context.Context, containerID string) error {
-	return s.client.ContainerKill(ctx, containerID, "SIGKILL")
+// KillContainer sends a signal to a container's main process, e.g. "SIGKILL"
+// to kill it or "SIGHUP" to make it reload
+func (s *Service) KillContainer(ctx context.Context, containerID, signal string) error {
+	return s.cli().ContainerKill(ctx, containerID, signal)
 }
 
 // InspectContainer returns detailed info about a container
 func (s *Service) InspectContainer(ctx context.Context, containerID string) (string, error) {
-	info, err := s.client.ContainerInspect(ctx, containerID)
+	info, err := s.cli().ContainerInspect(ctx, containerID)
 	if err != nil {
 		return "", err
 	}
@@ -664,26 +1148,137 @@ func (s *Service) InspectContainer(ctx context.Context, containerID string) (str
 	return string(data), nil
 }
 
+// ContainerState returns the state of a container (e.g. "running", "paused", "exited")
+func (s *Service) ContainerState(ctx context.Context, containerID string) (string, error) {
+	info, err := s.cli().ContainerInspect(ctx, containerID)
+	if err != nil {
+		return "", err
+	}
+	if info.ContainerJSONBase == nil || info.State == nil {
+		return "", fmt.Errorf("no state reported for container %s", containerID)
+	}
+	return info.State.Status, nil
+}
+
+// ContainerResources returns the memory limit in bytes and the CPU shares of
+// a container, 0 where none are set
+func (s *Service) ContainerResources(ctx context.Context, containerID string) (memory, cpuShares int64, err error) {
+	info, err := s.cli().ContainerInspect(ctx, containerID)
+	if err != nil {
+		return 0, 0, err
+	}
+	if info.ContainerJSONBase == nil || info.HostConfig == nil {
+		return 0, 0, fmt.Errorf("no host configuration reported for container %s", containerID)
+	}
+	return info.HostConfig.Memory, info.HostConfig.CPUShares, nil
+}
+
+// UpdateContainerResources changes the memory limit and CPU shares of a
+// container without recreating it, like `docker update`. A value of 0 leaves
+// that limit unchanged. The swap limit is set to twice the memory limit, as
+// `docker run` does, since the daemon refuses a memory limit above it.
+func (s *Service) UpdateContainerResources(ctx context.Context, containerID string, memory, cpuShares int64) error {
+	resources := container.Resources{
+		Memory:    memory,
+		CPUShares: cpuShares,
+	}
+	if memory > 0 {
+		resources.MemorySwap = 2 * memory
+	}
+	_, err := s.cli().ContainerUpdate(ctx, containerID, container.UpdateConfig{Resources: resources})
+	if err != nil {
+		return fmt.Errorf("failed to update the resources of %s: %w", containerID, err)
+	}
+	return nil
+}
+
+// SetRestartPolicy changes when Docker restarts a container: "no", "always",
+// "unless-stopped" or "on-failure", which gives up after maxRetries failed
+// restarts, or never if it's zero
+func (s *Service) SetRestartPolicy(ctx context.Context, containerID, policy string, maxRetries int) error {
+	restartPolicy := container.RestartPolicy{Name: container.RestartPolicyMode(policy), MaximumRetryCount: maxRetries}
+	if err := container.ValidateRestartPolicy(restartPolicy); err != nil {
+		return err
+	}
+
+	_, err := s.cli().ContainerUpdate(ctx, containerID, container.UpdateConfig{RestartPolicy: restartPolicy})
+	if err != nil {
+		return fmt.Errorf("failed to update the restart policy of %s: %w", containerID, err)
+	}
+	return nil
+}
+
+// ContainerTop lists the processes running in a container, like `docker top`.
+// The first row holds the column titles, the rest one process each.
+func (s *Service) ContainerTop(ctx context.Context, containerID string) ([][]string, error) {
+	top, err := s.cli().ContainerTop(ctx, containerID, []string{"-eo", "pid,user,pcpu,args"})
+	if err != nil {
+		// Not every host's ps accepts custom columns, so fall back to its defaults
+		top, err = s.cli().ContainerTop(ctx, containerID, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list processes of %s: %w", containerID, err)
+		}
+	}
+	return append([][]string{top.Titles}, top.Processes...), nil
+}
+
+// ContainerDiff lists the files and directories added, changed or deleted in
+// a container's filesystem compared to its image
+func (s *Service) ContainerDiff(ctx context.Context, containerID string) ([]container.FilesystemChange, error) {
+	changes, err := s.cli().ContainerDiff(ctx, containerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff the filesystem of %s: %w", containerID, err)
+	}
+	return changes, nil
+}
+
+// CommitContainer creates an image repo:tag from a container's current
+// filesystem, like `docker commit`, returning the new image's ID. The
+// container is paused while its filesystem is copied.
+func (s *Service) CommitContainer(ctx context.Context, containerID, repo, tag, message string) (string, error) {
+	ref := repo
+	if tag != "" {
+		ref += ":" + tag
+	}
+	resp, err := s.cli().ContainerCommit(ctx, containerID, container.CommitOptions{
+		Reference: ref,
+		Comment:   message,
+		Pause:     true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to commit %s as %s: %w", containerID, ref, err)
+	}
+	return resp.ID, nil
+}
+
 // CreateContainer creates a new container with the given configuration
 func (s *Service) CreateContainer(ctx context.Context, config ContainerCreateConfig) (string, error) {
 	// Pull the image if it doesn't exist
-	_, err := s.client.ImagePull(ctx, config.Image, image.PullOptions{})
+	if !s.HasImage(ctx, config.Image) {
+		if err := s.PullImage(ctx, config.Image, nil); err != nil {
+			return "", err
+		}
+	}
+
+	exposedPorts, portBindings, err := nat.ParsePortSpecs(config.Ports)
 	if err != nil {
-		return "", fmt.Errorf("failed to pull image: %v", err)
+		return "", fmt.Errorf("invalid port mapping: %v", err)
 	}
 
 	// Prepare container configuration
 	containerConfig := &container.Config{
-		Image:  config.Image,
-		Cmd:    config.Command,
-		Env:    config.Env,
-		Labels: config.Labels,
+		Image:        config.Image,
+		Cmd:          config.Command,
+		Env:          config.Env,
+		Labels:       config.Labels,
+		ExposedPorts: exposedPorts,
 	}
 
 	// Prepare host configuration
 	hostConfig := &container.HostConfig{
-		Binds:       config.Volumes,
-		NetworkMode: container.NetworkMode(config.NetworkMode),
+		Binds:        config.Volumes,
+		PortBindings: portBindings,
+		NetworkMode:  container.NetworkMode(config.NetworkMode),
 		Resources: container.Resources{
 			Memory:    config.Memory,
 			CPUShares: config.CPUShares,
@@ -692,13 +1287,22 @@ func (s *Service) CreateContainer(ctx context.Context, config ContainerCreateCon
 
 	// Set restart policy if provided
 	if config.Restart != "" {
+		// on-failure takes an optional retry limit, as in on-failure:5
+		name, retries, _ := strings.Cut(config.Restart, ":")
 		hostConfig.RestartPolicy = container.RestartPolicy{
-			Name: container.RestartPolicyMode(config.Restart),
+			Name: container.RestartPolicyMode(name),
+		}
+		if retries != "" {
+			count, err := strconv.Atoi(retries)
+			if err != nil {
+				return "", fmt.Errorf("invalid restart policy %q", config.Restart)
+			}
+			hostConfig.RestartPolicy.MaximumRetryCount = count
 		}
 	}
 
 	// Create the container
-	resp, err := s.client.ContainerCreate(
+	resp, err := s.cli().ContainerCreate(
 		ctx,
 		containerConfig,
 		hostConfig,
@@ -713,54 +1317,124 @@ func (s *Service) CreateContainer(ctx context.Context, config ContainerCreateCon
 	return resp.ID, nil
 }
 
-// StartContainer starts a container
-func (s *Service) StartContainer(ctx context.Context, containerID string) error {
-	return s.client.ContainerStart(ctx, containerID, container.StartOptions{})
-}
+// ContainerTemplate builds a create configuration from an existing container's
+// settings, so a near-duplicate of it can be created
+func (s *Service) ContainerTemplate(ctx context.Context, containerID string) (ContainerCreateConfig, error) {
+	info, err := s.cli().ContainerInspect(ctx, containerID)
+	if err != nil {
+		return ContainerCreateConfig{}, err
+	}
+	if info.ContainerJSONBase == nil || info.Config == nil || info.HostConfig == nil {
+		return ContainerCreateConfig{}, fmt.Errorf("incomplete inspect data for container %s", containerID)
+	}
 
-// StopContainer stops a container
-func (s *Service) StopContainer(ctx context.Context, containerID string) error {
-	timeout := int(10)
-	return s.client.ContainerStop(ctx, containerID, container.StopOptions{Timeout: &timeout})
-}
+	config := ContainerCreateConfig{
+		Name:        strings.TrimPrefix(info.Name, "/") + "-copy",
+		Image:       info.Config.Image,
+		Command:     info.Config.Cmd,
+		Env:         info.Config.Env,
+		Volumes:     info.HostConfig.Binds,
+		NetworkMode: string(info.HostConfig.NetworkMode),
+		Restart:     string(info.HostConfig.RestartPolicy.Name),
+		Memory:      info.HostConfig.Memory,
+		CPUShares:   info.HostConfig.CPUShares,
+	}
+	if policy := info.HostConfig.RestartPolicy; policy.IsOnFailure() && policy.MaximumRetryCount > 0 {
+		config.Restart += ":" + strconv.Itoa(policy.MaximumRetryCount)
+	}
+
+	// Published ports, in the same format CreateContainer accepts
+	for port, bindings := range info.HostConfig.PortBindings {
+		for _, b := range bindings {
+			spec := string(port)
+			if b.HostPort != "" {
+				spec = b.HostPort + ":" + spec
+			}
+			if b.HostIP != "" {
+				spec = b.HostIP + ":" + spec
+			}
+			config.Ports = append(config.Ports, spec)
+		}
+	}
+	sort.Strings(config.Ports)
+
+	// Don't copy the compose labels, or compose would treat the copy as part of its project
+	config.Labels = make(map[string]string)
+	for k, v := range info.Config.Labels {
+		if !strings.HasPrefix(k, "com.docker.compose.") {
+			config.Labels[k] = v
+		}
+	}
+
+	return config, nil
+}
+
+// StartContainer starts a container
+func (s *Service) StartContainer(ctx context.Context, containerID string) error {
+	return s.cli().ContainerStart(ctx, containerID, container.StartOptions{})
+}
+
+// StopContainer stops a container, killing it with SIGKILL if it hasn't
+// exited timeout seconds after being asked to. Zero kills it right away.
+func (s *Service) StopContainer(ctx context.Context, containerID string, timeout int) error {
+	return s.cli().ContainerStop(ctx, containerID, container.StopOptions{Timeout: &timeout})
+}
+
+// RestartContainer restarts a container, stopping it the way StopContainer does
+func (s *Service) RestartContainer(ctx context.Context, containerID string, timeout int) error {
+	return s.cli().ContainerRestart(ctx, containerID, container.StopOptions{Timeout: &timeout})
+}
+
+// ErrContainerRunning is returned when removing a running container without force
+var ErrContainerRunning = errors.New("container is running")
 
-// RestartContainer restarts a container
-func (s *Service) RestartContainer(ctx context.Context, containerID string) error {
-	timeout := int(10)
-	return s.client.ContainerRestart(ctx, containerID, container.StopOptions{Timeout: &timeout})
+// RemoveContainer removes a container. A running container is only removed,
+// killing it first, when force is set; otherwise ErrContainerRunning is returned.
+func (s *Service) RemoveContainer(ctx context.Context, containerID string, force bool) error {
+	err := s.cli().ContainerRemove(ctx, containerID, container.RemoveOptions{Force: force})
+	if err != nil && !force && errdefs.IsConflict(err) {
+		return fmt.Errorf("%w: %w", ErrContainerRunning, err)
+	}
+	return err
 }
 
-// RemoveContainer removes a container
-func (s *Service) RemoveContainer(ctx context.Context, containerID string) error {
-	return s.client.ContainerRemove(ctx, containerID, container.RemoveOptions{Force: true})
+// RenameContainer gives a container a new name
+func (s *Service) RenameContainer(ctx context.Context, containerID, newName string) error {
+	return s.cli().ContainerRename(ctx, containerID, newName)
 }
 
 // Ping checks if the Docker daemon is responding
 func (s *Service) Ping(ctx context.Context) (types.Ping, error) {
-	return s.client.Ping(ctx)
+	return s.cli().Ping(ctx)
+}
+
+// ComposeVersion returns the version of the docker compose CLI plugin
+func ComposeVersion(ctx context.Context) (string, error) {
+	output, err := runCommand(ctx, "docker", "compose", "version", "--short")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
 }
 
 // ListComposeProjects returns the list of Docker Compose projects
 func (s *Service) ListComposeProjects(ctx context.Context) ([]ComposeInfo, error) {
-	// Try using the docker compose ls command
-	cmd := exec.Command("docker", "compose", "ls", "--format", "json")
-	output, err := cmd.CombinedOutput()
-
-	// Check for errors - try fallback approaches
+	// Try using the docker compose ls command. Only stdout is parsed, so
+	// warnings printed by the CLI don't end up in the JSON.
+	stdout, err := runCommand(ctx, "docker", "compose", "ls", "--format", "json")
 	if err != nil {
 		// We got an error, check if it's a well-known one
-		if _, ok := err.(*exec.ExitError); ok {
-			// Command ran but exited with non-zero code
-			return nil, fmt.Errorf("failed to list Docker Compose projects: %v", err)
-		} else {
+		if errors.Is(err, exec.ErrNotFound) {
 			// Command couldn't even run
 			return nil, fmt.Errorf("failed to execute Docker Compose command: %v", err)
 		}
-
-		// This code is unreachable but left for consistency
-		return nil, fmt.Errorf("failed to list Docker Compose projects")
+		// Command ran but exited with non-zero code
+		return nil, fmt.Errorf("failed to list Docker Compose projects: %v", err)
 	}
 
+	// Some wrappers print warnings to stdout too, so skip anything before the JSON
+	output := trimToJSON(stdout)
+
 	// Try to parse the JSON output from docker compose ls
 	var projects []ComposeInfo
 	if err := json.Unmarshal(output, &projects); err != nil {
@@ -772,7 +1446,7 @@ func (s *Service) ListComposeProjects(ctx context.Context) ([]ComposeInfo, error
 
 			// Make sure path is set
 			if singleProject.Path == "" {
-				projects[0].Path = s.findComposeProjectPath(singleProject.Name)
+				projects[0].Path = s.findComposeProjectPath(ctx, singleProject.Name)
 			}
 		} else {
 			// Manual parsing if JSON approach failed
@@ -786,7 +1460,7 @@ func (s *Service) ListComposeProjects(ctx context.Context) ([]ComposeInfo, error
 	}
 
 	// Try to find additional projects via config files
-	configProjects := s.tryExtractProjectsViaConfig()
+	configProjects := s.tryExtractProjectsViaConfig(ctx)
 
 	// Add any projects found in config that aren't already in our list
 	for _, cp := range configProjects {
@@ -814,7 +1488,7 @@ func (s *Service) ListComposeProjects(ctx context.Context) ([]ComposeInfo, error
 
 			// Some versions don't return the path, so try to find it
 			if p.Path == "" {
-				p.Path = s.findComposeProjectPath(p.Name)
+				p.Path = s.findComposeProjectPath(ctx, p.Name)
 			}
 
 			uniqueProjects = append(uniqueProjects, p)
@@ -824,11 +1498,23 @@ func (s *Service) ListComposeProjects(ctx context.Context) ([]ComposeInfo, error
 	return uniqueProjects, nil
 }
 
+// trimToJSON drops any lines before the first one that starts a JSON array or
+// object. The output is returned unchanged if no such line is found.
+func trimToJSON(output []byte) []byte {
+	lines := bytes.SplitAfter(output, []byte("\n"))
+	for i, line := range lines {
+		trimmed := bytes.TrimSpace(line)
+		if bytes.HasPrefix(trimmed, []byte("[")) || bytes.HasPrefix(trimmed, []byte("{")) {
+			return bytes.Join(lines[i:], nil)
+		}
+	}
+	return output
+}
+
 // Helper to find a compose project path when it's not provided
-func (s *Service) findComposeProjectPath(projectName string) string {
+func (s *Service) findComposeProjectPath(ctx context.Context, projectName string) string {
 	// Try to use docker compose config with the project name
-	cmd := exec.Command("docker", "compose", "--project-name", projectName, "config", "--format", "json")
-	output, err := cmd.Output()
+	output, err := runCommand(ctx, "docker", "compose", "--project-name", projectName, "config", "--format", "json")
 	if err == nil {
 		// Try to extract the working directory
 		var config map[string]interface{}
@@ -840,8 +1526,7 @@ func (s *Service) findComposeProjectPath(projectName string) string {
 	}
 
 	// Next try to find the path by running config for each possible docker-compose.yml
-	cmd = exec.Command("docker", "compose", "ls", "-a")
-	output, err = cmd.Output()
+	output, err = runCommand(ctx, "docker", "compose", "ls", "-a")
 	if err == nil {
 		// Try to find the project in the detailed listing
 		lines := strings.Split(string(output), "\n")
@@ -860,31 +1545,17 @@ func (s *Service) findComposeProjectPath(projectName string) string {
 	return "."
 }
 
-// tryExtractProjectsViaConfig tries to get project info by running compose config
-func (s *Service) tryExtractProjectsViaConfig() []ComposeInfo {
-	// Find all projects in the current directory
-	cmd := exec.Command("find", ".", "-name", "docker-compose.yml", "-o", "-name", "compose.yaml", "-o", "-name", "compose.yml", "-o", "-name", "docker-compose.yaml")
-	output, err := cmd.Output()
-	if err != nil {
-		return nil
-	}
+// tryExtractProjectsViaConfig looks for compose projects that aren't running
+// under the compose search roots, naming them by running compose config
+func (s *Service) tryExtractProjectsViaConfig(ctx context.Context) []ComposeInfo {
+	s.mu.RLock()
+	roots, depth := s.composeRoots, s.composeDepth
+	s.mu.RUnlock()
 
 	var projects []ComposeInfo
-	files := strings.Split(string(output), "\n")
-	for _, file := range files {
-		if file == "" {
-			continue
-		}
-
-		// Get the directory
-		dir := file[:strings.LastIndex(file, "/")]
-		if dir == "" {
-			dir = "."
-		}
-
+	for _, dir := range findComposeDirs(ctx, roots, depth) {
 		// Try to get the project name
-		cmd = exec.Command("docker", "compose", "--project-directory", dir, "config", "--format", "json")
-		output, err := cmd.Output()
+		output, err := runCommand(ctx, "docker", s.composeArgs(dir, "config", "--format", "json")...)
 		if err != nil {
 			continue
 		}
@@ -899,8 +1570,7 @@ func (s *Service) tryExtractProjectsViaConfig() []ComposeInfo {
 			name = n
 		} else {
 			// Use directory name as fallback
-			dirParts := strings.Split(dir, "/")
-			name = dirParts[len(dirParts)-1]
+			name = filepath.Base(dir)
 		}
 
 		// We found a valid project
@@ -1001,60 +1671,211 @@ func (s *Service) parseComposeOutputManually(output string) []ComposeInfo {
 
 // ComposeUp starts Docker Compose project
 func (s *Service) ComposeUp(ctx context.Context, projectPath string) error {
-	cmd := exec.Command("docker", "compose", "--project-directory", projectPath, "up", "-d")
-	_, err := cmd.Output()
+	_, err := runActionCommand(ctx, "docker", s.composeArgs(projectPath, "up", "-d")...)
 	if err != nil {
-		return fmt.Errorf("failed to start Docker Compose project: %v", err)
+		return fmt.Errorf("failed to start Docker Compose project: %s", commandReason(err))
 	}
 	return nil
 }
 
 // ComposeDown stops Docker Compose project
 func (s *Service) ComposeDown(ctx context.Context, projectPath string) error {
-	cmd := exec.Command("docker", "compose", "--project-directory", projectPath, "down")
-	_, err := cmd.Output()
+	_, err := runActionCommand(ctx, "docker", s.composeArgs(projectPath, "down")...)
 	if err != nil {
-		return fmt.Errorf("failed to stop Docker Compose project: %v", err)
+		return fmt.Errorf("failed to stop Docker Compose project: %s", commandReason(err))
 	}
 	return nil
 }
 
+// ComposeRestart restarts every service of a Docker Compose project
+func (s *Service) ComposeRestart(ctx context.Context, projectPath string) error {
+	_, err := runActionCommand(ctx, "docker", s.composeArgs(projectPath, "restart")...)
+	if err != nil {
+		return fmt.Errorf("failed to restart Docker Compose project: %s", commandReason(err))
+	}
+	return nil
+}
+
+// ComposeStartService starts the containers of a single service of a Docker Compose project
+func (s *Service) ComposeStartService(ctx context.Context, projectPath, service string) error {
+	_, err := runActionCommand(ctx, "docker", s.composeArgs(projectPath, "start", service)...)
+	if err != nil {
+		return fmt.Errorf("failed to start service %s: %s", service, commandReason(err))
+	}
+	return nil
+}
+
+// ComposeStopService stops the containers of a single service of a Docker Compose project
+func (s *Service) ComposeStopService(ctx context.Context, projectPath, service string) error {
+	_, err := runActionCommand(ctx, "docker", s.composeArgs(projectPath, "stop", service)...)
+	if err != nil {
+		return fmt.Errorf("failed to stop service %s: %s", service, commandReason(err))
+	}
+	return nil
+}
+
+// ComposeScale runs the given number of replicas of a Docker Compose service.
+// Services whose container is given a fixed container_name can't have more
+// than one, since container names must be unique.
+func (s *Service) ComposeScale(ctx context.Context, projectPath, service string, replicas int) error {
+	if replicas < 1 {
+		return fmt.Errorf("replicas must be at least 1, got %d", replicas)
+	}
+
+	if replicas > 1 {
+		output, err := runCommand(ctx, "docker", s.composeArgs(projectPath, "config", "--format", "json")...)
+		if err != nil {
+			return fmt.Errorf("failed to read Docker Compose config: %s", commandReason(err))
+		}
+		var config struct {
+			Services map[string]struct {
+				ContainerName string `json:"container_name"`
+			} `json:"services"`
+		}
+		if err := json.Unmarshal(output, &config); err == nil {
+			if name := config.Services[service].ContainerName; name != "" {
+				return fmt.Errorf("service %s can't be scaled: its container is named %q by container_name, and container names must be unique", service, name)
+			}
+		}
+	}
+
+	_, err := runActionCommand(ctx, "docker", s.composeArgs(projectPath, "up", "-d", "--scale", fmt.Sprintf("%s=%d", service, replicas))...)
+	if err != nil {
+		return fmt.Errorf("failed to scale service %s: %s", service, commandReason(err))
+	}
+	return nil
+}
+
+// ComposeExecCommand returns the command running cmd in the running container
+// of a Docker Compose service, for the caller to attach to the terminal. It
+// fails if the service has no running container.
+func (s *Service) ComposeExecCommand(ctx context.Context, projectPath, service string, cmd []string) (*exec.Cmd, error) {
+	// Without a running container compose only prints a terse error, so check first
+	output, err := runCommand(ctx, "docker", s.composeArgs(projectPath, "ps", "--status", "running", "--quiet", service)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the containers of service %s: %s", service, commandReason(err))
+	}
+	if strings.TrimSpace(string(output)) == "" {
+		return nil, fmt.Errorf("service %s has no running container", service)
+	}
+
+	return exec.CommandContext(ctx, "docker", s.composeArgs(projectPath, append([]string{"exec", service}, cmd...)...)...), nil
+}
+
 // ComposePull pulls images for Docker Compose project
 func (s *Service) ComposePull(ctx context.Context, projectPath string) error {
-	cmd := exec.Command("docker", "compose", "--project-directory", projectPath, "pull")
-	_, err := cmd.Output()
+	_, err := runActionCommand(ctx, "docker", s.composeArgs(projectPath, "pull")...)
 	if err != nil {
-		return fmt.Errorf("failed to pull Docker Compose images: %v", err)
+		return fmt.Errorf("failed to pull Docker Compose images: %s", commandReason(err))
 	}
 	return nil
 }
 
-// ComposePs lists containers in a Docker Compose project
-func (s *Service) ComposePs(ctx context.Context, projectPath string) (string, error) {
-	cmd := exec.Command("docker", "compose", "--project-directory", projectPath, "ps")
-	output, err := cmd.Output()
+// ComposePsEntry is a container of a Docker Compose project, as listed by
+// docker compose ps
+type ComposePsEntry struct {
+	ID         string
+	Name       string
+	Service    string
+	Image      string
+	Command    string
+	State      string
+	Status     string
+	Health     string
+	ExitCode   int
+	Publishers []ComposePublisher
+}
+
+// ComposePublisher is a port a compose container publishes
+type ComposePublisher struct {
+	URL           string
+	TargetPort    int
+	PublishedPort int
+	Protocol      string
+}
+
+// ComposePs lists the containers of a Docker Compose project, stopped ones
+// included, ordered by service and name
+func (s *Service) ComposePs(ctx context.Context, projectPath string) ([]ComposePsEntry, error) {
+	output, err := runCommand(ctx, "docker", s.composeArgs(projectPath, "ps", "--all", "--format", "json")...)
 	if err != nil {
-		return "", fmt.Errorf("failed to list Docker Compose containers: %v", err)
+		return nil, fmt.Errorf("failed to list Docker Compose containers: %s", commandReason(err))
 	}
-	return string(output), nil
+	entries, err := parseComposePs(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Docker Compose containers: %w", err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Service != entries[j].Service {
+			return entries[i].Service < entries[j].Service
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+// parseComposePs reads the JSON output of compose ps, which older versions of
+// compose print as an array and newer ones as one object per line
+func parseComposePs(output []byte) ([]ComposePsEntry, error) {
+	output = bytes.TrimSpace(output)
+	var entries []ComposePsEntry
+	if bytes.HasPrefix(output, []byte("[")) {
+		if err := json.Unmarshal(output, &entries); err != nil {
+			return nil, err
+		}
+		return entries, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	for decoder.More() {
+		var entry ComposePsEntry
+		if err := decoder.Decode(&entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
 }
 
-// ComposeLogs gets logs for a Docker Compose project
-func (s *Service) ComposeLogs(ctx context.Context, projectPath string) (string, error) {
-	cmd := exec.Command("docker", "compose", "--project-directory", projectPath, "logs")
-	output, err := cmd.Output()
+// StreamComposeLogs streams the logs of every service in a Docker Compose
+// project, starting from the last 500 lines of each, as uncolored lines
+// prefixed with the container they came from ("web-1  | ..."). With follow,
+// new lines keep arriving until the stream is closed.
+func (s *Service) StreamComposeLogs(ctx context.Context, projectPath string, follow bool) (io.ReadCloser, error) {
+	args := s.composeArgs(projectPath, "logs", "--no-color", "--tail", "500")
+	if follow {
+		args = append(args, "--follow")
+	}
+	output, err := streamCommand(ctx, "docker", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Docker Compose logs: %v", err)
+	}
+	return output, nil
+}
+
+// ComposeServiceLogs gets logs for a single service of a Docker Compose project
+func (s *Service) ComposeServiceLogs(ctx context.Context, projectPath, service string) (string, error) {
+	if projectPath == "" {
+		return "", fmt.Errorf("project path is required")
+	}
+	if service == "" {
+		return "", fmt.Errorf("service name is required")
+	}
+
+	output, err := runCommand(ctx, "docker", s.composeArgs(projectPath, "logs", "--no-color", "--tail", "500", service)...)
 	if err != nil {
-		return "", fmt.Errorf("failed to get Docker Compose logs: %v", err)
+		return "", fmt.Errorf("failed to get logs for service %s: %s", service, commandReason(err))
 	}
 	return string(output), nil
 }
 
-// ComposeConfig validates and displays the Compose file
+// ComposeConfig validates the Compose files and returns the resolved
+// configuration, with overrides merged and variables interpolated
 func (s *Service) ComposeConfig(ctx context.Context, projectPath string) (string, error) {
-	cmd := exec.Command("docker", "compose", "--project-directory", projectPath, "config")
-	output, err := cmd.Output()
+	output, err := runCommand(ctx, "docker", s.composeArgs(projectPath, "config")...)
 	if err != nil {
-		return "", fmt.Errorf("failed to validate Docker Compose config: %v", err)
+		return "", fmt.Errorf("failed to validate Docker Compose config: %s", commandReason(err))
 	}
 	return string(output), nil
 }
@@ -1068,10 +1889,9 @@ func (s *Service) FetchComposeServiceDetails(ctx context.Context, projectPath st
 	}
 
 	// Try to get service details from the compose config
-	configCmd := exec.Command("docker", "compose", "--project-directory", projectPath, "config", "--services")
-	configOutput, err := configCmd.CombinedOutput()
+	configOutput, err := runCommand(ctx, "docker", s.composeArgs(projectPath, "config", "--services")...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get service config: %v", err)
+		return nil, fmt.Errorf("failed to get service config: %s", commandReason(err))
 	}
 
 	// Check if service exists in this project
@@ -1089,14 +1909,12 @@ func (s *Service) FetchComposeServiceDetails(ctx context.Context, projectPath st
 	}
 
 	// Get detailed config for this service
-	detailCmd := exec.Command("docker", "compose", "--project-directory", projectPath, "ps", serviceName, "--format", "json")
-	detailOutput, err := detailCmd.CombinedOutput()
+	detailOutput, err := runCommand(ctx, "docker", s.composeArgs(projectPath, "ps", serviceName, "--format", "json")...)
 	if err != nil {
 		// If the JSON format fails, try regular output
-		detailCmd = exec.Command("docker", "compose", "--project-directory", projectPath, "ps", serviceName)
-		detailOutput, err = detailCmd.CombinedOutput()
+		detailOutput, err = runCommand(ctx, "docker", s.composeArgs(projectPath, "ps", serviceName)...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get service details: %v", err)
+			return nil, fmt.Errorf("failed to get service details: %s", commandReason(err))
 		}
 	}
 
@@ -1145,8 +1963,7 @@ func (s *Service) FetchComposeServiceDetails(ctx context.Context, projectPath st
 	}
 
 	// Get image information from config
-	imageCmd := exec.Command("docker", "compose", "--project-directory", projectPath, "config", "--format", "json")
-	imageOutput, err := imageCmd.CombinedOutput()
+	imageOutput, err := runCommand(ctx, "docker", s.composeArgs(projectPath, "config", "--format", "json")...)
 
 	var image string
 	var ports []string
@@ -1183,7 +2000,7 @@ func (s *Service) FetchComposeServiceDetails(ctx context.Context, projectPath st
 	// If we couldn't get the image from config, try to extract it from containers
 	if image == "" && len(containerIDs) > 0 {
 		// Get the first container's details
-		container, err := s.client.ContainerInspect(ctx, containerIDs[0])
+		container, err := s.cli().ContainerInspect(ctx, containerIDs[0])
 		if err == nil {
 			image = container.Config.Image
 		}
@@ -1216,316 +2033,287 @@ func (s *Service) FetchComposeServiceDetails(ctx context.Context, projectPath st
 	}, nil
 }
 
-// InspectComposeProject returns information about a Docker Compose project
-func (s *Service) InspectComposeProject(ctx context.Context, projectPath string) (string, error) {
-	var result string
-	var projectName string
+// ListComposeServices returns the services defined in the active compose
+// files of a project, merged in order
+func (s *Service) ListComposeServices(ctx context.Context, projectPath string) ([]ComposeServiceInfo, error) {
+	var services []ComposeServiceInfo
 
-	// Try to extract project name from path if available
-	if projectPath != "" {
-		parts := strings.Split(projectPath, string(filepath.Separator))
-		projectName = parts[len(parts)-2] // Usually it's the parent directory name
+	// Check if the path exists before trying to use it
+	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("project path does not exist: %s", projectPath)
 	}
 
-	// First approach: Try using project name
-	if projectName != "" {
-		// Try to use docker compose config --project-name
-		configCmd := exec.Command("docker", "compose", "--project-name", projectName, "config")
-		configOutput, err := configCmd.CombinedOutput()
-		if err != nil {
-			return "", fmt.Errorf("failed to get config for project %s: %v", projectName, err)
-		}
+	// Find the compose files in use, the base file first
+	var composePaths []string
+	fileInfo, err := os.Stat(projectPath)
 
-		// Try to use docker compose ps --project-name
-		psCmd := exec.Command("docker", "compose", "--project-name", projectName, "ps", "--format", "json")
-		psOutput, err := psCmd.CombinedOutput()
-		if err != nil {
-			return "", fmt.Errorf("failed to get ps for project %s: %v", projectName, err)
+	if err == nil && !fileInfo.IsDir() {
+		// This is a direct file path, just use it
+		composePaths = []string{projectPath}
+	} else {
+		composePaths = s.activeComposeFiles(projectPath)
+		if len(composePaths) == 0 {
+			return nil, fmt.Errorf("no compose file found in path: %s", projectPath)
 		}
-
-		// Combine config and ps output
-		result = fmt.Sprintf("=== Docker Compose Project: %s ===\n\n", projectName)
-		result += fmt.Sprintf("=== Config ===\n%s\n\n", string(configOutput))
-		result += fmt.Sprintf("=== Running Containers ===\n%s\n", string(psOutput))
-
-		return result, nil
 	}
 
-	// Second approach: Try using project directory
-	if projectPath != "" {
-		// Check if the path exists
-		if _, err := os.Stat(projectPath); err == nil {
-			// Try to use docker compose config with --project-directory
-			configCmd := exec.Command("docker", "compose", "--project-directory", projectPath, "config")
-			config, err := configCmd.CombinedOutput()
-
-			if err != nil {
-				// Try with --workdir instead for older versions
-				configCmd = exec.Command("docker", "compose", "--workdir", projectPath, "config")
-				config, err = configCmd.CombinedOutput()
+	// Merge the services of every file in order, like compose does: later
+	// files override the image and add ports
+	merged := make(map[string]*ComposeServiceInfo)
+	found := false
+	for _, composePath := range composePaths {
+		servicesMap, err := readComposeServices(composePath)
+		if err != nil {
+			return nil, err
+		}
+		found = found || servicesMap != nil
 
-				if err != nil {
-					return "", fmt.Errorf("failed to get config for path %s: %v", projectPath, err)
-				}
+		for serviceName, serviceData := range servicesMap {
+			serviceMap, ok := serviceData.(map[string]interface{})
+			if !ok && serviceData != nil {
+				continue
 			}
 
-			// Try to get the service structure using config with JSON format
-			jsonConfigCmd := exec.Command("docker", "compose", "--project-directory", projectPath, "config", "--format", "json")
-			jsonConfig, jsonErr := jsonConfigCmd.CombinedOutput()
-
-			if jsonErr == nil {
-				// Try to extract service names from the JSON
-				var configData map[string]interface{}
-				jsonUnmarshalErr := json.Unmarshal(jsonConfig, &configData)
+			service, ok := merged[serviceName]
+			if !ok {
+				service = &ComposeServiceInfo{Name: serviceName}
+				merged[serviceName] = service
+			}
 
-				if jsonUnmarshalErr == nil {
-					// Check if we have a services key
-					if servicesData, ok := configData["services"].(map[string]interface{}); ok {
-						// We found services, count them for reporting
-						_ = len(servicesData) // Use the length but discard it
-					}
-				}
+			// Extract image if available
+			if image, ok := serviceMap["image"].(string); ok {
+				service.Image = image
 			}
 
-			// Try to use docker compose ps with --project-directory
-			psCmd := exec.Command("docker", "compose", "--project-directory", projectPath, "ps", "--format", "json")
-			ps, err := psCmd.CombinedOutput()
+			// Ports and volumes add up across files, while environment
+			// variables override those of earlier files by name
+			service.Ports = append(service.Ports, composeMappings(serviceMap["ports"], "published", "target")...)
+			service.Volumes = append(service.Volumes, composeMappings(serviceMap["volumes"], "source", "target")...)
+			service.Environment = mergeEnvironment(service.Environment, composeEnvironment(serviceMap["environment"]))
+			service.DependsOn = appendUnique(service.DependsOn, composeNames(serviceMap["depends_on"])...)
+			service.Networks = appendUnique(service.Networks, composeNames(serviceMap["networks"])...)
+		}
+	}
 
-			if err != nil {
-				// Try with --workdir instead for older versions
-				psCmd = exec.Command("docker", "compose", "--workdir", projectPath, "ps", "--format", "json")
-				ps, err = psCmd.CombinedOutput()
+	if !found {
+		return nil, fmt.Errorf("no services found in compose file or invalid format")
+	}
 
-				if err != nil {
-					return "", fmt.Errorf("failed to get ps for path %s: %v", projectPath, err)
-				}
-			}
+	names := make([]string, 0, len(merged))
+	for name := range merged {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		services = append(services, *merged[name])
+	}
+
+	return services, nil
+}
 
-			// Try to extract service names directly using docker compose services
-			servicesCmd := exec.Command("docker", "compose", "--project-directory", projectPath, "config", "--services")
-			_, _ = servicesCmd.CombinedOutput() // Discard the output, we don't need it here
-
-			// Try to find and read the compose file directly
-			possibleFiles := []string{
-				filepath.Join(projectPath),
-				filepath.Join(projectPath, "docker-compose.yml"),
-				filepath.Join(projectPath, "docker-compose.yaml"),
-				filepath.Join(projectPath, "compose.yml"),
-				filepath.Join(projectPath, "compose.yaml"),
+// composeMappings reads a list of ports or volumes, each either a string such
+// as "8080:80", or the long syntax with the given source and target keys
+func composeMappings(value interface{}, sourceKey, targetKey string) []string {
+	items, _ := value.([]interface{})
+	var mappings []string
+	for _, item := range items {
+		switch item := item.(type) {
+		case map[string]interface{}:
+			mapping := fmt.Sprint(item[targetKey])
+			if source, ok := item[sourceKey]; ok && source != nil {
+				mapping = fmt.Sprintf("%v:%s", source, mapping)
 			}
+			mappings = append(mappings, mapping)
+		case nil:
+		default:
+			mappings = append(mappings, fmt.Sprint(item))
+		}
+	}
+	return mappings
+}
 
-			// Check if we have a direct file path
-			fileInfo, err := os.Stat(projectPath)
-			if err == nil && !fileInfo.IsDir() {
-				// This is a direct file path, just use it
+// composeEnvironment reads environment variables given either as a list of
+// KEY=value strings or as a map
+func composeEnvironment(value interface{}) []string {
+	var env []string
+	switch value := value.(type) {
+	case []interface{}:
+		for _, item := range value {
+			env = append(env, fmt.Sprint(item))
+		}
+	case map[string]interface{}:
+		for key, v := range value {
+			if v == nil {
+				env = append(env, key)
 			} else {
-				// This is a directory, check for compose files
-				for _, file := range possibleFiles {
-					if _, err := os.Stat(file); err == nil {
-						// Found a compose file
-						break
-					}
-				}
+				env = append(env, fmt.Sprintf("%s=%v", key, v))
 			}
-
-			// Assemble the result
-			result = fmt.Sprintf("=== Docker Compose Project at %s ===\n\n", projectPath)
-			result += fmt.Sprintf("=== Config ===\n%s\n\n", string(config))
-			result += fmt.Sprintf("=== Running Containers ===\n%s\n", string(ps))
-
-			return result, nil
 		}
 	}
-
-	// If we got here, we couldn't find the project
-	return "", fmt.Errorf("couldn't find Docker Compose project at %s", projectPath)
+	return env
 }
 
-// ListComposeServices returns services defined in a Docker Compose file
-func (s *Service) ListComposeServices(ctx context.Context, projectPath string) ([]ComposeServiceInfo, error) {
-	var services []ComposeServiceInfo
-
-	// Check if the path exists before trying to use it
-	if _, err := os.Stat(projectPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("project path does not exist: %s", projectPath)
+// mergeEnvironment overrides variables of env with those of overrides by
+// name, returning them sorted
+func mergeEnvironment(env, overrides []string) []string {
+	byName := make(map[string]string, len(env)+len(overrides))
+	for _, variable := range append(env, overrides...) {
+		name, _, _ := strings.Cut(variable, "=")
+		byName[name] = variable
 	}
+	merged := make([]string, 0, len(byName))
+	for _, variable := range byName {
+		merged = append(merged, variable)
+	}
+	sort.Strings(merged)
+	return merged
+}
 
-	// Try to find the compose file
-	var composePath string
-	fileInfo, err := os.Stat(projectPath)
-
-	if err == nil && !fileInfo.IsDir() {
-		// This is a direct file path, just use it
-		composePath = projectPath
-	} else {
-		// This is a directory, look for compose files
-		possibleFiles := []string{
-			filepath.Join(projectPath, "docker-compose.yml"),
-			filepath.Join(projectPath, "docker-compose.yaml"),
-			filepath.Join(projectPath, "compose.yml"),
-			filepath.Join(projectPath, "compose.yaml"),
+// composeNames reads a list of names, or the keys of a map of them as used
+// by depends_on and networks with options
+func composeNames(value interface{}) []string {
+	var names []string
+	switch value := value.(type) {
+	case []interface{}:
+		for _, item := range value {
+			names = append(names, fmt.Sprint(item))
 		}
-
-		for _, file := range possibleFiles {
-			if _, err := os.Stat(file); err == nil {
-				composePath = file
-				break
-			}
+	case map[string]interface{}:
+		for name := range value {
+			names = append(names, name)
 		}
+		sort.Strings(names)
+	}
+	return names
+}
 
-		if composePath == "" {
-			return nil, fmt.Errorf("no compose file found in path: %s", projectPath)
+// appendUnique appends the values not in list yet
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		if !slices.Contains(list, value) {
+			list = append(list, value)
 		}
 	}
+	return list
+}
 
-	// Try to read and parse the compose file
-	composeContent, readErr := os.ReadFile(composePath)
-	if readErr != nil {
-		return nil, fmt.Errorf("failed to read compose file: %v", readErr)
+// readComposeServices reads the services section of a compose file, nil if
+// it has none
+func readComposeServices(composePath string) (map[string]interface{}, error) {
+	composeContent, err := os.ReadFile(composePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compose file: %v", err)
 	}
 
 	if len(composeContent) == 0 {
 		return nil, fmt.Errorf("compose file is empty: %s", composePath)
 	}
 
-	// Try to parse the YAML content
 	var composeData map[string]interface{}
-	err = yaml.Unmarshal(composeContent, &composeData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse compose file: %v", err)
+	if err := yaml.Unmarshal(composeContent, &composeData); err != nil {
+		return nil, fmt.Errorf("failed to parse compose file %s: %v", filepath.Base(composePath), err)
 	}
 
-	// Check if we have a services section
-	servicesMap, ok := composeData["services"].(map[string]interface{})
-	if !ok {
-		// No services found or not a map
-		return nil, fmt.Errorf("no services found in compose file or invalid format")
+	// Override files may not have a services section
+	servicesMap, _ := composeData["services"].(map[string]interface{})
+	return servicesMap, nil
+}
+
+// ListComposeContainers returns containers for a Docker Compose project
+func (s *Service) ListComposeContainers(ctx context.Context, projectName string) ([]ContainerInfo, error) {
+	if projectName == "" {
+		return []ContainerInfo{}, fmt.Errorf("no project name provided")
 	}
 
-	// Process each service
-	for serviceName, serviceData := range servicesMap {
-		serviceMap, ok := serviceData.(map[string]interface{})
-		if !ok {
+	// Try the name as given, then with dashes instead of underscores and
+	// lowercased, as compose normalizes project names
+	var containers []ContainerInfo
+	tried := make(map[string]bool)
+	for _, name := range []string{projectName, strings.ReplaceAll(projectName, "_", "-"), strings.ToLower(projectName)} {
+		if len(containers) > 0 || tried[name] {
 			continue
 		}
+		tried[name] = true
 
-		// Create a new service info
-		service := ComposeServiceInfo{
-			Name: serviceName,
-		}
-
-		// Extract image if available
-		if image, ok := serviceMap["image"].(string); ok {
-			service.Image = image
-		}
-
-		// Extract ports if available
-		if portsData, ok := serviceMap["ports"].([]interface{}); ok {
-			for _, portData := range portsData {
-				if portStr, ok := portData.(string); ok {
-					service.Ports = append(service.Ports, portStr)
-				}
-			}
-		}
-
-		// Add to our list
-		services = append(services, service)
-	}
-
-	// If no services were found in the YAML, try using the command line
-	if len(services) == 0 {
-		// Try using docker compose config --services
-		cmd := exec.Command("docker", "compose", "--file", composePath, "config", "--services")
-		output, err := cmd.CombinedOutput()
-
-		if err == nil {
-			// Split by newlines to get service names
-			serviceNames := strings.Split(strings.TrimSpace(string(output)), "\n")
-
-			// Add each service name if it's not empty
-			for _, name := range serviceNames {
-				name = strings.TrimSpace(name)
-				if name != "" {
-					services = append(services, ComposeServiceInfo{
-						Name: name,
-					})
-				}
-			}
-		} else {
-			// Command failed, but we'll continue with other approaches
+		var err error
+		containers, err = s.getContainersByProjectName(ctx, name)
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	// If still no services found, add a dummy service for testing
-	if len(services) == 0 {
-		// For testing purposes only
-		services = append(services, ComposeServiceInfo{
-			Name:  "test-service",
-			Image: "test/image:latest",
-			Ports: []string{"8080:80"},
-		})
+	// If still no containers found, try using docker-compose ps command
+	if len(containers) == 0 {
+		containers = s.getContainersByComposeCommand(ctx, projectName)
 	}
 
-	return services, nil
+	return containers, nil
 }
 
-// ListComposeContainers returns containers for a Docker Compose project
-func (s *Service) ListComposeContainers(ctx context.Context, projectName string) ([]ContainerInfo, error) {
-	if projectName == "" {
-		return []ContainerInfo{}, fmt.Errorf("no project name provided")
-	}
-
-	// First try to get containers using the standard API
-	containers := s.getContainersByProjectName(ctx, projectName)
+// ListServiceReplicas returns every container of a compose service, ordered
+// by replica number
+func (s *Service) ListServiceReplicas(ctx context.Context, projectName, serviceName string) ([]ContainerInfo, error) {
+	args := filters.NewArgs()
+	args.Add("label", fmt.Sprintf("com.docker.compose.project=%s", projectName))
+	args.Add("label", fmt.Sprintf("com.docker.compose.service=%s", serviceName))
 
-	// If no containers found, try with alternate project name formats
-	if len(containers) == 0 {
-		// Try with dashes instead of underscores
-		altProjectName := strings.ReplaceAll(projectName, "_", "-")
-		if altProjectName != projectName {
-			containers = s.getContainersByProjectName(ctx, altProjectName)
-		}
+	containers, err := s.cli().ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: args,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Try with normalized (lowercase) project name
-	if len(containers) == 0 {
-		normalizedProjectName := strings.ToLower(projectName)
-		if normalizedProjectName != projectName {
-			containers = s.getContainersByProjectName(ctx, normalizedProjectName)
+	var replicas []ContainerInfo
+	for _, c := range containers {
+		name := ""
+		if len(c.Names) > 0 {
+			name = c.Names[0][1:] // Remove leading slash
 		}
-	}
 
-	// If still no containers found, try using docker-compose ps command
-	if len(containers) == 0 {
-		containers = s.getContainersByComposeCommand(projectName)
-	}
+		id := ShortID(c.ID, 12)
 
-	// If no containers found, add dummy containers for testing
-	if len(containers) == 0 && projectName == "test" {
-		containers = s.addTestContainers(projectName)
+		replicas = append(replicas, ContainerInfo{
+			ID:      id,
+			Name:    name,
+			Image:   c.Image,
+			Command: c.Command,
+			Status:  c.Status,
+			State:   c.State,
+			Created: time.Unix(c.Created, 0),
+			Ports:   c.Ports,
+			Labels:  c.Labels,
+		})
 	}
 
-	return containers, nil
+	sort.Slice(replicas, func(i, j int) bool {
+		return replicaNumber(replicas[i]) < replicaNumber(replicas[j])
+	})
+	return replicas, nil
+}
+
+// replicaNumber returns the compose replica number of a container, or 0 if it has none
+func replicaNumber(c ContainerInfo) int {
+	n, _ := strconv.Atoi(c.Labels["com.docker.compose.container-number"])
+	return n
 }
 
 // Helper method to get containers by project name using Docker API
-func (s *Service) getContainersByProjectName(ctx context.Context, projectName string) []ContainerInfo {
+func (s *Service) getContainersByProjectName(ctx context.Context, projectName string) ([]ContainerInfo, error) {
 	// Create filter args for the Docker API
 	args := filters.NewArgs()
 	args.Add("label", fmt.Sprintf("com.docker.compose.project=%s", projectName))
 
 	// Get containers with the specified label
-	containers, err := s.client.ContainerList(ctx, container.ListOptions{
+	containers, err := s.cli().ContainerList(ctx, container.ListOptions{
 		All:     true,
 		Filters: args,
 	})
 	if err != nil {
-		fmt.Printf("DEBUG: Error listing containers for project %s: %v\n", projectName, err)
-		return nil
+		return nil, fmt.Errorf("failed to list the containers of project %s: %w", projectName, err)
 	}
 
-	fmt.Printf("DEBUG: Found %d containers for project %s via API\n", len(containers), projectName)
-
 	// Convert to ContainerInfo objects
 	var containerInfos []ContainerInfo
 	for _, c := range containers {
@@ -1534,10 +2322,7 @@ func (s *Service) getContainersByProjectName(ctx context.Context, projectName st
 			name = c.Names[0][1:] // Remove leading slash
 		}
 
-		id := c.ID
-		if len(id) > 12 {
-			id = id[:12] // Short ID
-		}
+		id := ShortID(c.ID, 12)
 
 		// Get service name from label
 		serviceName := ""
@@ -1555,6 +2340,7 @@ func (s *Service) getContainersByProjectName(ctx context.Context, projectName st
 			State:   c.State,
 			Created: time.Unix(c.Created, 0),
 			Ports:   c.Ports,
+			Labels:  c.Labels,
 		}
 
 		// Add service name to container name for clarity
@@ -1563,23 +2349,19 @@ func (s *Service) getContainersByProjectName(ctx context.Context, projectName st
 		}
 
 		containerInfos = append(containerInfos, containerInfo)
-		fmt.Printf("DEBUG: Added container: %s, Service: %s\n", name, serviceName)
 	}
 
-	return containerInfos
+	return containerInfos, nil
 }
 
 // Helper method to get containers using docker-compose ps command
-func (s *Service) getContainersByComposeCommand(projectName string) []ContainerInfo {
+func (s *Service) getContainersByComposeCommand(ctx context.Context, projectName string) []ContainerInfo {
 	var containerInfos []ContainerInfo
 
 	// Try with --format json first for newer Docker versions
-	cmd := exec.Command("docker", "compose", "--project-name", projectName, "ps", "--format", "json")
-	output, err := cmd.CombinedOutput()
+	output, err := runCommand(ctx, "docker", "compose", "--project-name", projectName, "ps", "--format", "json")
 
 	if err == nil && len(output) > 0 {
-		fmt.Printf("DEBUG: Compose ps command successful, parsing output\n")
-
 		// Try to parse JSON array of containers
 		var composeContainers []map[string]interface{}
 		if err := json.Unmarshal(output, &composeContainers); err == nil {
@@ -1592,9 +2374,7 @@ func (s *Service) getContainersByComposeCommand(projectName string) []ContainerI
 				service, _ := container["Service"].(string)
 
 				if id != "" {
-					if len(id) > 12 {
-						id = id[:12]
-					}
+					id = ShortID(id, 12)
 
 					containerName := name
 					if service != "" {
@@ -1609,15 +2389,11 @@ func (s *Service) getContainersByComposeCommand(projectName string) []ContainerI
 						State:   state,
 						Created: time.Now(), // We don't have creation time from this command
 					})
-
-					fmt.Printf("DEBUG: Added container from compose ps: %s, Service: %s\n", name, service)
 				}
 			}
 			return containerInfos
 		}
 
-		fmt.Printf("DEBUG: Failed to parse compose ps output as JSON: %v\n", err)
-
 		// Try text parsing as fallback
 		containerInfos = s.parseComposeTextOutput(output)
 		if len(containerInfos) > 0 {
@@ -1626,16 +2402,14 @@ func (s *Service) getContainersByComposeCommand(projectName string) []ContainerI
 	}
 
 	// Try without --format for older Docker versions
-	cmd = exec.Command("docker", "compose", "--project-name", projectName, "ps")
-	output, err = cmd.CombinedOutput()
+	output, err = runCommand(ctx, "docker", "compose", "--project-name", projectName, "ps")
 	if err == nil && len(output) > 0 {
 		containerInfos = s.parseComposeTextOutput(output)
 		return containerInfos
 	}
 
 	// One last try with docker-compose (hyphenated) for older Docker versions
-	cmd = exec.Command("docker-compose", "--project-name", projectName, "ps")
-	output, err = cmd.CombinedOutput()
+	output, err = runCommand(ctx, "docker-compose", "--project-name", projectName, "ps")
 	if err == nil && len(output) > 0 {
 		return s.parseComposeTextOutput(output)
 	}
@@ -1657,10 +2431,7 @@ func (s *Service) parseComposeTextOutput(output []byte) []ContainerInfo {
 		parts := strings.Fields(line)
 		if len(parts) >= 3 {
 			// Basic extraction of ID, name and status
-			id := parts[0]
-			if len(id) > 12 {
-				id = id[:12]
-			}
+			id := ShortID(parts[0], 12)
 
 			name := parts[1]
 
@@ -1697,167 +2468,43 @@ func (s *Service) parseComposeTextOutput(output []byte) []ContainerInfo {
 				State:   state,
 				Created: time.Now(),
 			})
-
-			fmt.Printf("DEBUG: Added container from text parsing: %s\n", name)
 		}
 	}
 
 	return containerInfos
 }
 
-// Helper method to add test containers for development
-func (s *Service) addTestContainers(projectName string) []ContainerInfo {
-	fmt.Printf("DEBUG: No containers found, adding test containers\n")
-
-	var containerInfos []ContainerInfo
-
-	// Add test containers with names matching the project
-	containerInfos = append(containerInfos, ContainerInfo{
-		ID:      "test1234567",
-		Name:    fmt.Sprintf("%s_mongodb_1 (mongodb)", projectName),
-		Image:   "mongo:latest",
-		Command: "mongod",
-		Status:  "Up 2 hours",
-		State:   "running",
-		Created: time.Now().Add(-2 * time.Hour),
-	})
-
-	containerInfos = append(containerInfos, ContainerInfo{
-		ID:      "test7654321",
-		Name:    fmt.Sprintf("%s_api_1 (api)", projectName),
-		Image:   projectName + "/api:latest",
-		Command: "node server.js",
-		Status:  "Up 2 hours",
-		State:   "running",
-		Created: time.Now().Add(-2 * time.Hour),
-	})
-
-	return containerInfos
-}
-
 // SubscribeToEvents subscribes to Docker API events and calls the provided callback
-// function when events occur. This function blocks until the context is canceled.
+// function when events occur. This function blocks until the context is canceled
+// or the event stream fails.
 func (s *Service) SubscribeToEvents(ctx context.Context, callback EventCallback) error {
-	// For now, we'll implement a simple polling mechanism
-	// instead of using the event stream directly due to API compatibility issues
-	ticker := time.NewTicker(2 * time.Second)
-	defer ticker.Stop()
-
-	var lastContainerIDs []string
-	var lastImageIDs []string
-	var lastVolumeNames []string
-	var lastNetworkIDs []string
+	options := events.ListOptions{Filters: filters.NewArgs(
+		filters.Arg("type", string(events.ContainerEventType)),
+		filters.Arg("type", string(events.ImageEventType)),
+		filters.Arg("type", string(events.VolumeEventType)),
+		filters.Arg("type", string(events.NetworkEventType)),
+	)}
+	messages, errs := s.cli().Events(ctx, options)
 
-	// Main loop
 	for {
 		select {
-		case <-ticker.C:
-			// Check for container changes
-			containers, err := s.ListContainers(ctx, true)
-			if err == nil {
-				currentIDs := make([]string, len(containers))
-				for i, c := range containers {
-					currentIDs[i] = c.ID
-				}
-
-				// If this is not the first check and the list has changed
-				if len(lastContainerIDs) > 0 && !stringSlicesEqual(lastContainerIDs, currentIDs) {
-					callback(DockerEvent{
-						Type:   "container",
-						Action: "update",
-						Time:   time.Now(),
-					})
-				}
-
-				lastContainerIDs = currentIDs
-			}
-
-			// Check for image changes
-			images, err := s.ListImages(ctx)
-			if err == nil {
-				currentIDs := make([]string, len(images))
-				for i, img := range images {
-					currentIDs[i] = img.ID
-				}
-
-				// If this is not the first check and the list has changed
-				if len(lastImageIDs) > 0 && !stringSlicesEqual(lastImageIDs, currentIDs) {
-					callback(DockerEvent{
-						Type:   "image",
-						Action: "update",
-						Time:   time.Now(),
-					})
-				}
-
-				lastImageIDs = currentIDs
-			}
-
-			// Check for volume changes
-			volumes, err := s.ListVolumes(ctx)
-			if err == nil {
-				currentNames := make([]string, len(volumes))
-				for i, vol := range volumes {
-					currentNames[i] = vol.Name
-				}
-
-				// If this is not the first check and the list has changed
-				if len(lastVolumeNames) > 0 && !stringSlicesEqual(lastVolumeNames, currentNames) {
-					callback(DockerEvent{
-						Type:   "volume",
-						Action: "update",
-						Time:   time.Now(),
-					})
-				}
-
-				lastVolumeNames = currentNames
-			}
-
-			// Check for network changes
-			networks, err := s.ListNetworks(ctx)
-			if err == nil {
-				currentIDs := make([]string, len(networks))
-				for i, nw := range networks {
-					currentIDs[i] = nw.ID
-				}
-
-				// If this is not the first check and the list has changed
-				if len(lastNetworkIDs) > 0 && !stringSlicesEqual(lastNetworkIDs, currentIDs) {
-					callback(DockerEvent{
-						Type:   "network",
-						Action: "update",
-						Time:   time.Now(),
-					})
-				}
+		case msg := <-messages:
+			callback(DockerEvent{
+				Type:       string(msg.Type),
+				Action:     string(msg.Action),
+				ID:         msg.Actor.ID,
+				Time:       time.Unix(0, msg.TimeNano),
+				Resource:   msg.Actor.Attributes["name"],
+				Attributes: msg.Actor.Attributes,
+			})
 
-				lastNetworkIDs = currentIDs
+		case err := <-errs:
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
-
-		case <-ctx.Done():
-			return ctx.Err()
-		}
-	}
-}
-
-// Helper function to compare two string slices
-func stringSlicesEqual(a, b []string) bool {
-	if len(a) != len(b) {
-		return false
-	}
-
-	// Create maps for quick lookups
-	mapA := make(map[string]bool, len(a))
-	for _, str := range a {
-		mapA[str] = true
-	}
-
-	// Check if all elements in b are in a
-	for _, str := range b {
-		if !mapA[str] {
-			return false
+			return err
 		}
 	}
-
-	return true
 }
 
 // ComposeServiceAction performs an action on a specific Docker Compose service
@@ -1871,28 +2518,28 @@ func (s *Service) ComposeServiceAction(ctx context.Context, projectPath string,
 	}
 
 	// Map the action to a Docker Compose command
-	var cmd *exec.Cmd
+	var args []string
 	switch strings.ToLower(action) {
 	case "up", "start":
-		cmd = exec.Command("docker", "compose", "--project-directory", projectPath, "up", "-d", serviceName)
+		args = []string{"up", "-d", serviceName}
 	case "down", "stop":
-		cmd = exec.Command("docker", "compose", "--project-directory", projectPath, "stop", serviceName)
+		args = []string{"stop", serviceName}
 	case "restart":
-		cmd = exec.Command("docker", "compose", "--project-directory", projectPath, "restart", serviceName)
+		args = []string{"restart", serviceName}
 	case "pull":
-		cmd = exec.Command("docker", "compose", "--project-directory", projectPath, "pull", serviceName)
+		args = []string{"pull", serviceName}
 	case "logs":
-		cmd = exec.Command("docker", "compose", "--project-directory", projectPath, "logs", serviceName)
+		args = []string{"logs", serviceName}
 	case "ps":
-		cmd = exec.Command("docker", "compose", "--project-directory", projectPath, "ps", serviceName)
+		args = []string{"ps", serviceName}
 	default:
 		return fmt.Errorf("unsupported action: %s", action)
 	}
 
 	// Execute the command
-	output, err := cmd.CombinedOutput()
+	_, err := runActionCommand(ctx, "docker", s.composeArgs(projectPath, args...)...)
 	if err != nil {
-		return fmt.Errorf("failed to perform %s on service %s: %v\n%s", action, serviceName, err, string(output))
+		return fmt.Errorf("failed to perform %s on service %s: %s", action, serviceName, commandReason(err))
 	}
 
 	return nil
@@ -1900,7 +2547,7 @@ func (s *Service) ComposeServiceAction(ctx context.Context, projectPath string,
 
 // GetSystemInfo returns system-wide Docker information
 func (s *Service) GetSystemInfo(ctx context.Context) (SystemInfo, error) {
-	info, err := s.client.Info(ctx)
+	info, err := s.cli().Info(ctx)
 	if err != nil {
 		return SystemInfo{}, err
 	}
@@ -1915,13 +2562,13 @@ func (s *Service) GetSystemInfo(ctx context.Context) (SystemInfo, error) {
 	}
 
 	// Get network count
-	networks, err := s.client.NetworkList(ctx, network.ListOptions{})
+	networks, err := s.cli().NetworkList(ctx, network.ListOptions{})
 	if err == nil {
 		systemInfo.Networks = len(networks)
 	}
 
 	// Get volume count
-	volumes, err := s.client.VolumeList(ctx, volume.ListOptions{Filters: filters.Args{}})
+	volumes, err := s.cli().VolumeList(ctx, volume.ListOptions{Filters: filters.Args{}})
 	if err == nil {
 		systemInfo.Volumes = len(volumes.Volumes)
 	}
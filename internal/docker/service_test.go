@@ -0,0 +1,112 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+)
+
+// fakeDaemon returns a service talking to a test server standing in for the
+// Docker daemon
+func fakeDaemon(t *testing.T, handler http.HandlerFunc) *Service {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cli, err := client.NewClientWithOpts(client.WithHost("tcp://"+strings.TrimPrefix(server.URL, "http://")), client.WithVersion("1.45"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cli.Close() })
+	return &Service{client: cli}
+}
+
+func TestUpdateContainerResourcesAboveSwap(t *testing.T) {
+	const mb = 1024 * 1024
+
+	// The container runs with a 256m limit and 512m of memory and swap
+	memory, swap := int64(256*mb), int64(512*mb)
+	service := fakeDaemon(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/containers/web/update") {
+			http.NotFound(w, r)
+			return
+		}
+		var update container.UpdateConfig
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// The daemon's check on a memory limit raised without the swap limit
+		if update.MemorySwap != 0 {
+			swap = update.MemorySwap
+		}
+		if update.Memory > 0 && swap > 0 && update.Memory > swap {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{
+				"message": "Memory limit should be smaller than already set memoryswap limit, update the memoryswap limit first",
+			})
+			return
+		}
+		if update.Memory > 0 {
+			memory = update.Memory
+		}
+		json.NewEncoder(w).Encode(container.ContainerUpdateOKBody{})
+	})
+
+	if err := service.UpdateContainerResources(context.Background(), "web", 1024*mb, 0); err != nil {
+		t.Fatalf("UpdateContainerResources() above the swap limit: %v", err)
+	}
+	if memory != 1024*mb || swap != 2048*mb {
+		t.Errorf("memory = %dm, swap = %dm, want 1024m and 2048m", memory/mb, swap/mb)
+	}
+
+	// CPU shares alone leave both limits as they are
+	if err := service.UpdateContainerResources(context.Background(), "web", 0, 512); err != nil {
+		t.Fatalf("UpdateContainerResources() of CPU shares: %v", err)
+	}
+	if memory != 1024*mb || swap != 2048*mb {
+		t.Errorf("memory = %dm, swap = %dm after changing CPU shares, want 1024m and 2048m", memory/mb, swap/mb)
+	}
+}
+
+func TestContainerTemplateRestartPolicy(t *testing.T) {
+	tests := []struct {
+		policy container.RestartPolicy
+		want   string
+	}{
+		{container.RestartPolicy{Name: container.RestartPolicyOnFailure, MaximumRetryCount: 5}, "on-failure:5"},
+		{container.RestartPolicy{Name: container.RestartPolicyOnFailure}, "on-failure"},
+		{container.RestartPolicy{Name: container.RestartPolicyAlways}, "always"},
+		{container.RestartPolicy{Name: container.RestartPolicyDisabled}, "no"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			service := fakeDaemon(t, func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(container.InspectResponse{
+					ContainerJSONBase: &container.ContainerJSONBase{
+						Name:       "/web",
+						HostConfig: &container.HostConfig{RestartPolicy: tt.policy},
+					},
+					Config: &container.Config{Image: "nginx"},
+				})
+			})
+
+			config, err := service.ContainerTemplate(context.Background(), "web")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if config.Restart != tt.want {
+				t.Errorf("ContainerTemplate() restart policy = %q, want %q", config.Restart, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package docker
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"testing"
+)
+
+// loadStatsSamples reads the stats samples, by name. Each is a whole
+// /containers/{id}/stats?stream=false response in the daemon's format, from
+// a cgroup v2 host except cgroupV1, with CPU counters picked so the expected
+// percentages are exact. They weren't captured from a daemon; to check a real
+// one, add its response from
+// curl --unix-socket /var/run/docker.sock "http://localhost/containers/<id>/stats?stream=false"
+func loadStatsSamples(t *testing.T) map[string]map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile("testdata/stats.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var samples map[string]map[string]interface{}
+	if err := json.Unmarshal(data, &samples); err != nil {
+		t.Fatal(err)
+	}
+	return samples
+}
+
+func TestCPUPercentBetween(t *testing.T) {
+	samples := loadStatsSamples(t)
+
+	tests := []struct {
+		sample  string
+		percent float64
+		ok      bool
+	}{
+		// 0.4s of CPU time out of 4s of host time, on 4 CPUs
+		{"streamed", 40, true},
+		// A one-off read has nothing to measure against
+		{"oneOff", 0, false},
+		// Without online_cpus, the per-CPU counters give the CPU count
+		{"cgroupV1", 30, true},
+		// The counters went backwards, so no usage rather than a negative one
+		{"restarted", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sample, func(t *testing.T) {
+			sample := samples[tt.sample]
+			cpu, _ := sample["cpu_stats"].(map[string]interface{})
+			preCPU, _ := sample["precpu_stats"].(map[string]interface{})
+
+			percent, ok := cpuPercentBetween(cpu, preCPU)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if math.Abs(percent-tt.percent) > 1e-9 {
+				t.Errorf("percent = %v, want %v", percent, tt.percent)
+			}
+		})
+	}
+}
+
+func TestProcessStats(t *testing.T) {
+	stats := processStats(loadStatsSamples(t)["streamed"])
+
+	if math.Abs(stats.CPUPercentage-40) > 1e-9 {
+		t.Errorf("CPUPercentage = %v, want 40", stats.CPUPercentage)
+	}
+	if stats.MemoryUsage != 52428800 || stats.MemoryLimit != 2097152000 {
+		t.Errorf("memory = %d of %d, want 52428800 of 2097152000", stats.MemoryUsage, stats.MemoryLimit)
+	}
+	if math.Abs(stats.MemoryPercentage-2.5) > 1e-9 {
+		t.Errorf("MemoryPercentage = %v, want 2.5", stats.MemoryPercentage)
+	}
+}
@@ -0,0 +1,26 @@
+package docker
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/system"
+)
+
+// SystemInfo returns what the daemon reports about itself and its host, as
+// shown by `docker info`
+func (s *Service) SystemInfo(ctx context.Context) (system.Info, error) {
+	return s.cli().Info(ctx)
+}
+
+// ServerVersion returns the versions of the daemon and its components, as
+// shown by `docker version`
+func (s *Service) ServerVersion(ctx context.Context) (types.Version, error) {
+	return s.cli().ServerVersion(ctx)
+}
+
+// ClientAPIVersion returns the API version the client talks to the daemon
+// with, either negotiated or pinned with dockerAPIVersion
+func (s *Service) ClientAPIVersion() string {
+	return s.cli().ClientVersion()
+}
@@ -0,0 +1,129 @@
+// Package metrics serves the stats of the running containers over HTTP in
+// the Prometheus text format, for monitoring beyond the life of a session.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// shutdownTimeout is how long scrapes in progress get to finish once the
+// server is shut down
+const shutdownTimeout = 2 * time.Second
+
+// metric is a series written for every running container
+type metric struct {
+	name, help, kind string
+	value            func(docker.ContainerStats) float64
+}
+
+// metrics are the series served, each labelled with the container's name and ID
+var metrics = []metric{
+	{"docker_tea_container_cpu_percent", "CPU usage of the container, 100 per fully used core.", "gauge",
+		func(s docker.ContainerStats) float64 { return s.CPUPercentage }},
+	{"docker_tea_container_memory_percent", "Memory usage of the container, as a percentage of its limit.", "gauge",
+		func(s docker.ContainerStats) float64 { return s.MemoryPercentage }},
+	{"docker_tea_container_network_receive_bytes_total", "Bytes received by the container over all its networks.", "counter",
+		func(s docker.ContainerStats) float64 { return float64(s.NetworkRx) }},
+	{"docker_tea_container_network_transmit_bytes_total", "Bytes sent by the container over all its networks.", "counter",
+		func(s docker.ContainerStats) float64 { return float64(s.NetworkTx) }},
+	{"docker_tea_container_block_read_bytes_total", "Bytes read by the container from block devices.", "counter",
+		func(s docker.ContainerStats) float64 { return float64(s.BlockRead) }},
+	{"docker_tea_container_block_write_bytes_total", "Bytes written by the container to block devices.", "counter",
+		func(s docker.ContainerStats) float64 { return float64(s.BlockWrite) }},
+}
+
+// Serve starts serving /metrics on addr, e.g. ":9090", sampling the stats on
+// every scrape. A bad or busy address is reported right away. The server is
+// shut down once ctx is cancelled, and the returned channel closed after that.
+func Serve(ctx context.Context, addr string, service *docker.Service) (<-chan struct{}, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serve metrics on %s: %w", addr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		handleMetrics(w, r, service)
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Metrics server stopped: %v", err)
+		}
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("Failed to shut down the metrics server: %v", err)
+		}
+	}()
+	return done, nil
+}
+
+// handleMetrics samples the running containers and writes their stats
+func handleMetrics(w http.ResponseWriter, r *http.Request, service *docker.Service) {
+	stats, err := service.GetAllProcessedStats(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	// The stats are keyed by full ID and the list has short ones, so the
+	// names to label them with are matched by the short form
+	shortNames := make(map[string]string)
+	if containers, err := service.ListContainers(r.Context(), false); err == nil {
+		for _, c := range containers {
+			shortNames[docker.ShortID(c.ID, 12)] = c.Name
+		}
+	}
+
+	ids := make([]string, 0, len(stats))
+	names := make(map[string]string, len(stats))
+	for id := range stats {
+		ids = append(ids, id)
+		names[id] = shortNames[docker.ShortID(id, 12)]
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if names[ids[i]] != names[ids[j]] {
+			return names[ids[i]] < names[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+
+	var sb strings.Builder
+	for _, m := range metrics {
+		fmt.Fprintf(&sb, "# HELP %s %s\n", m.name, m.help)
+		fmt.Fprintf(&sb, "# TYPE %s %s\n", m.name, m.kind)
+		for _, id := range ids {
+			name := names[id]
+			if name == "" {
+				name = docker.ShortID(id, 12)
+			}
+			fmt.Fprintf(&sb, "%s{name=\"%s\",id=\"%s\"} %g\n", m.name, labelValue(name), labelValue(id), m.value(stats[id]))
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(sb.String()))
+}
+
+// labelValue escapes a label value for the Prometheus text format
+func labelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
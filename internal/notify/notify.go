@@ -0,0 +1,53 @@
+// Package notify shows desktop notifications using the tools each platform
+// ships with: notify-send on Linux and the BSDs, osascript on macOS and
+// PowerShell on Windows.
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Send shows a desktop notification with a title and a message
+func Send(title, message string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		cmd = exec.Command("osascript", "-e", script)
+	case "windows":
+		cmd = exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", windowsToast(title, message))
+	default:
+		cmd = exec.Command("notify-send", "--app-name=docker-tea", title, message)
+	}
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if text := strings.TrimSpace(string(output)); text != "" {
+			return fmt.Errorf("desktop notification failed: %s", text)
+		}
+		return fmt.Errorf("desktop notification failed: %w", err)
+	}
+	return nil
+}
+
+// appleScriptString quotes text as an AppleScript string literal
+func appleScriptString(text string) string {
+	text = strings.ReplaceAll(text, `\`, `\\`)
+	return `"` + strings.ReplaceAll(text, `"`, `\"`) + `"`
+}
+
+// windowsToast builds a PowerShell script showing a toast notification
+func windowsToast(title, message string) string {
+	quote := func(text string) string {
+		return "'" + strings.ReplaceAll(text, "'", "''") + "'"
+	}
+	return `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] > $null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$texts = $template.GetElementsByTagName('text')
+$texts.Item(0).AppendChild($template.CreateTextNode(` + quote(title) + `)) > $null
+$texts.Item(1).AppendChild($template.CreateTextNode(` + quote(message) + `)) > $null
+$toast = [Windows.UI.Notifications.ToastNotification]::new($template)
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('docker-tea').Show($toast)`
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// autoRefreshTickMsg asks for the current list to be refreshed
+type autoRefreshTickMsg struct{}
+
+// autoRefreshMsg wraps the result of a periodic refresh, so it can update the
+// list without replacing the status message
+type autoRefreshMsg struct {
+	msg tea.Msg
+}
+
+// autoRefreshTick schedules the next periodic refresh
+func autoRefreshTick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return autoRefreshTickMsg{}
+	})
+}
+
+// handleAutoRefreshTick refreshes the list on screen and schedules the next
+// refresh. Lists aren't refreshed while paused, while another view is open,
+// or while the user is typing, so rows don't move underneath them.
+func (m FullModel) handleAutoRefreshTick() tea.Cmd {
+	next := autoRefreshTick(m.config.RefreshInterval)
+	if !m.autoRefresh || m.currentMode != ListMode || !m.dockerConnected ||
+		m.prompt.active || m.picker.active || m.confirm.active || m.createForm.active ||
+		m.resourceForm.active || m.diskUsage.open || m.daemonInfo.open || m.dashboard.open {
+		return next
+	}
+
+	var fetch tea.Cmd
+	switch m.currentTab {
+	case ContainersTab:
+		fetch = m.fetchContainers
+	case ImagesTab:
+		fetch = m.fetchImages
+	case VolumesTab:
+		fetch = m.fetchVolumes
+	case NetworksTab:
+		fetch = m.fetchNetworks
+	default:
+		// The Events and Compose tabs are kept up to date by the events
+		// themselves; refetching compose projects runs `docker compose config`
+		// in every project directory, which is too costly to repeat every tick
+		return next
+	}
+	return tea.Batch(next, func() tea.Msg {
+		return autoRefreshMsg{msg: fetch()}
+	})
+}
+
+// toggleAutoRefresh pauses or resumes the periodic refresh
+func (m *FullModel) toggleAutoRefresh() {
+	m.autoRefresh = !m.autoRefresh
+	if m.autoRefresh {
+		m.statusMsg = fmt.Sprintf("Auto-refresh resumed (every %s)", m.config.RefreshInterval)
+	} else {
+		m.statusMsg = "Auto-refresh paused"
+	}
+}
+
+// autoRefreshSummary describes the auto-refresh state for the footer
+func (m FullModel) autoRefreshSummary() string {
+	if !m.autoRefresh {
+		return "⟳ paused"
+	}
+	return fmt.Sprintf("⟳ %s", m.config.RefreshInterval)
+}
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// cloneTemplateMsg carries the configuration of the container being cloned
+type cloneTemplateMsg struct {
+	source string
+	config docker.ContainerCreateConfig
+	err    error
+}
+
+// fetchCloneTemplate builds a create configuration from the selected container
+func (m FullModel) fetchCloneTemplate() tea.Msg {
+	if m.selectedID == "" {
+		return cloneTemplateMsg{err: fmt.Errorf("no container selected")}
+	}
+
+	config, err := m.docker.ContainerTemplate(m.ctx, m.selectedID)
+	return cloneTemplateMsg{source: m.selectedName, config: config, err: err}
+}
+
+// handleCloneTemplate opens the creation form filled in with the settings
+// of the container being cloned, so they can be changed before the copy is made
+func (m *FullModel) handleCloneTemplate(msg cloneTemplateMsg) tea.Cmd {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", msg.err)
+		return nil
+	}
+
+	m.statusMsg = fmt.Sprintf("Cloning %s", msg.source)
+	return m.openCreateForm(fmt.Sprintf("Clone %s", msg.source), msg.config)
+}
+
+// createContainer creates and starts a container from the given
+// configuration. A missing image is pulled first, showing its progress.
+func (m FullModel) createContainer(config docker.ContainerCreateConfig) tea.Cmd {
+	return func() tea.Msg {
+		if !m.docker.HasImage(m.ctx, config.Image) {
+			return pullBeforeCreateMsg{config: config}
+		}
+
+		id, err := m.docker.CreateContainer(m.ctx, config)
+		if err != nil {
+			return fullActionResultMsg{success: false, message: err.Error()}
+		}
+
+		if err := m.docker.StartContainer(m.ctx, id); err != nil {
+			// The container exists now, so still refresh the list
+			return fullActionResultMsg{
+				success: true,
+				message: fmt.Sprintf("Created %s but failed to start it: %v", containerLabel(config.Name, id), err),
+				action:  "create",
+			}
+		}
+
+		return fullActionResultMsg{
+			success: true,
+			message: fmt.Sprintf("Created and started %s", containerLabel(config.Name, id)),
+			action:  "create",
+		}
+	}
+}
+
+// splitList splits a comma separated list, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// containerLabel names a new container in messages, falling back to its
+// short ID when Docker generated the name
+func containerLabel(name, id string) string {
+	if name != "" {
+		return name
+	}
+	return docker.ShortID(id, 12)
+}
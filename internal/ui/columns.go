@@ -0,0 +1,86 @@
+package ui
+
+import "github.com/charmbracelet/bubbles/table"
+
+// cellPadding is the space the table styles put around the content of each cell
+const cellPadding = 2
+
+// columnSpec describes a column of a resource list. Flexible columns share
+// the width the others leave, in proportion to their grow weight.
+type columnSpec struct {
+	title string
+	width int // the width of a fixed column, the least a flexible one gets
+	grow  int // share of the width left over, 0 for a fixed column
+}
+
+// tableColumnSpecs lists the columns of each resource list. The widths add
+// up to fit an 80-column terminal; content that doesn't fit is cut off with
+// an ellipsis.
+var tableColumnSpecs = map[Tab][]columnSpec{
+	ContainersTab: {
+		{title: "NAME", width: 10, grow: 2},
+		{title: "STATUS", width: 14},
+		{title: "IMAGE", width: 10, grow: 3},
+		{title: "PORTS", width: 8, grow: 2},
+		{title: "UPTIME", width: 10, grow: 1},
+		{title: "ID", width: 12},
+	},
+	ImagesTab: {
+		{title: "REPOSITORY", width: 20, grow: 1},
+		{title: "SIZE", width: 10},
+		{title: "ID", width: 12},
+	},
+	VolumesTab: {
+		{title: "NAME", width: 16, grow: 2},
+		{title: "DRIVER", width: 8},
+		{title: "MOUNTPOINT", width: 20, grow: 3},
+	},
+	NetworksTab: {
+		{title: "NAME", width: 16, grow: 1},
+		{title: "DRIVER", width: 10},
+		{title: "SCOPE", width: 8},
+		{title: "ID", width: 12},
+	},
+	ComposeTab: {
+		{title: "NAME", width: 14, grow: 1},
+		{title: "STATUS", width: 14, grow: 1},
+		{title: "PATH", width: 20, grow: 3},
+	},
+}
+
+// tableColumns sizes the columns of a tab's list to fill width. On a
+// terminal too narrow for them, every column keeps its least width.
+func tableColumns(tab Tab, width int) []table.Column {
+	specs := tableColumnSpecs[tab]
+
+	remaining, grow := width, 0
+	for _, spec := range specs {
+		remaining -= spec.width + cellPadding
+		grow += spec.grow
+	}
+	remaining = max(0, remaining)
+
+	columns := make([]table.Column, len(specs))
+	given, last := 0, -1
+	for i, spec := range specs {
+		columns[i] = table.Column{Title: spec.title, Width: spec.width}
+		if spec.grow > 0 {
+			extra := remaining * spec.grow / grow
+			columns[i].Width += extra
+			given += extra
+			last = i
+		}
+	}
+	// Rounding down leaves a few cells over, which go to the last flexible column
+	if last >= 0 {
+		columns[last].Width += remaining - given
+	}
+	return columns
+}
+
+// resizeTable fits the table of a tab to the terminal, its columns filling the width
+func (m *FullModel) resizeTable(t *table.Model, tab Tab, height int) {
+	t.SetColumns(tableColumns(tab, m.width))
+	t.SetWidth(m.width)
+	t.SetHeight(height)
+}
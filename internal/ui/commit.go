@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// Fields of the commit form
+const (
+	commitFormRepo = iota
+	commitFormTag
+	commitFormMessage
+)
+
+// Patterns for the repositories and tags Docker accepts
+var (
+	repoPattern = regexp.MustCompile(`^[a-z0-9]+(?:[._-][a-z0-9]+)*(?::[0-9]+)?(?:/[a-z0-9]+(?:[._-]+[a-z0-9]+)*)*$`)
+	tagPattern  = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+)
+
+// openCommitForm asks for the repository, tag and message of an image made
+// from the selected container
+func (m *FullModel) openCommitForm() tea.Cmd {
+	if m.selectedID == "" {
+		m.statusMsg = "No container selected"
+		return nil
+	}
+
+	fields := []formField{
+		commitFormRepo:    textField("Repository", "e.g. myapp or registry:5000/team/myapp", strings.ToLower(m.selectedName)),
+		commitFormTag:     textField("Tag", "e.g. debugged", "latest"),
+		commitFormMessage: textField("Message", "optional", ""),
+	}
+	id, name := m.selectedID, m.selectedName
+	return m.openResourceForm(fmt.Sprintf("Commit %s to an image", name), fields, validateCommitForm,
+		func(m *FullModel, values []string) tea.Cmd {
+			return commitContainer(m, id, name, values)
+		})
+}
+
+// validateCommitForm checks the repository and tag
+func validateCommitForm(values []string) []string {
+	errs := make([]string, len(values))
+	if repo := values[commitFormRepo]; repo == "" {
+		errs[commitFormRepo] = "a repository is required"
+	} else if !repoPattern.MatchString(repo) {
+		errs[commitFormRepo] = "must be lowercase letters, digits and separators, e.g. team/myapp"
+	}
+	if tag := values[commitFormTag]; tag != "" && !tagPattern.MatchString(tag) {
+		errs[commitFormTag] = "may only contain letters, digits, _ . and -, and not start with . or -"
+	}
+	return errs
+}
+
+// commitContainer creates the image from the submitted form, then shows it
+// on the Images tab
+func commitContainer(m *FullModel, id, name string, values []string) tea.Cmd {
+	repo, tag, message := values[commitFormRepo], values[commitFormTag], values[commitFormMessage]
+	ref := repo
+	if tag != "" {
+		ref += ":" + tag
+	}
+
+	m.statusMsg = fmt.Sprintf("Committing %s as %s...", name, ref)
+	return func() tea.Msg {
+		imageID, err := m.docker.CommitContainer(m.ctx, id, repo, tag, message)
+		if err != nil {
+			return resourceCreatedMsg{result: fullActionResultMsg{success: false, message: fmt.Sprintf("Error: %v", err)}}
+		}
+		imageID = strings.TrimPrefix(imageID, "sha256:")
+		return resourceCreatedMsg{
+			result: fullActionResultMsg{success: true, message: fmt.Sprintf("Committed %s as %s (image %s)", name, ref, docker.ShortID(imageID, 12))},
+			list:   m.fetchImages(),
+			tab:    ImagesTab,
+			id:     imageID,
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// composeShell is the command run when opening a shell in a compose service
+var composeShell = []string{"/bin/sh"}
+
+// composeExecMsg carries the command opening a shell in a compose service,
+// once the service is known to have a running container
+type composeExecMsg struct {
+	service string
+	command *exec.Cmd
+	err     error
+}
+
+// execIntoComposeService opens a shell in the running container of the
+// service selected in the compose inspect view
+func (m *FullModel) execIntoComposeService() tea.Cmd {
+	if m.composeServiceCursor >= len(m.composeServiceList) {
+		m.statusMsg = "No compose service selected"
+		return nil
+	}
+
+	path := m.selectedProjectPath
+	if path == "" {
+		path = m.selectedPath
+	}
+	project := m.selectedName
+	service := m.composeServiceList[m.composeServiceCursor].Name
+
+	running := false
+	for _, c := range m.containers {
+		if c.Labels[composeLabelProject] == project && c.Labels[composeLabelService] == service && c.State == "running" {
+			running = true
+		}
+	}
+	if !running {
+		m.statusMsg = fmt.Sprintf("Service %s has no running container (%s to start it)", service, DefaultFullKeyMap.Start.Help().Key)
+		return nil
+	}
+
+	m.statusMsg = fmt.Sprintf("Opening a shell in %s...", service)
+	return func() tea.Msg {
+		command, err := m.docker.ComposeExecCommand(m.ctx, path, service, composeShell)
+		return composeExecMsg{service: service, command: command, err: err}
+	}
+}
+
+// handleComposeExec hands the terminal to the shell of a compose service
+// until it exits
+func (m *FullModel) handleComposeExec(msg composeExecMsg) tea.Cmd {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", msg.err)
+		return nil
+	}
+	return tea.ExecProcess(msg.command, func(err error) tea.Msg {
+		if err != nil {
+			return fullActionResultMsg{success: false, message: fmt.Sprintf("Shell in %s: %v", msg.service, err)}
+		}
+		return fullActionResultMsg{success: true, message: fmt.Sprintf("Closed the shell in %s", msg.service)}
+	})
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pickComposeOverride lists the override files of the inspected compose
+// project and turns the chosen one on or off
+func (m *FullModel) pickComposeOverride() tea.Cmd {
+	path := m.selectedProjectPath
+	if path == "" {
+		path = m.selectedPath
+	}
+
+	var overrides []string
+	var items []string
+	for _, file := range m.docker.ComposeFiles(path) {
+		if !file.Override {
+			continue
+		}
+		mark := "[ ]"
+		if file.Active {
+			mark = "[x]"
+		}
+		overrides = append(overrides, file.Path)
+		items = append(items, fmt.Sprintf("%s %s", mark, filepath.Base(file.Path)))
+	}
+	if len(overrides) == 0 {
+		m.statusMsg = fmt.Sprintf("No override files found next to the compose file of %s", m.selectedName)
+		return nil
+	}
+
+	m.openPicker(fmt.Sprintf("Toggle override files of %s", m.selectedName), items, 0, func(m *FullModel, index int) tea.Cmd {
+		active := strings.HasPrefix(items[index], "[ ]")
+		m.docker.SetComposeOverride(path, overrides[index], active)
+		m.statusMsg = fmt.Sprintf("Compose files: %s", m.activeComposeFileNames(path))
+		return refreshInspect
+	})
+	return nil
+}
+
+// activeComposeFileNames lists the compose files in use for a project
+func (m FullModel) activeComposeFileNames(path string) string {
+	var names []string
+	for _, file := range m.docker.ComposeFiles(path) {
+		if file.Active {
+			names = append(names, filepath.Base(file.Path))
+		}
+	}
+	if len(names) == 0 {
+		return "found by compose"
+	}
+	return strings.Join(names, " + ")
+}
@@ -0,0 +1,470 @@
+package ui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/klejdi94/docker-tea/internal/docker"
+	"github.com/klejdi94/docker-tea/internal/ui/views"
+)
+
+// Limits for the interleaved compose log tail
+const (
+	composeTailLines   = 50   // lines of history fetched per container when the tail starts
+	maxComposeLogLines = 2000 // lines kept in memory across all services
+	composeLogBatch    = 500  // maximum lines handed to the UI per update
+)
+
+// Labels set by compose on the containers it creates
+const (
+	composeLabelProject = "com.docker.compose.project"
+	composeLabelService = "com.docker.compose.service"
+	composeLabelNumber  = "com.docker.compose.container-number"
+)
+
+// composeLogColors are assigned to the services of a project in turn
+var composeLogColors = []lipgloss.Color{"#88c0d0", "#a3be8c", "#ebcb8b", "#b48ead", "#d08770", "#5e81ac", "#bf616a", "#8fbcbb"}
+
+// composeLogLine is a single log line from one of the project's containers.
+// Its service is the tag shown in front of it: the service name when tailing a
+// whole project, or the replica name when tailing a single service.
+type composeLogLine struct {
+	service string
+	text    string
+}
+
+// composeLogStream is an in-app tail of every container in a compose project.
+// The container streams only write to lines; everything else is owned by Update.
+type composeLogStream struct {
+	project  string
+	service  string // set when tailing the replicas of a single service
+	cancel   context.CancelFunc
+	lines    chan composeLogLine
+	services []string
+	hidden   map[string]bool
+	buffer   []composeLogLine
+}
+
+// composeTailContainersMsg carries the containers whose logs should be tailed
+type composeTailContainersMsg struct {
+	project    string
+	service    string
+	containers []docker.ContainerInfo
+	err        error
+}
+
+// composeLogLinesMsg delivers the lines received from a compose log stream
+type composeLogLinesMsg struct {
+	stream *composeLogStream
+	lines  []composeLogLine
+	done   bool // every container stream has ended
+}
+
+// fetchComposeTailContainers lists the containers of the selected compose project
+func (m FullModel) fetchComposeTailContainers() tea.Msg {
+	containers, err := m.docker.ListComposeContainers(m.ctx, m.selectedName)
+	return composeTailContainersMsg{project: m.selectedName, containers: containers, err: err}
+}
+
+// fetchReplicaTailContainers lists the replicas of a single compose service
+func (m FullModel) fetchReplicaTailContainers(project, service string) tea.Cmd {
+	return func() tea.Msg {
+		containers, err := m.docker.ListServiceReplicas(m.ctx, project, service)
+		return composeTailContainersMsg{project: project, service: service, containers: containers, err: err}
+	}
+}
+
+// tailSelectedReplicas tails every replica of the selected container's compose service
+func (m *FullModel) tailSelectedReplicas() tea.Cmd {
+	for _, c := range m.containers {
+		if c.ID != m.selectedID {
+			continue
+		}
+		project, service := c.Labels[composeLabelProject], c.Labels[composeLabelService]
+		if project == "" || service == "" {
+			break
+		}
+		m.statusMsg = fmt.Sprintf("Finding replicas of %s...", service)
+		return m.fetchReplicaTailContainers(project, service)
+	}
+	m.statusMsg = fmt.Sprintf("%s is not part of a compose service", m.selectedName)
+	return nil
+}
+
+// composeServiceName returns the compose service a container belongs to
+func composeServiceName(c docker.ContainerInfo) string {
+	if service := c.Labels[composeLabelService]; service != "" {
+		return service
+	}
+	return c.Name
+}
+
+// replicaName tags a container with its service and replica number, like the
+// container names compose generates
+func replicaName(c docker.ContainerInfo) string {
+	if number := c.Labels[composeLabelNumber]; number != "" {
+		return composeServiceName(c) + "-" + number
+	}
+	return c.Name
+}
+
+// startComposeTail starts following the logs of every container in the project
+func (m *FullModel) startComposeTail(msg composeTailContainersMsg) tea.Cmd {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", msg.err)
+		return nil
+	}
+	if len(msg.containers) == 0 {
+		if msg.service != "" {
+			m.statusMsg = fmt.Sprintf("No replicas found for %s", msg.service)
+			return nil
+		}
+		m.statusMsg = fmt.Sprintf("No containers found for %s", msg.project)
+		return nil
+	}
+
+	m.stopComposeTail()
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	stream := &composeLogStream{
+		project: msg.project,
+		service: msg.service,
+		cancel:  cancel,
+		lines:   make(chan composeLogLine, 256),
+		hidden:  make(map[string]bool),
+	}
+
+	var wg sync.WaitGroup
+	seen := make(map[string]bool)
+	for _, c := range msg.containers {
+		service := composeServiceName(c)
+		if msg.service != "" {
+			service = replicaName(c)
+		}
+		if !seen[service] {
+			seen[service] = true
+			stream.services = append(stream.services, service)
+		}
+
+		send := func(text string) bool {
+			select {
+			case stream.lines <- composeLogLine{service: service, text: text}:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			logs, err := m.docker.StreamContainerLogs(ctx, id, true, docker.LogOptions{Tail: composeTailLines})
+			if err == nil {
+				err = readLogLines(ctx, logs, send)
+			}
+			if err != nil {
+				send(fmt.Sprintf("[log stream ended: %v]", err))
+			}
+		}(c.ID)
+	}
+
+	// Close the channel once every container stream has finished
+	go func() {
+		wg.Wait()
+		close(stream.lines)
+	}()
+
+	m.composeLogs = stream
+	m.currentMode = LogsMode
+	m.logContent = ""
+	m.setViewportContent("")
+	if msg.service != "" {
+		m.statusMsg = fmt.Sprintf("Tailing %d replicas of %s (1-9 toggle replicas)", len(msg.containers), msg.service)
+	} else {
+		m.statusMsg = fmt.Sprintf("Tailing %d containers of %s (1-9 toggle services)", len(msg.containers), msg.project)
+	}
+
+	return waitForComposeLogs(stream)
+}
+
+// startComposeLogs follows the output of `docker compose logs` for the
+// selected project. Services are added to the legend as their first lines
+// arrive.
+func (m *FullModel) startComposeLogs() tea.Cmd {
+	m.stopComposeTail()
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	stream := &composeLogStream{
+		project: m.selectedName,
+		cancel:  cancel,
+		lines:   make(chan composeLogLine, 256),
+		hidden:  make(map[string]bool),
+	}
+
+	path := m.selectedPath
+	go func() {
+		defer close(stream.lines)
+		send := func(line composeLogLine) bool {
+			select {
+			case stream.lines <- line:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		output, err := m.docker.StreamComposeLogs(ctx, path, true)
+		if err != nil {
+			send(composeLogLine{text: fmt.Sprintf("[log stream ended: %v]", err)})
+			return
+		}
+
+		scanner := bufio.NewScanner(output)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			if !send(parseComposeLogLine(scanner.Text())) {
+				break
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			send(composeLogLine{text: fmt.Sprintf("[log stream ended: %v]", err)})
+		}
+		if err := output.Close(); err != nil {
+			send(composeLogLine{text: fmt.Sprintf("[log stream ended: %v]", err)})
+		}
+	}()
+
+	m.composeLogs = stream
+	m.currentMode = LogsMode
+	m.logContent = ""
+	m.setViewportContent("")
+	m.statusMsg = fmt.Sprintf("Following logs of %s (1-9 toggle services, %s show one service)", m.selectedName, DefaultFullKeyMap.SoloService.Help().Key)
+
+	return waitForComposeLogs(stream)
+}
+
+// replicaSuffix matches the replica number compose adds to a service's
+// container names, e.g. the "-2" of "web-2"
+var replicaSuffix = regexp.MustCompile(`-\d+$`)
+
+// parseComposeLogLine splits a line of `docker compose logs` output into the
+// service it came from and the message. The lines are prefixed with the
+// container's name, so the replicas of a service share its color and filter.
+func parseComposeLogLine(line string) composeLogLine {
+	container, text, ok := strings.Cut(line, " | ")
+	if !ok {
+		return composeLogLine{text: line}
+	}
+	return composeLogLine{service: replicaSuffix.ReplaceAllString(strings.TrimSpace(container), ""), text: text}
+}
+
+// waitForComposeLogs waits for the next lines from the stream, batching any
+// that are already queued so bursts of output render in a single update
+func waitForComposeLogs(stream *composeLogStream) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-stream.lines
+		if !ok {
+			return composeLogLinesMsg{stream: stream, done: true}
+		}
+
+		lines := []composeLogLine{line}
+		for len(lines) < composeLogBatch {
+			select {
+			case line, ok := <-stream.lines:
+				if !ok {
+					return composeLogLinesMsg{stream: stream, lines: lines, done: true}
+				}
+				lines = append(lines, line)
+			default:
+				return composeLogLinesMsg{stream: stream, lines: lines}
+			}
+		}
+		return composeLogLinesMsg{stream: stream, lines: lines}
+	}
+}
+
+// handleComposeLogLines appends newly received lines and keeps listening
+func (m *FullModel) handleComposeLogLines(msg composeLogLinesMsg) tea.Cmd {
+	// Ignore lines from a tail that has since been stopped
+	if msg.stream != m.composeLogs {
+		return nil
+	}
+
+	stream := msg.stream
+	for _, line := range msg.lines {
+		if line.service != "" && !slices.Contains(stream.services, line.service) {
+			stream.services = append(stream.services, line.service)
+		}
+	}
+	stream.buffer = append(stream.buffer, msg.lines...)
+	if len(stream.buffer) > maxComposeLogLines {
+		stream.buffer = stream.buffer[len(stream.buffer)-maxComposeLogLines:]
+	}
+	m.refreshComposeTail()
+
+	if msg.done {
+		m.statusMsg = fmt.Sprintf("All log streams for %s have ended", stream.project)
+		return nil
+	}
+	return waitForComposeLogs(stream)
+}
+
+// refreshComposeTail re-renders the tail, following new output if the user
+// is already looking at the end of it
+func (m *FullModel) refreshComposeTail() {
+	atBottom := m.viewport.AtBottom()
+	m.logContent = m.composeLogs.render()
+	m.setViewportContent(m.renderLogContent())
+	if atBottom {
+		m.viewport.GotoBottom()
+	}
+}
+
+// toggleComposeService shows or hides the service at the given index
+func (m *FullModel) toggleComposeService(index int) {
+	stream := m.composeLogs
+	if index < 0 || index >= len(stream.services) {
+		return
+	}
+
+	service := stream.services[index]
+	stream.hidden[service] = !stream.hidden[service]
+	m.refreshComposeTail()
+
+	if stream.hidden[service] {
+		m.statusMsg = fmt.Sprintf("Hiding logs from %s", service)
+	} else {
+		m.statusMsg = fmt.Sprintf("Showing logs from %s", service)
+	}
+}
+
+// soloComposeService shows only the next service in turn, then all of them again
+func (m *FullModel) soloComposeService() {
+	stream := m.composeLogs
+	if len(stream.services) == 0 {
+		return
+	}
+
+	// Find the service shown alone, if any
+	shown := -1
+	for i, service := range stream.services {
+		if !stream.hidden[service] {
+			if shown >= 0 {
+				shown = -1
+				break
+			}
+			shown = i
+		}
+	}
+
+	next := shown + 1
+	for i, service := range stream.services {
+		stream.hidden[service] = next < len(stream.services) && i != next
+	}
+	m.refreshComposeTail()
+
+	if next < len(stream.services) {
+		m.statusMsg = fmt.Sprintf("Showing only logs from %s (%s for the next service)", stream.services[next], DefaultFullKeyMap.SoloService.Help().Key)
+	} else {
+		m.statusMsg = "Showing logs from all services"
+	}
+}
+
+// stopComposeTail stops following the compose project's logs
+func (m *FullModel) stopComposeTail() {
+	if m.composeLogs == nil {
+		return
+	}
+	m.composeLogs.cancel()
+	m.composeLogs = nil
+}
+
+// title describes what the stream is tailing
+func (s *composeLogStream) title() string {
+	if s.service != "" {
+		return fmt.Sprintf("Live logs for all replicas of %s in %s", s.service, s.project)
+	}
+	return fmt.Sprintf("Live logs for all services of %s", s.project)
+}
+
+// serviceStyle returns the color style for a service's prefix
+func (s *composeLogStream) serviceStyle(service string) lipgloss.Style {
+	for i, name := range s.services {
+		if name == service {
+			return lipgloss.NewStyle().Foreground(composeLogColors[i%len(composeLogColors)])
+		}
+	}
+	return lipgloss.NewStyle()
+}
+
+// render renders the buffered lines of the visible services, prefixed with
+// their service name in the style of `docker compose logs`
+func (s *composeLogStream) render() string {
+	width := 0
+	for _, service := range s.services {
+		width = max(width, len(service))
+	}
+
+	var sb strings.Builder
+	for _, line := range s.buffer {
+		if s.hidden[line.service] {
+			continue
+		}
+		prefix := fmt.Sprintf("%-*s |", width, line.service)
+		sb.WriteString(s.serviceStyle(line.service).Render(prefix))
+		sb.WriteString(" ")
+		sb.WriteString(line.text)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// legend renders the numbered list of services and whether each is shown
+func (s *composeLogStream) legend(styles views.Styles) string {
+	offStyle := lipgloss.NewStyle().Foreground(styles.Border).Strikethrough(true)
+
+	items := make([]string, len(s.services))
+	for i, service := range s.services {
+		label := fmt.Sprintf("[%d] %s", i+1, service)
+		if s.hidden[service] {
+			items[i] = offStyle.Render(label)
+		} else {
+			items[i] = s.serviceStyle(service).Render(label)
+		}
+	}
+	return strings.Join(items, "  ")
+}
+
+// moveComposeServiceCursor moves the service selection in the compose inspect view
+func (m *FullModel) moveComposeServiceCursor(delta int) {
+	if len(m.composeServiceList) == 0 {
+		return
+	}
+
+	m.composeServiceCursor = max(0, min(len(m.composeServiceList)-1, m.composeServiceCursor+delta))
+	m.setViewportContent(m.renderComposeInspect())
+	m.statusMsg = fmt.Sprintf("Selected service %s (%s for its logs)", m.composeServiceList[m.composeServiceCursor].Name, DefaultFullKeyMap.ComposeServiceLogs.Help().Key)
+}
+
+// fetchComposeServiceLogs loads the logs of a single service of the selected project
+func (m FullModel) fetchComposeServiceLogs(service string) tea.Cmd {
+	return func() tea.Msg {
+		path := m.selectedProjectPath
+		if path == "" {
+			path = m.selectedPath
+		}
+
+		logs, err := m.docker.ComposeServiceLogs(m.ctx, path, service)
+		if err != nil {
+			return fullActionResultMsg{success: false, message: err.Error()}
+		}
+		return fullLogsMsg{content: logs}
+	}
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// promptComposeScale asks how many replicas the selected compose service
+// should run, then scales it
+func (m *FullModel) promptComposeScale() tea.Cmd {
+	if m.composeServiceCursor >= len(m.composeServiceList) {
+		m.statusMsg = "No compose service selected"
+		return nil
+	}
+
+	path := m.selectedProjectPath
+	if path == "" {
+		path = m.selectedPath
+	}
+	project := m.selectedName
+	service := m.composeServiceList[m.composeServiceCursor].Name
+
+	// Start from the number of replicas running now
+	replicas := 0
+	for _, c := range m.containers {
+		if c.Labels[composeLabelProject] == project && c.Labels[composeLabelService] == service {
+			replicas++
+		}
+	}
+
+	return m.openPrompt(fmt.Sprintf("Replicas of %s:", service), strconv.Itoa(max(1, replicas)), func(m *FullModel, value string) tea.Cmd {
+		count, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || count < 1 {
+			m.statusMsg = fmt.Sprintf("Invalid replica count %q: must be a whole number of at least 1", value)
+			return nil
+		}
+
+		m.statusMsg = fmt.Sprintf("Scaling %s to %d replicas...", service, count)
+		return tea.Sequence(func() tea.Msg {
+			// Scaling down stops the surplus replicas
+			m.stops.record(composeStopKey(project, service))
+			if err := m.docker.ComposeScale(m.ctx, path, service, count); err != nil {
+				return fullActionResultMsg{success: false, message: fmt.Sprintf("Error: %v", err)}
+			}
+			return fullActionResultMsg{
+				success: true,
+				message: fmt.Sprintf("Scaled %s to %d replicas", service, count),
+				action:  "scale",
+			}
+		}, refreshInspect)
+	})
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klejdi94/docker-tea/internal/config"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// composeSearchPathSavedMsg reports whether a compose search path added from
+// the Compose tab could be written to the config file
+type composeSearchPathSavedMsg struct {
+	path string
+	err  error
+}
+
+// promptComposeSearchPath asks for another directory to look for compose
+// projects in. It's used right away and saved to the config file, so it's
+// searched in later sessions too.
+func (m *FullModel) promptComposeSearchPath() tea.Cmd {
+	cmd := m.openPrompt("Look for compose projects in:", "", func(m *FullModel, value string) tea.Cmd {
+		path := strings.TrimSpace(value)
+		if slices.Contains(m.config.ComposeSearchPaths, path) {
+			m.statusMsg = fmt.Sprintf("%s is already searched for compose projects", path)
+			return nil
+		}
+
+		m.config.ComposeSearchPaths = append(m.config.ComposeSearchPaths, path)
+		m.docker.SetComposeSearch(m.config.ComposeSearchPaths, m.config.ComposeSearchDepth)
+		m.statusMsg = fmt.Sprintf("Looking for compose projects in %s...", path)
+		return func() tea.Msg {
+			return composeSearchPathSavedMsg{path: path, err: config.AddComposeSearchPath(path)}
+		}
+	})
+	m.prompt.validate = func(value string) string {
+		path := strings.TrimSpace(value)
+		if path == "" {
+			return "enter a directory, e.g. ~/projects"
+		}
+		if info, err := os.Stat(docker.ExpandHome(path)); err != nil || !info.IsDir() {
+			return fmt.Sprintf("%s isn't a directory", path)
+		}
+		return ""
+	}
+	return cmd
+}
+
+// handleComposeSearchPathSaved reloads the compose projects, now including
+// those found under the added path
+func (m *FullModel) handleComposeSearchPathSaved(msg composeSearchPathSavedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Searching %s for this session only, it couldn't be saved: %v", msg.path, msg.err)
+	} else {
+		m.statusMsg = fmt.Sprintf("Added %s to composeSearchPaths in the config", msg.path)
+	}
+	return m.fetchComposeProjects
+}
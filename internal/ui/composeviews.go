@@ -0,0 +1,277 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// composeViews are the sub-tabs of the compose inspect view, in the order
+// the ComposeView key cycles through them
+var composeViews = []struct {
+	view  inspectView
+	title string
+}{
+	{inspectViewDefault, "services"},
+	{inspectViewConfig, "config"},
+	{inspectViewPs, "ps"},
+}
+
+// composeConfigMsg carries the resolved configuration of a compose project,
+// or why it doesn't validate
+type composeConfigMsg struct {
+	content string
+	err     error
+}
+
+// composePsMsg carries the containers of a compose project
+type composePsMsg struct {
+	entries []docker.ComposePsEntry
+	err     error
+}
+
+// composeConfigKey matches a mapping key in the output of compose config,
+// possibly as the first key of a list item
+var composeConfigKey = regexp.MustCompile(`^(\s*)(- )?([^\s:#'"-][^:]*|"[^"]*"|'[^']*'):(\s.*)?$`)
+
+// cycleComposeView switches the compose inspect view to its next sub-tab,
+// loading what it shows
+func (m *FullModel) cycleComposeView() tea.Cmd {
+	next := composeViews[0].view
+	for i, v := range composeViews {
+		if v.view == m.inspectView {
+			next = composeViews[(i+1)%len(composeViews)].view
+		}
+	}
+
+	if next == inspectViewDefault {
+		m.inspectView = inspectViewDefault
+		m.setViewportContent(m.renderComposeInspect())
+		m.viewport.GotoTop()
+		m.statusMsg = fmt.Sprintf("Services of %s", m.selectedName)
+		return nil
+	}
+	return m.fetchComposeView(next)
+}
+
+// fetchComposeView loads the config or ps output of the inspected compose project
+func (m *FullModel) fetchComposeView(view inspectView) tea.Cmd {
+	path := m.selectedProjectPath
+	if path == "" {
+		path = m.selectedPath
+	}
+	ctx, service := m.ctx, m.docker
+
+	switch view {
+	case inspectViewConfig:
+		m.statusMsg = fmt.Sprintf("Validating the compose files of %s...", m.selectedName)
+		return func() tea.Msg {
+			content, err := service.ComposeConfig(ctx, path)
+			return composeConfigMsg{content: content, err: err}
+		}
+	case inspectViewPs:
+		m.statusMsg = fmt.Sprintf("Listing the containers of %s...", m.selectedName)
+		return func() tea.Msg {
+			entries, err := service.ComposePs(ctx, path)
+			return composePsMsg{entries: entries, err: err}
+		}
+	}
+	return nil
+}
+
+// handleComposeConfig shows the resolved configuration, or the validation errors
+func (m *FullModel) handleComposeConfig(msg composeConfigMsg) {
+	if m.currentMode != InspectMode || m.currentTab != ComposeTab {
+		return
+	}
+
+	refresh := m.inspectView == inspectViewConfig
+	m.inspectView = inspectViewConfig
+	m.composeConfig = msg
+	m.setViewportContent(m.renderComposeInspect())
+	if !refresh {
+		m.viewport.GotoTop()
+	}
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("The compose files of %s don't validate", m.selectedName)
+	} else {
+		m.statusMsg = fmt.Sprintf("Resolved configuration of %s", m.selectedName)
+	}
+}
+
+// handleComposePs shows the containers of the project
+func (m *FullModel) handleComposePs(msg composePsMsg) {
+	if m.currentMode != InspectMode || m.currentTab != ComposeTab {
+		return
+	}
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", msg.err)
+		return
+	}
+
+	refresh := m.inspectView == inspectViewPs
+	m.inspectView = inspectViewPs
+	m.composePs = msg.entries
+	m.setViewportContent(m.renderComposeInspect())
+	if !refresh {
+		m.viewport.GotoTop()
+	}
+	m.statusMsg = fmt.Sprintf("%d containers in %s", len(msg.entries), m.selectedName)
+}
+
+// renderComposeViewTabs renders the sub-tabs of the compose inspect view,
+// highlighting the one shown
+func (m FullModel) renderComposeViewTabs() string {
+	activeStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Emphasis).Background(m.styles.Highlight).Padding(0, 1)
+	inactiveStyle := lipgloss.NewStyle().Foreground(m.styles.Muted).Padding(0, 1)
+	hintStyle := lipgloss.NewStyle().Foreground(m.styles.Muted).Italic(true)
+
+	var tabs []string
+	for _, v := range composeViews {
+		if v.view == m.inspectView {
+			tabs = append(tabs, activeStyle.Render(v.title))
+		} else {
+			tabs = append(tabs, inactiveStyle.Render(v.title))
+		}
+	}
+	hint := hintStyle.Render(fmt.Sprintf("  %s to switch", DefaultFullKeyMap.ComposeView.Help().Key))
+	return lipgloss.JoinHorizontal(lipgloss.Top, append(tabs, hint)...)
+}
+
+// renderComposeConfig renders the output of compose config with its keys,
+// list items and comments colored, and any validation errors on top
+func (m FullModel) renderComposeConfig() string {
+	var sb strings.Builder
+
+	if m.composeConfig.err != nil {
+		errorStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(m.styles.Emphasis).
+			Background(m.styles.Error).
+			Padding(0, 1)
+		sb.WriteString(errorStyle.Render(IconError + "The compose files don't validate"))
+		sb.WriteString("\n\n")
+		sb.WriteString(lipgloss.NewStyle().Foreground(m.styles.Error).Render(m.composeConfig.err.Error()))
+		sb.WriteString("\n\n")
+		sb.WriteString(lipgloss.NewStyle().Foreground(m.styles.Muted).Italic(true).Render(
+			fmt.Sprintf("Fix them and press %s to check again", DefaultFullKeyMap.Refresh.Help().Key)))
+		return sb.String()
+	}
+
+	topKeyStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Title)
+	keyStyle := lipgloss.NewStyle().Foreground(m.styles.Accent)
+	valueStyle := lipgloss.NewStyle().Foreground(m.styles.Special)
+	dashStyle := lipgloss.NewStyle().Foreground(m.styles.Warning)
+	commentStyle := lipgloss.NewStyle().Foreground(m.styles.Muted).Italic(true)
+
+	for _, line := range strings.Split(strings.TrimRight(m.composeConfig.content, "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "#"):
+			sb.WriteString(commentStyle.Render(line))
+		case composeConfigKey.MatchString(line):
+			parts := composeConfigKey.FindStringSubmatch(line)
+			indent, dash, name, value := parts[1], parts[2], parts[3], parts[4]
+			style := keyStyle
+			if indent == "" && dash == "" {
+				style = topKeyStyle
+			}
+			sb.WriteString(indent)
+			if dash != "" {
+				sb.WriteString(dashStyle.Render(dash))
+			}
+			sb.WriteString(style.Render(name + ":"))
+			sb.WriteString(valueStyle.Render(value))
+		case strings.HasPrefix(trimmed, "- "):
+			indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+			sb.WriteString(indent + dashStyle.Render("- ") + valueStyle.Render(strings.TrimPrefix(trimmed, "- ")))
+		default:
+			sb.WriteString(valueStyle.Render(line))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// renderComposePs renders the containers of the project in a table, with
+// their state colored
+func (m FullModel) renderComposePs() string {
+	if len(m.composePs) == 0 {
+		return "No containers; the project hasn't been started"
+	}
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Title).Padding(0, 1)
+	cellStyle := lipgloss.NewStyle().Padding(0, 1)
+
+	rows := make([][]string, 0, len(m.composePs))
+	for _, entry := range m.composePs {
+		state := entry.State
+		if entry.Health != "" {
+			state += " (" + entry.Health + ")"
+		}
+		if entry.State == "exited" {
+			state += fmt.Sprintf(" [%d]", entry.ExitCode)
+		}
+		rows = append(rows, []string{
+			entry.Service,
+			entry.Name,
+			truncateCell(entry.Image, 30),
+			state,
+			entry.Status,
+			composePsPorts(entry.Publishers),
+		})
+	}
+
+	return table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(m.styles.Border)).
+		Headers("SERVICE", "NAME", "IMAGE", "STATE", "STATUS", "PORTS").
+		Rows(rows...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return headerStyle
+			}
+			if col == 3 {
+				return cellStyle.Foreground(m.composePsStateColor(m.composePs[row]))
+			}
+			return cellStyle
+		}).
+		Render()
+}
+
+// composePsStateColor colors a compose container by whether it's healthy and running
+func (m FullModel) composePsStateColor(entry docker.ComposePsEntry) lipgloss.Color {
+	switch {
+	case entry.Health == "unhealthy" || (entry.State == "exited" && entry.ExitCode != 0) || entry.State == "dead":
+		return m.styles.Error
+	case entry.State == "running":
+		return m.styles.Success
+	default:
+		return m.styles.Warning
+	}
+}
+
+// composePsPorts lists the ports a compose container publishes, like docker
+// compose ps does
+func composePsPorts(publishers []docker.ComposePublisher) string {
+	var ports []string
+	for _, p := range publishers {
+		target := strconv.Itoa(p.TargetPort) + "/" + p.Protocol
+		if p.PublishedPort == 0 {
+			ports = append(ports, target)
+			continue
+		}
+		host := p.URL
+		if host == "" {
+			host = "0.0.0.0"
+		}
+		ports = append(ports, fmt.Sprintf("%s:%d->%s", host, p.PublishedPort, target))
+	}
+	return strings.Join(ports, ", ")
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klejdi94/docker-tea/internal/config"
+	"github.com/klejdi94/docker-tea/internal/ui/views"
+)
+
+// configReloadedMsg carries the result of re-reading the config file
+type configReloadedMsg struct {
+	config *config.Config
+	err    error
+}
+
+// reloadConfig re-reads and validates the config file
+func reloadConfig() tea.Msg {
+	cfg, err := config.LoadConfig()
+	return configReloadedMsg{config: cfg, err: err}
+}
+
+// handleConfigReloaded applies a reloaded config, keeping the current one if
+// the file turned out to be invalid
+func (m *FullModel) handleConfigReloaded(msg configReloadedMsg) {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Config not reloaded, keeping the current one: %v", msg.err)
+		return
+	}
+
+	if err := ApplyKeyBindings(msg.config.Keybindings); err != nil {
+		m.statusMsg = fmt.Sprintf("Config not reloaded, keeping the current one: keybindings: %v", err)
+		return
+	}
+
+	// The client is only created at startup, so a new API version or host can't be applied live
+	apiVersionChanged := msg.config.DockerAPIVersion != m.config.DockerAPIVersion
+	hostChanged := msg.config.DockerHost != m.config.DockerHost
+
+	m.config = msg.config
+	m.logTail = m.config.LogTailLines
+	m.docker.SetComposeSearch(m.config.ComposeSearchPaths, m.config.ComposeSearchDepth)
+	views.SetSizeUnits(m.config.SizeUnits)
+	m.applyTheme(m.config.Theme)
+
+	for tab := ContainersTab; tab <= ComposeTab; tab++ {
+		m.refreshRows(tab)
+	}
+	m.updateTables()
+
+	m.statusMsg = "Config reloaded"
+	switch {
+	case apiVersionChanged:
+		m.statusMsg = "Config reloaded (restart to apply the new dockerAPIVersion)"
+	case hostChanged:
+		m.statusMsg = "Config reloaded (restart to apply the new dockerHost, or switch with X)"
+	}
+}
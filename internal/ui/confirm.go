@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/klejdi94/docker-tea/internal/config"
+)
+
+// Settings for confirming bulk destructive actions
+const (
+	bulkConfirmThreshold = 10                      // affected resources from which the count must be typed by default
+	holdConfirmDuration  = 1500 * time.Millisecond // how long y must be held down
+	holdConfirmGap       = 700 * time.Millisecond  // longest pause between key repeats while holding
+	maxConfirmItemsShown = 15                      // affected resources listed before summarizing the rest
+)
+
+// confirmation is an overlay asking the user to confirm a destructive action
+// on several resources, listing exactly which ones will be affected
+type confirmation struct {
+	active    bool
+	title     string
+	items     []string
+	strength  string
+	input     textinput.Model // the typed count, for count confirmations
+	holdStart time.Time       // when y started being held, for hold confirmations
+	holdLast  time.Time       // when the last repeat of y arrived
+	onConfirm func(m *FullModel) tea.Cmd
+}
+
+// confirmBulk asks for confirmation before running a destructive action on
+// the given resources. The action name selects the configured strength.
+func (m *FullModel) confirmBulk(action, title string, items []string, onConfirm func(m *FullModel) tea.Cmd) tea.Cmd {
+	if len(items) == 0 {
+		m.statusMsg = fmt.Sprintf("Nothing to %s", strings.ToLower(title))
+		return nil
+	}
+
+	strength := m.config.Confirmation(action)
+	if strength == "" {
+		strength = config.ConfirmYes
+		if len(items) >= bulkConfirmThreshold {
+			strength = config.ConfirmCount
+		}
+	}
+
+	m.confirm = confirmation{
+		active:    true,
+		title:     title,
+		items:     items,
+		strength:  strength,
+		onConfirm: onConfirm,
+	}
+
+	if strength == config.ConfirmCount {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Width = 10
+		m.confirm.input = input
+		return m.confirm.input.Focus()
+	}
+	return nil
+}
+
+// handleConfirmKey processes key presses while the confirmation is open
+func (m *FullModel) handleConfirmKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.confirm = confirmation{}
+		m.statusMsg = "Cancelled"
+		return nil
+	}
+
+	switch m.confirm.strength {
+	case config.ConfirmCount:
+		if msg.String() != "enter" {
+			var cmd tea.Cmd
+			m.confirm.input, cmd = m.confirm.input.Update(msg)
+			return cmd
+		}
+		if strings.TrimSpace(m.confirm.input.Value()) != strconv.Itoa(len(m.confirm.items)) {
+			m.statusMsg = fmt.Sprintf("Type %d to confirm, or esc to cancel", len(m.confirm.items))
+			m.confirm.input.SetValue("")
+			return nil
+		}
+		return m.acceptConfirmation()
+
+	case config.ConfirmHold:
+		if msg.String() != "y" {
+			if msg.String() == "n" {
+				m.confirm = confirmation{}
+				m.statusMsg = "Cancelled"
+			}
+			return nil
+		}
+
+		// Holding a key down makes the terminal repeat it, so a hold is a run
+		// of presses without a long pause in between
+		now := time.Now()
+		if m.confirm.holdStart.IsZero() || now.Sub(m.confirm.holdLast) > holdConfirmGap {
+			m.confirm.holdStart = now
+		}
+		m.confirm.holdLast = now
+		if now.Sub(m.confirm.holdStart) >= holdConfirmDuration {
+			return m.acceptConfirmation()
+		}
+		return nil
+
+	default:
+		switch msg.String() {
+		case "y":
+			return m.acceptConfirmation()
+		case "n":
+			m.confirm = confirmation{}
+			m.statusMsg = "Cancelled"
+		}
+		return nil
+	}
+}
+
+// acceptConfirmation closes the confirmation and runs the confirmed action
+func (m *FullModel) acceptConfirmation() tea.Cmd {
+	confirmed := m.confirm
+	m.confirm = confirmation{}
+	if confirmed.onConfirm != nil {
+		return confirmed.onConfirm(m)
+	}
+	return nil
+}
+
+// renderConfirm renders the confirmation overlay
+func (m FullModel) renderConfirm() string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Error)
+	itemStyle := lipgloss.NewStyle().Foreground(m.styles.Text)
+	hintStyle := lipgloss.NewStyle().Foreground(m.styles.Muted).Italic(true)
+
+	items := m.confirm.items
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("%s: %d affected", m.confirm.title, len(items))))
+	sb.WriteString("\n\n")
+
+	for i, item := range items {
+		if i == maxConfirmItemsShown {
+			sb.WriteString(hintStyle.Render(fmt.Sprintf("  ...and %d more", len(items)-maxConfirmItemsShown)))
+			sb.WriteString("\n")
+			break
+		}
+		sb.WriteString(itemStyle.Render("  " + item))
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+
+	switch m.confirm.strength {
+	case config.ConfirmCount:
+		sb.WriteString(fmt.Sprintf("Type %d to confirm: ", len(items)))
+		sb.WriteString(m.confirm.input.View())
+		sb.WriteString("\n")
+		sb.WriteString(hintStyle.Render("enter confirm • esc cancel"))
+	case config.ConfirmHold:
+		held := time.Duration(0)
+		if !m.confirm.holdStart.IsZero() {
+			held = min(m.confirm.holdLast.Sub(m.confirm.holdStart), holdConfirmDuration)
+		}
+		width := 20
+		filled := int(float64(width) * float64(held) / float64(holdConfirmDuration))
+		sb.WriteString(fmt.Sprintf("Hold y to confirm [%s%s]", strings.Repeat("█", filled), strings.Repeat("░", width-filled)))
+		sb.WriteString("\n")
+		sb.WriteString(hintStyle.Render("n/esc cancel"))
+	default:
+		sb.WriteString(hintStyle.Render("y confirm • n/esc cancel"))
+	}
+	return sb.String()
+}
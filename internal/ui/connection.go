@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// handleDockerConnection records the result of a connection check. The
+// resources are loaded whenever Docker comes up, at startup or after it was
+// down; while it's down the check is repeated until it answers again. Coming
+// back from an outage, or with the event stream broken, the Docker client is
+// recreated and the events subscribed to again, as the old connection died
+// with the daemon.
+func (m *FullModel) handleDockerConnection(msg dockerConnectionMsg) tea.Cmd {
+	wasDown := m.dockerErr != nil
+	wasConnected := m.dockerConnected
+
+	m.dockerConnected = msg.connected
+	if !msg.connected {
+		m.dockerErr = msg.err
+		m.loading = false
+		m.statusMsg = "Docker is not running"
+		if m.eventStream == eventStreamConnected {
+			m.eventStream = eventStreamReconnecting
+		}
+		return m.startConnectionCheck()
+	}
+
+	m.dockerErr = nil
+	if wasDown || m.eventStream == eventStreamReconnecting {
+		m.statusMsg = "Reconnecting to Docker..."
+		return m.reconnect
+	}
+	if wasConnected {
+		return nil
+	}
+	m.loading = true
+	return m.fetchAll()
+}
+
+// dockerDown reports whether the last connection check failed
+func (m FullModel) dockerDown() bool {
+	return !m.dockerConnected && m.dockerErr != nil
+}
+
+// renderDockerDown renders the screen shown instead of the resource lists
+// while Docker isn't reachable
+func (m FullModel) renderDockerDown() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Error)
+	textStyle := lipgloss.NewStyle().Foreground(m.styles.Text)
+	hintStyle := lipgloss.NewStyle().Foreground(m.styles.Muted).Italic(true)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(IconError + "Docker is not running"))
+	sb.WriteString("\n\n")
+	sb.WriteString(textStyle.Render(fmt.Sprintf("Couldn't reach the Docker daemon at %s", m.docker.Host())))
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render(m.dockerErr.Error()))
+	sb.WriteString("\n\n")
+	sb.WriteString(textStyle.Render("Start Docker and everything will load on its own; the connection is checked every 10 seconds."))
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render(fmt.Sprintf("%s reconnect now • %s switch context • %s quit",
+		DefaultFullKeyMap.HardRefresh.Help().Key, DefaultFullKeyMap.SwitchContext.Help().Key, DefaultFullKeyMap.Quit.Help().Key)))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.styles.Error).
+		Padding(1, 2).
+		Render(sb.String())
+}
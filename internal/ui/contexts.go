@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// dockerContextsMsg carries the Docker CLI contexts available to the user
+type dockerContextsMsg struct {
+	contexts []docker.DockerContext
+	err      error
+	open     bool // open the context picker once loaded
+}
+
+// contextSwitchedMsg reports the result of switching to another Docker context
+type contextSwitchedMsg struct {
+	name string
+	err  error
+}
+
+// fetchDockerContexts lists Docker contexts, optionally opening the picker afterwards
+func (m FullModel) fetchDockerContexts(open bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(m.ctx, 5*time.Second)
+		defer cancel()
+
+		contexts, err := docker.ListContexts(ctx)
+		return dockerContextsMsg{contexts: contexts, err: err, open: open}
+	}
+}
+
+// switchDockerContext reconnects the Docker service to the given context's endpoint
+func (m FullModel) switchDockerContext(dockerCtx docker.DockerContext) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(m.ctx, 10*time.Second)
+		defer cancel()
+
+		if dockerCtx.Host == "" {
+			return contextSwitchedMsg{name: dockerCtx.Name, err: fmt.Errorf("context %s has no Docker endpoint", dockerCtx.Name)}
+		}
+
+		err := m.docker.SwitchHost(ctx, dockerCtx.Host)
+		return contextSwitchedMsg{name: dockerCtx.Name, err: err}
+	}
+}
+
+// handleDockerContexts records the active context and opens the picker if requested
+func (m *FullModel) handleDockerContexts(msg dockerContextsMsg) tea.Cmd {
+	if msg.err != nil {
+		if msg.open {
+			m.statusMsg = fmt.Sprintf("Error: %v", msg.err)
+		}
+		return nil
+	}
+
+	m.dockerContexts = msg.contexts
+
+	// Work out which context matches the endpoint we're connected to
+	if m.dockerContext == "" {
+		host := m.docker.Host()
+		for _, c := range msg.contexts {
+			if c.Host == host {
+				m.dockerContext = c.Name
+				break
+			}
+		}
+	}
+
+	if !msg.open {
+		return nil
+	}
+
+	items := make([]string, len(msg.contexts))
+	cursor := 0
+	for i, c := range msg.contexts {
+		items[i] = fmt.Sprintf("%-20s %s", c.Name, c.Host)
+		if c.Name == m.dockerContext {
+			items[i] += "  (active)"
+			cursor = i
+		}
+	}
+
+	m.openPicker("Switch Docker context", items, cursor, func(m *FullModel, index int) tea.Cmd {
+		selected := m.dockerContexts[index]
+		m.statusMsg = fmt.Sprintf("Switching to context %s...", selected.Name)
+		return m.switchDockerContext(selected)
+	})
+	return nil
+}
+
+// handleContextSwitched refreshes all data once the service points at a new context
+func (m *FullModel) handleContextSwitched(msg contextSwitchedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Error switching context: %v", msg.err)
+		return nil
+	}
+
+	// The events came through the previous endpoint's client, which is closed
+	m.restartEvents()
+
+	// Streams started on the previous endpoint would keep feeding its data
+	// into the new context's views, so stop them as leaving the views does
+	m.cancelLogDownload()
+	m.stopMonitoring()
+	m.stopLogFollow()
+	m.stopComposeTail()
+	m.logGrep = ""
+	m.search = viewportSearch{}
+
+	m.dockerContext = msg.name
+	m.dockerConnected = true
+	m.dockerErr = nil
+	m.currentMode = ListMode
+	m.statusMsg = fmt.Sprintf("Switched to context %s", msg.name)
+
+	return m.fetchAll()
+}
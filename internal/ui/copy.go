@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/klejdi94/docker-tea/internal/clipboard"
+)
+
+// clipboardMsg reports the result of copying content to the clipboard
+type clipboardMsg struct {
+	bytes int
+	path  string // set when the content was saved to a file instead
+	err   error
+}
+
+// copyViewContent copies the content of the inspect or logs view to the clipboard
+func (m FullModel) copyViewContent() tea.Cmd {
+	content := m.inspectContent
+	if m.currentMode == LogsMode {
+		content = m.logContent
+	}
+	// Views rendered by the app itself, like the compose project view, have no raw content
+	if content == "" {
+		content = ansi.Strip(m.viewportContent)
+	}
+
+	return func() tea.Msg {
+		path, err := clipboard.Copy(content)
+		return clipboardMsg{bytes: len(content), path: path, err: err}
+	}
+}
+
+// handleClipboard reports where the copied content went
+func (m *FullModel) handleClipboard(msg clipboardMsg) {
+	switch {
+	case msg.err != nil:
+		m.statusMsg = fmt.Sprintf("Copy failed: %v", msg.err)
+	case msg.path != "":
+		m.statusMsg = fmt.Sprintf("No clipboard available, saved %d bytes to %s", msg.bytes, msg.path)
+	default:
+		m.statusMsg = fmt.Sprintf("Copied %d bytes to clipboard", msg.bytes)
+	}
+}
@@ -0,0 +1,251 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// Fields of the container creation form, in the order they're shown
+const (
+	formImage = iota
+	formName
+	formPorts
+	formEnv
+	formVolumes
+	formRestart
+	formFieldCount
+)
+
+// formLabels are the labels of the form fields
+var formLabels = [formFieldCount]string{
+	formImage:   "Image",
+	formName:    "Name",
+	formPorts:   "Ports",
+	formEnv:     "Environment",
+	formVolumes: "Volumes",
+	formRestart: "Restart policy",
+}
+
+// formHints describe the expected format of each field
+var formHints = [formFieldCount]string{
+	formImage:   "e.g. nginx:latest",
+	formName:    "optional",
+	formPorts:   "host:container/proto, comma separated, e.g. 8080:80/tcp",
+	formEnv:     "KEY=value, comma separated",
+	formVolumes: "host:container[:ro], comma separated",
+	formRestart: "no, always, unless-stopped or on-failure[:retries]",
+}
+
+// portSpecPattern matches a published port: [[ip:]host:]container[/proto]
+var portSpecPattern = regexp.MustCompile(`^(?:(?:([0-9.]+):)?(\d+):)?(\d+)(?:/(tcp|udp|sctp))?$`)
+
+// restartPolicyPattern matches the restart policies Docker accepts
+var restartPolicyPattern = regexp.MustCompile(`^(no|always|unless-stopped|on-failure(:\d+)?)$`)
+
+// createForm is an overlay for entering the settings of a new container
+type createForm struct {
+	active bool
+	title  string
+	inputs [formFieldCount]textinput.Model
+	errors [formFieldCount]string
+	focus  int
+	base   docker.ContainerCreateConfig // settings the form doesn't show, kept when cloning
+}
+
+// openCreateForm opens the creation form, filled in from the given configuration
+func (m *FullModel) openCreateForm(title string, config docker.ContainerCreateConfig) tea.Cmd {
+	form := createForm{active: true, title: title, base: config}
+
+	values := [formFieldCount]string{
+		formImage:   config.Image,
+		formName:    config.Name,
+		formPorts:   strings.Join(config.Ports, ", "),
+		formEnv:     strings.Join(config.Env, ", "),
+		formVolumes: strings.Join(config.Volumes, ", "),
+		formRestart: config.Restart,
+	}
+	for i := range form.inputs {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Placeholder = formHints[i]
+		input.Width = 60
+		input.SetValue(values[i])
+		input.CursorEnd()
+		form.inputs[i] = input
+	}
+
+	m.createForm = form
+	return m.focusFormField(0)
+}
+
+// focusFormField moves the cursor to the given field
+func (m *FullModel) focusFormField(index int) tea.Cmd {
+	m.createForm.inputs[m.createForm.focus].Blur()
+	m.createForm.focus = (index + formFieldCount) % formFieldCount
+	return m.createForm.inputs[m.createForm.focus].Focus()
+}
+
+// handleCreateFormKey processes key presses while the creation form is open
+func (m *FullModel) handleCreateFormKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.createForm = createForm{}
+		m.statusMsg = "Cancelled"
+		return nil
+	case "tab", "down":
+		return m.focusFormField(m.createForm.focus + 1)
+	case "shift+tab", "up":
+		return m.focusFormField(m.createForm.focus - 1)
+	case "ctrl+s":
+		return m.submitCreateForm()
+	case "enter":
+		if m.createForm.focus == formFieldCount-1 {
+			return m.submitCreateForm()
+		}
+		return m.focusFormField(m.createForm.focus + 1)
+	}
+
+	var cmd tea.Cmd
+	focus := m.createForm.focus
+	m.createForm.inputs[focus], cmd = m.createForm.inputs[focus].Update(msg)
+	m.createForm.errors[focus] = ""
+	return cmd
+}
+
+// submitCreateForm validates the form and, if everything is valid, creates
+// and starts the container. Otherwise the errors are shown next to the fields.
+func (m *FullModel) submitCreateForm() tea.Cmd {
+	form := &m.createForm
+	value := func(field int) string { return strings.TrimSpace(form.inputs[field].Value()) }
+
+	config := form.base
+	config.Image = value(formImage)
+	config.Name = value(formName)
+	config.Ports = splitList(value(formPorts))
+	config.Env = splitEnv(value(formEnv))
+	config.Volumes = splitList(value(formVolumes))
+	config.Restart = value(formRestart)
+
+	form.errors = [formFieldCount]string{}
+	if config.Image == "" {
+		form.errors[formImage] = "an image is required"
+	}
+	if config.Name != "" && !containerNamePattern.MatchString(config.Name) {
+		form.errors[formName] = "may only contain letters, digits, _ . and -, and must start with a letter or digit"
+	}
+	for _, port := range config.Ports {
+		if err := validatePortSpec(port); err != nil {
+			form.errors[formPorts] = err.Error()
+			break
+		}
+	}
+	for _, env := range config.Env {
+		if strings.Index(env, "=") <= 0 {
+			form.errors[formEnv] = fmt.Sprintf("%q is not KEY=value", env)
+			break
+		}
+	}
+	for _, volume := range config.Volumes {
+		parts := strings.Split(volume, ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || !strings.HasPrefix(parts[1], "/") {
+			form.errors[formVolumes] = fmt.Sprintf("%q is not host:/container[:mode]", volume)
+			break
+		}
+	}
+	if config.Restart != "" && !restartPolicyPattern.MatchString(config.Restart) {
+		form.errors[formRestart] = fmt.Sprintf("%q is not a restart policy", config.Restart)
+	}
+
+	for i, err := range form.errors {
+		if err != "" {
+			m.statusMsg = "Fix the highlighted fields"
+			return m.focusFormField(i)
+		}
+	}
+
+	m.createForm = createForm{}
+	name := config.Name
+	if name == "" {
+		name = "a container"
+	}
+	m.statusMsg = fmt.Sprintf("Creating %s from %s...", name, config.Image)
+	return m.createContainer(config)
+}
+
+// containerNamePattern matches the container names Docker accepts
+var containerNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+
+// validatePortSpec checks a published port in host:container/proto form
+func validatePortSpec(spec string) error {
+	parts := portSpecPattern.FindStringSubmatch(spec)
+	if parts == nil {
+		return fmt.Errorf("%q is not host:container/proto", spec)
+	}
+	for _, port := range parts[2:4] {
+		if port == "" {
+			continue // no host port, Docker picks one
+		}
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("%q: port %s is out of range", spec, port)
+		}
+	}
+	return nil
+}
+
+// splitEnv splits a comma separated list of KEY=value pairs. A piece without
+// an = belongs to the value before it, so values may contain commas.
+func splitEnv(value string) []string {
+	var env []string
+	for _, item := range strings.Split(value, ",") {
+		trimmed := strings.TrimSpace(item)
+		if trimmed == "" {
+			continue
+		}
+		if len(env) > 0 && !strings.Contains(trimmed, "=") {
+			env[len(env)-1] += "," + item
+			continue
+		}
+		env = append(env, trimmed)
+	}
+	return env
+}
+
+// renderCreateForm renders the creation form
+func (m FullModel) renderCreateForm() string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Title)
+	labelStyle := lipgloss.NewStyle().Foreground(m.styles.Text).Width(16)
+	focusStyle := labelStyle.Foreground(m.styles.Accent).Bold(true)
+	errorStyle := lipgloss.NewStyle().Foreground(m.styles.Error)
+	hintStyle := lipgloss.NewStyle().Foreground(m.styles.Muted).Italic(true)
+
+	sb.WriteString(titleStyle.Render(m.createForm.title))
+	sb.WriteString("\n\n")
+
+	for i, input := range m.createForm.inputs {
+		style := labelStyle
+		if i == m.createForm.focus {
+			style = focusStyle
+		}
+		sb.WriteString(style.Render(formLabels[i]))
+		sb.WriteString(input.View())
+		sb.WriteString("\n")
+		if err := m.createForm.errors[i]; err != "" {
+			sb.WriteString(labelStyle.Render(""))
+			sb.WriteString(errorStyle.Render("✗ " + err))
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render("tab/↑/↓ move • enter next field • ctrl+s create • esc cancel"))
+	return sb.String()
+}
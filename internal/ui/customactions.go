@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klejdi94/docker-tea/internal/config"
+)
+
+// customActionData is what a custom action's command template can refer to
+type customActionData struct {
+	ID   string // ID of the selected resource
+	Name string // name of the selected resource
+	Path string // project directory, for compose projects
+	Tab  string // tab the resource was selected on
+}
+
+// customActionsForTab returns the custom actions that apply to a tab
+func (m FullModel) customActionsForTab(tab Tab) []config.CustomAction {
+	var actions []config.CustomAction
+	for _, action := range m.config.CustomActions {
+		if len(action.Tabs) == 0 {
+			actions = append(actions, action)
+			continue
+		}
+		for _, t := range action.Tabs {
+			if t == tabStateKeys[tab] {
+				actions = append(actions, action)
+				break
+			}
+		}
+	}
+	return actions
+}
+
+// findCustomAction returns the custom action bound to a key on the current tab
+func (m FullModel) findCustomAction(msg tea.KeyMsg) (config.CustomAction, bool) {
+	for _, action := range m.customActionsForTab(m.currentTab) {
+		if msg.String() == action.Key {
+			return action, true
+		}
+	}
+	return config.CustomAction{}, false
+}
+
+// runCustomAction runs a custom action's command against the selected
+// resource, handing it the terminal until it exits
+func (m *FullModel) runCustomAction(action config.CustomAction) tea.Cmd {
+	if m.selectedID == "" {
+		m.statusMsg = fmt.Sprintf("Select a resource to %s", action.Label)
+		return nil
+	}
+
+	tmpl, err := action.Template()
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error in %s command: %v", action.Label, err)
+		return nil
+	}
+
+	var command strings.Builder
+	data := customActionData{
+		ID:   m.selectedID,
+		Name: m.selectedName,
+		Path: m.selectedPath,
+		Tab:  tabStateKeys[m.currentTab],
+	}
+	if err := tmpl.Execute(&command, data); err != nil {
+		m.statusMsg = fmt.Sprintf("Error in %s command: %v", action.Label, err)
+		return nil
+	}
+
+	label, target := action.Label, m.selectedName
+	m.statusMsg = fmt.Sprintf("Running %s on %s...", label, target)
+	return tea.ExecProcess(shellCommand(command.String()), func(err error) tea.Msg {
+		if err != nil {
+			return fullActionResultMsg{success: false, message: fmt.Sprintf("%s on %s failed: %v", label, target, err)}
+		}
+		return fullActionResultMsg{
+			success: true,
+			message: fmt.Sprintf("Successfully ran %s on %s", label, target),
+			action:  "custom",
+		}
+	})
+}
+
+// shellCommand runs a command line in the user's shell, waiting for a key
+// press afterwards so its output can be read before the UI comes back
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command+" & pause")
+	}
+	script := command + "\nstatus=$?\nprintf '\\nPress enter to return to docker-tea '\nread _\nexit $status"
+	return exec.Command("sh", "-c", script)
+}
@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/system"
+	"github.com/klejdi94/docker-tea/internal/clipboard"
+)
+
+// daemonInfoPanel shows which daemon docker-tea is connected to and what it
+// runs on, like `docker info` and `docker version`
+type daemonInfoPanel struct {
+	open    bool
+	loading bool
+	info    system.Info
+	version types.Version
+	err     error
+}
+
+// daemonInfoMsg carries the daemon's info and versions
+type daemonInfoMsg struct {
+	info    system.Info
+	version types.Version
+	err     error
+}
+
+// toggleDaemonInfo opens the system info panel, loading fresh details, or closes it
+func (m *FullModel) toggleDaemonInfo() tea.Cmd {
+	if m.daemonInfo.open {
+		m.daemonInfo = daemonInfoPanel{}
+		return nil
+	}
+	m.daemonInfo = daemonInfoPanel{open: true}
+	return m.fetchDaemonInfo()
+}
+
+// fetchDaemonInfo queries the daemon for its info and versions
+func (m *FullModel) fetchDaemonInfo() tea.Cmd {
+	m.daemonInfo.loading = true
+	return tea.Batch(m.spinner.Tick, func() tea.Msg {
+		info, err := m.docker.SystemInfo(m.ctx)
+		if err != nil {
+			return daemonInfoMsg{err: err}
+		}
+		version, err := m.docker.ServerVersion(m.ctx)
+		return daemonInfoMsg{info: info, version: version, err: err}
+	})
+}
+
+// handleDaemonInfo stores the daemon's details, unless the panel was closed meanwhile
+func (m *FullModel) handleDaemonInfo(msg daemonInfoMsg) {
+	if !m.daemonInfo.open {
+		return
+	}
+	m.daemonInfo.loading = false
+	m.daemonInfo.info = msg.info
+	m.daemonInfo.version = msg.version
+	m.daemonInfo.err = msg.err
+}
+
+// handleDaemonInfoKey processes key presses while the system info panel is open
+func (m *FullModel) handleDaemonInfoKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, DefaultFullKeyMap.SystemInfo), key.Matches(msg, DefaultFullKeyMap.Back):
+		return m.toggleDaemonInfo()
+	case key.Matches(msg, DefaultFullKeyMap.Refresh):
+		if !m.daemonInfo.loading {
+			return m.fetchDaemonInfo()
+		}
+	case key.Matches(msg, DefaultFullKeyMap.Copy):
+		if !m.daemonInfo.loading && m.daemonInfo.err == nil {
+			content := ansi.Strip(m.daemonInfoDetails())
+			return func() tea.Msg {
+				path, err := clipboard.Copy(content)
+				return clipboardMsg{bytes: len(content), path: path, err: err}
+			}
+		}
+	case key.Matches(msg, DefaultFullKeyMap.Quit):
+		return m.quit()
+	}
+	return nil
+}
+
+// daemonInfoDetails renders the daemon's details, one per line
+func (m FullModel) daemonInfoDetails() string {
+	labelStyle := lipgloss.NewStyle().Foreground(m.styles.Accent).Width(18)
+	warningStyle := lipgloss.NewStyle().Foreground(m.styles.Warning)
+
+	info, version := m.daemonInfo.info, m.daemonInfo.version
+	connection := m.docker.Host()
+	if m.dockerContext != "" {
+		connection = fmt.Sprintf("%s (context %s)", connection, m.dockerContext)
+	}
+
+	rows := [][2]string{
+		{"Connected to", connection},
+		{"Daemon", fmt.Sprintf("%s, ID %s", info.Name, info.ID)},
+		{"Docker", fmt.Sprintf("%s (%s, built with %s)", version.Version, version.GitCommit, version.GoVersion)},
+		{"API version", fmt.Sprintf("server %s (down to %s), client %s", version.APIVersion, version.MinAPIVersion, m.docker.ClientAPIVersion())},
+		{"OS", fmt.Sprintf("%s (%s/%s)", info.OperatingSystem, info.OSType, info.Architecture)},
+		{"Kernel", info.KernelVersion},
+		{"Resources", fmt.Sprintf("%d CPUs, %s memory", info.NCPU, formatBytes(info.MemTotal))},
+		{"Containers", fmt.Sprintf("%d (%d running, %d paused, %d stopped)", info.Containers, info.ContainersRunning, info.ContainersPaused, info.ContainersStopped)},
+		{"Images", fmt.Sprintf("%d", info.Images)},
+		{"Storage driver", info.Driver},
+		{"Logging driver", info.LoggingDriver},
+		{"Root directory", info.DockerRootDir},
+	}
+	// Cgroups and the limits they enforce only exist on Linux
+	if info.OSType == "linux" {
+		rows = append(rows,
+			[2]string{"Cgroups", fmt.Sprintf("%s driver, v%s", info.CgroupDriver, info.CgroupVersion)},
+			[2]string{"Limits support", daemonLimitsSupport(info)})
+	}
+
+	var sb strings.Builder
+	for _, row := range rows {
+		sb.WriteString(labelStyle.Render(row[0]))
+		sb.WriteString(row[1])
+		sb.WriteString("\n")
+	}
+	for _, warning := range info.Warnings {
+		sb.WriteString(warningStyle.Render(IconWarning + strings.TrimPrefix(warning, "WARNING: ")))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// daemonLimitsSupport lists the resource limits the daemon can't enforce
+func daemonLimitsSupport(info system.Info) string {
+	var missing []string
+	for _, limit := range []struct {
+		name      string
+		supported bool
+	}{
+		{"memory", info.MemoryLimit},
+		{"swap", info.SwapLimit},
+		{"CPU shares", info.CPUShares},
+		{"CPU quota", info.CPUCfsQuota},
+		{"PIDs", info.PidsLimit},
+	} {
+		if !limit.supported {
+			missing = append(missing, limit.name)
+		}
+	}
+	if len(missing) == 0 {
+		return "memory, swap, CPU and PIDs limits all supported"
+	}
+	return "no " + strings.Join(missing, ", ") + " limits"
+}
+
+// renderDaemonInfo renders the system info panel
+func (m FullModel) renderDaemonInfo() string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Title)
+	errorStyle := lipgloss.NewStyle().Foreground(m.styles.Error)
+	hintStyle := lipgloss.NewStyle().Foreground(m.styles.Muted).Italic(true)
+
+	sb.WriteString(titleStyle.Render("Docker System Info"))
+	sb.WriteString("\n\n")
+
+	switch {
+	case m.daemonInfo.loading:
+		sb.WriteString(fmt.Sprintf("%s Asking the daemon...\n", m.spinner.View()))
+	case m.daemonInfo.err != nil:
+		sb.WriteString(errorStyle.Render(fmt.Sprintf("Failed to get the daemon's info: %v", m.daemonInfo.err)))
+		sb.WriteString("\n")
+	default:
+		sb.WriteString(m.daemonInfoDetails())
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render(fmt.Sprintf("%s copy for a bug report • %s refresh • %s/%s close",
+		DefaultFullKeyMap.Copy.Help().Key, DefaultFullKeyMap.Refresh.Help().Key, DefaultFullKeyMap.SystemInfo.Help().Key, DefaultFullKeyMap.Back.Help().Key)))
+	return sb.String()
+}
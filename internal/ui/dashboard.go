@@ -0,0 +1,219 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// Settings for the stats dashboard
+const (
+	dashboardInterval = 3 * time.Second // pause between samples
+	dashboardPageSize = 20              // containers shown at once
+)
+
+// statsDashboard shows the resource usage of every running container at once
+type statsDashboard struct {
+	open    bool
+	loading bool
+	session int // identifies the current sampling loop, so stale ones stop
+	rows    []dashboardRow
+	cursor  int
+	err     error
+	at      time.Time
+}
+
+// dashboardRow is the latest sample of one running container
+type dashboardRow struct {
+	id    string
+	name  string
+	stats docker.ContainerStats
+}
+
+// dashboardStatsMsg carries a sample of all running containers
+type dashboardStatsMsg struct {
+	session int
+	stats   map[string]docker.ContainerStats
+	err     error
+}
+
+// dashboardTickMsg asks for the next dashboard sample
+type dashboardTickMsg struct {
+	session int
+}
+
+// toggleDashboard opens the stats dashboard and starts sampling, or closes it
+func (m *FullModel) toggleDashboard() tea.Cmd {
+	session := m.dashboard.session + 1
+	if m.dashboard.open {
+		m.dashboard = statsDashboard{session: session}
+		return nil
+	}
+	m.dashboard = statsDashboard{open: true, session: session}
+	return tea.Batch(m.spinner.Tick, m.sampleDashboard())
+}
+
+// sampleDashboard samples every running container. A new session is started,
+// so a sample or tick still pending from before is dropped.
+func (m *FullModel) sampleDashboard() tea.Cmd {
+	m.dashboard.session++
+	m.dashboard.loading = true
+	session := m.dashboard.session
+	return func() tea.Msg {
+		stats, err := m.docker.GetAllProcessedStats(m.ctx)
+		return dashboardStatsMsg{session: session, stats: stats, err: err}
+	}
+}
+
+// handleDashboardStats shows a sample, sorted by CPU usage, and schedules the next one
+func (m *FullModel) handleDashboardStats(msg dashboardStatsMsg) tea.Cmd {
+	if !m.dashboard.open || msg.session != m.dashboard.session {
+		return nil
+	}
+	m.dashboard.loading = false
+	m.dashboard.err = msg.err
+	if msg.err == nil {
+		var selected string
+		if m.dashboard.cursor < len(m.dashboard.rows) {
+			selected = m.dashboard.rows[m.dashboard.cursor].id
+		}
+
+		rows := make([]dashboardRow, 0, len(msg.stats))
+		for id, stats := range msg.stats {
+			rows = append(rows, dashboardRow{id: id, name: m.containerName(id), stats: stats})
+		}
+		sort.Slice(rows, func(i, j int) bool {
+			if rows[i].stats.CPUPercentage != rows[j].stats.CPUPercentage {
+				return rows[i].stats.CPUPercentage > rows[j].stats.CPUPercentage
+			}
+			return rows[i].name < rows[j].name
+		})
+
+		// Keep the same container selected as the rows get reordered
+		m.dashboard.cursor = min(m.dashboard.cursor, max(0, len(rows)-1))
+		for i, row := range rows {
+			if row.id == selected {
+				m.dashboard.cursor = i
+			}
+		}
+		m.dashboard.rows = rows
+		m.dashboard.at = time.Now()
+	}
+
+	session := m.dashboard.session
+	return tea.Tick(dashboardInterval, func(time.Time) tea.Msg {
+		return dashboardTickMsg{session: session}
+	})
+}
+
+// handleDashboardTick takes the next sample, unless the dashboard was closed
+// or restarted meanwhile
+func (m *FullModel) handleDashboardTick(msg dashboardTickMsg) tea.Cmd {
+	if !m.dashboard.open || msg.session != m.dashboard.session {
+		return nil
+	}
+	return m.sampleDashboard()
+}
+
+// containerName returns the name of the container with the given full ID as
+// shown in the list, or its short ID if it isn't listed yet
+func (m FullModel) containerName(id string) string {
+	for _, c := range m.containers {
+		if strings.HasPrefix(id, c.ID) {
+			return c.Name
+		}
+	}
+	return docker.ShortID(id, 12)
+}
+
+// handleDashboardKey processes key presses while the stats dashboard is open
+func (m *FullModel) handleDashboardKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, DefaultFullKeyMap.Up):
+		m.dashboard.cursor = max(0, m.dashboard.cursor-1)
+	case key.Matches(msg, DefaultFullKeyMap.Down):
+		m.dashboard.cursor = min(max(0, len(m.dashboard.rows)-1), m.dashboard.cursor+1)
+	case msg.String() == "enter":
+		if m.dashboard.cursor >= len(m.dashboard.rows) {
+			return nil
+		}
+		row := m.dashboard.rows[m.dashboard.cursor]
+		m.toggleDashboard()
+		m.selectedID = docker.ShortID(row.id, 12)
+		m.selectedName = row.name
+		return m.startMonitoring()
+	case key.Matches(msg, DefaultFullKeyMap.Refresh):
+		if !m.dashboard.loading {
+			return m.sampleDashboard()
+		}
+	case key.Matches(msg, DefaultFullKeyMap.Dashboard), key.Matches(msg, DefaultFullKeyMap.Back):
+		return m.toggleDashboard()
+	case key.Matches(msg, DefaultFullKeyMap.Quit):
+		return m.quit()
+	}
+	return nil
+}
+
+// renderDashboard renders the stats dashboard
+func (m FullModel) renderDashboard() string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Title)
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Accent)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(m.styles.SelectedText).
+		Background(m.styles.SelectedBackground).
+		Bold(true)
+	errorStyle := lipgloss.NewStyle().Foreground(m.styles.Error)
+	hintStyle := lipgloss.NewStyle().Foreground(m.styles.Muted).Italic(true)
+
+	title := "Container Stats"
+	if !m.dashboard.at.IsZero() {
+		title += fmt.Sprintf(" (%d running, sampled at %s)", len(m.dashboard.rows), m.dashboard.at.Format("15:04:05"))
+	}
+	sb.WriteString(titleStyle.Render(title))
+	sb.WriteString("\n\n")
+
+	switch {
+	case m.dashboard.err != nil:
+		sb.WriteString(errorStyle.Render(fmt.Sprintf("Failed to get container stats: %v", m.dashboard.err)))
+		sb.WriteString("\n")
+	case m.dashboard.at.IsZero():
+		sb.WriteString(fmt.Sprintf("%s Sampling running containers...\n", m.spinner.View()))
+	case len(m.dashboard.rows) == 0:
+		sb.WriteString("  No running containers\n")
+	default:
+		row := "%-30s %8s %8s  %s"
+		sb.WriteString(headerStyle.Render("  " + fmt.Sprintf(row, "NAME", "CPU %", "MEM %", "NET I/O (RX / TX)")))
+		sb.WriteString("\n")
+
+		// Scroll so the selected row stays in view
+		start := max(0, m.dashboard.cursor-dashboardPageSize+1)
+		end := min(len(m.dashboard.rows), start+dashboardPageSize)
+		for i := start; i < end; i++ {
+			r := m.dashboard.rows[i]
+			line := fmt.Sprintf(row,
+				truncateCell(r.name, 30),
+				fmt.Sprintf("%.1f%%", r.stats.CPUPercentage),
+				fmt.Sprintf("%.1f%%", r.stats.MemoryPercentage),
+				fmt.Sprintf("%s / %s", formatBytes(r.stats.NetworkRx), formatBytes(r.stats.NetworkTx)))
+			if i == m.dashboard.cursor {
+				sb.WriteString(selectedStyle.Render("> " + line))
+			} else {
+				sb.WriteString("  " + line)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render(fmt.Sprintf("%s select • enter monitor • %s refresh • %s/%s close",
+		keyPair(DefaultFullKeyMap.Up, DefaultFullKeyMap.Down), DefaultFullKeyMap.Refresh.Help().Key, DefaultFullKeyMap.Dashboard.Help().Key, DefaultFullKeyMap.Back.Help().Key)))
+	return sb.String()
+}
@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// diskUsagePanel shows the space Docker takes up on the host, like `docker system df`
+type diskUsagePanel struct {
+	open    bool
+	loading bool
+	usage   docker.DiskUsage
+	err     error
+}
+
+// diskUsageMsg carries the result of a disk usage query
+type diskUsageMsg struct {
+	usage docker.DiskUsage
+	err   error
+}
+
+// pruneDoneMsg reports the outcome of a prune, which the disk usage panel
+// also has to pick up
+type pruneDoneMsg struct {
+	result fullActionResultMsg
+}
+
+// toggleDiskUsage opens the disk usage panel, loading fresh numbers, or closes it
+func (m *FullModel) toggleDiskUsage() tea.Cmd {
+	if m.diskUsage.open {
+		m.diskUsage = diskUsagePanel{}
+		return nil
+	}
+	m.diskUsage = diskUsagePanel{open: true}
+	return m.fetchDiskUsage()
+}
+
+// fetchDiskUsage queries the daemon for its disk usage
+func (m *FullModel) fetchDiskUsage() tea.Cmd {
+	m.diskUsage.loading = true
+	return tea.Batch(m.spinner.Tick, func() tea.Msg {
+		usage, err := m.docker.DiskUsage(m.ctx)
+		return diskUsageMsg{usage: usage, err: err}
+	})
+}
+
+// handleDiskUsage stores a disk usage result, unless the panel was closed meanwhile
+func (m *FullModel) handleDiskUsage(msg diskUsageMsg) {
+	if !m.diskUsage.open {
+		return
+	}
+	m.diskUsage.loading = false
+	m.diskUsage.usage = msg.usage
+	m.diskUsage.err = msg.err
+}
+
+// handleDiskUsageKey processes key presses while the disk usage panel is open
+func (m *FullModel) handleDiskUsageKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, DefaultFullKeyMap.DiskUsage), key.Matches(msg, DefaultFullKeyMap.Back):
+		return m.toggleDiskUsage()
+	case key.Matches(msg, DefaultFullKeyMap.Refresh):
+		if !m.diskUsage.loading {
+			return m.fetchDiskUsage()
+		}
+	case key.Matches(msg, DefaultFullKeyMap.PruneAll):
+		// The same prune as everywhere else, listing what it removes
+		return m.pruneEverything()
+	case key.Matches(msg, DefaultFullKeyMap.Quit):
+		return m.quit()
+	}
+	return nil
+}
+
+// renderDiskUsage renders the disk usage panel
+func (m FullModel) renderDiskUsage() string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Title)
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Accent)
+	errorStyle := lipgloss.NewStyle().Foreground(m.styles.Error)
+	hintStyle := lipgloss.NewStyle().Foreground(m.styles.Muted).Italic(true)
+
+	sb.WriteString(titleStyle.Render("Disk Usage"))
+	sb.WriteString("\n\n")
+
+	switch {
+	case m.diskUsage.loading:
+		sb.WriteString(fmt.Sprintf("%s Calculating disk usage...\n", m.spinner.View()))
+	case m.diskUsage.err != nil:
+		sb.WriteString(errorStyle.Render(fmt.Sprintf("Failed to get disk usage: %v", m.diskUsage.err)))
+		sb.WriteString("\n")
+	default:
+		usage := m.diskUsage.usage
+		totalSize, totalReclaimable := usage.Total()
+
+		row := "  %-12s %6s %7s %11s %20s  %s"
+		sb.WriteString(headerStyle.Render(fmt.Sprintf(row, "TYPE", "TOTAL", "ACTIVE", "SIZE", "RECLAIMABLE", "SHARE OF TOTAL")))
+		sb.WriteString("\n")
+		categories := []struct {
+			name     string
+			category docker.DiskUsageCategory
+		}{
+			{"Images", usage.Images},
+			{"Containers", usage.Containers},
+			{"Volumes", usage.Volumes},
+			{"Build cache", usage.BuildCache},
+		}
+		for _, c := range categories {
+			share := 0.0
+			if totalSize > 0 {
+				share = float64(c.category.Size) / float64(totalSize) * 100
+			}
+			sb.WriteString(fmt.Sprintf(row,
+				c.name,
+				fmt.Sprint(c.category.Total),
+				fmt.Sprint(c.category.Active),
+				formatBytes(c.category.Size),
+				reclaimableLabel(c.category.Reclaimable, c.category.Size),
+				m.createUsageBar(share, 20)))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("  Total: %s, of which %s reclaimable\n",
+			formatBytes(totalSize), reclaimableLabel(totalReclaimable, totalSize)))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render(fmt.Sprintf("%s prune all reclaimable • %s refresh • %s/%s close",
+		DefaultFullKeyMap.PruneAll.Help().Key, DefaultFullKeyMap.Refresh.Help().Key, DefaultFullKeyMap.DiskUsage.Help().Key, DefaultFullKeyMap.Back.Help().Key)))
+	return sb.String()
+}
+
+// reclaimableLabel formats reclaimable space with its share of the size
+func reclaimableLabel(reclaimable, size int64) string {
+	if size <= 0 {
+		return formatBytes(reclaimable)
+	}
+	return fmt.Sprintf("%s (%.0f%%)", formatBytes(reclaimable), float64(reclaimable)/float64(size)*100)
+}
@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// maxEventLogEntries is how many Docker events the Events tab keeps; the
+// oldest are dropped first
+const maxEventLogEntries = 500
+
+// eventTypeFilters maps the Events tab filters to the event type they keep
+var eventTypeFilters = map[statusFilter]string{
+	filterContainerEvents: "container",
+	filterImageEvents:     "image",
+	filterVolumeEvents:    "volume",
+	filterNetworkEvents:   "network",
+}
+
+// eventLog keeps the Docker events seen while the UI is open
+type eventLog struct {
+	entries []docker.DockerEvent // oldest first
+	offset  int                  // shown entries scrolled past, counting from the newest
+}
+
+// recordEvent adds a Docker event to the Events tab
+func (m *FullModel) recordEvent(event docker.DockerEvent) {
+	m.eventLog.entries = append(m.eventLog.entries, event)
+	if len(m.eventLog.entries) > maxEventLogEntries {
+		m.eventLog.entries = m.eventLog.entries[len(m.eventLog.entries)-maxEventLogEntries:]
+	}
+
+	// Keep showing the same events while scrolled back; at the bottom, follow
+	if m.eventLog.offset > 0 && len(m.filterEvents([]docker.DockerEvent{event})) > 0 {
+		m.eventLog.offset++
+	}
+}
+
+// visibleEvents returns the events that pass the Events tab filters
+func (m FullModel) visibleEvents() []docker.DockerEvent {
+	return m.filterEvents(m.eventLog.entries)
+}
+
+// filterEvents keeps the events of the type picked with the filter key whose
+// resource name, ID or action match the text filter
+func (m FullModel) filterEvents(entries []docker.DockerEvent) []docker.DockerEvent {
+	if eventType, ok := eventTypeFilters[m.tabFilter(EventsTab)]; ok {
+		var matched []docker.DockerEvent
+		for _, event := range entries {
+			if event.Type == eventType {
+				matched = append(matched, event)
+			}
+		}
+		entries = matched
+	}
+	return matchText(entries, m.listFilter[EventsTab], eventText)
+}
+
+// eventText returns the fields of an event searched by the list filter
+func eventText(event docker.DockerEvent) []string {
+	return []string{event.Resource, event.ID, event.Action}
+}
+
+// eventLogPageSize is how many events fit on the Events tab
+func (m FullModel) eventLogPageSize() int {
+	return max(5, m.height-10)
+}
+
+// handleEventLogKey scrolls the Events tab. It has nothing to act on, so
+// resource actions are ignored.
+func (m *FullModel) handleEventLogKey(msg tea.KeyMsg) tea.Cmd {
+	page := m.eventLogPageSize()
+	maxOffset := max(0, len(m.visibleEvents())-page)
+
+	switch {
+	case key.Matches(msg, DefaultFullKeyMap.Up):
+		m.eventLog.offset = min(maxOffset, m.eventLog.offset+1)
+	case key.Matches(msg, DefaultFullKeyMap.Down):
+		m.eventLog.offset = max(0, m.eventLog.offset-1)
+	case key.Matches(msg, DefaultFullKeyMap.PageUp):
+		m.eventLog.offset = min(maxOffset, m.eventLog.offset+page)
+	case key.Matches(msg, DefaultFullKeyMap.PageDown):
+		m.eventLog.offset = max(0, m.eventLog.offset-page)
+	case key.Matches(msg, DefaultFullKeyMap.GoToTop):
+		m.eventLog.offset = maxOffset
+	case key.Matches(msg, DefaultFullKeyMap.GoToBottom):
+		m.eventLog.offset = 0
+	}
+	return nil
+}
+
+// eventTypeColor color-codes the Events tab by resource type
+func (m FullModel) eventTypeColor(eventType string) lipgloss.Color {
+	switch eventType {
+	case "image":
+		return m.styles.Special
+	case "volume":
+		return m.styles.Warning
+	case "network":
+		return m.styles.Success
+	default:
+		return m.styles.Title
+	}
+}
+
+// renderEventLog renders the Events tab, newest events last
+func (m FullModel) renderEventLog() string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Accent)
+	timeStyle := lipgloss.NewStyle().Foreground(m.styles.Border)
+	hintStyle := lipgloss.NewStyle().Foreground(m.styles.Muted).Italic(true)
+
+	events := m.visibleEvents()
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("Docker Events (%d of %d)", len(events), len(m.eventLog.entries))))
+	sb.WriteString("\n\n")
+
+	if len(events) == 0 {
+		if len(m.eventLog.entries) == 0 {
+			sb.WriteString("  No events since docker-tea started\n")
+		} else {
+			sb.WriteString("  No events match the filter\n")
+		}
+	}
+
+	page := m.eventLogPageSize()
+	end := max(0, len(events)-m.eventLog.offset)
+	nameWidth := max(20, m.width-60)
+	for _, event := range events[max(0, end-page):end] {
+		style := lipgloss.NewStyle().Foreground(m.eventTypeColor(event.Type))
+		name := event.Resource
+		if name == "" {
+			name = event.ID
+		}
+		id := event.ID
+		if !strings.Contains(id, ":") {
+			id = docker.ShortID(id, 12)
+		}
+		sb.WriteString(fmt.Sprintf("  %s %s %s %s %s\n",
+			timeStyle.Render(event.Time.Format("15:04:05")),
+			style.Render(fmt.Sprintf("%-9s", event.Type)),
+			style.Bold(true).Render(fmt.Sprintf("%-14s", truncateCell(event.Action, 14))),
+			truncateCell(name, nameWidth),
+			timeStyle.Render(id)))
+	}
+
+	sb.WriteString("\n")
+	hint := fmt.Sprintf("%s scroll • %s filter by type • %s filter by name",
+		keyPair(DefaultFullKeyMap.Up, DefaultFullKeyMap.Down), DefaultFullKeyMap.Filter.Help().Key, DefaultFullKeyMap.Search.Help().Key)
+	if m.eventLog.offset > 0 {
+		hint += fmt.Sprintf(" • %d newer (%s to follow)", m.eventLog.offset, DefaultFullKeyMap.GoToBottom.Help().Key)
+	}
+	sb.WriteString(hintStyle.Render(hint))
+	return sb.String()
+}
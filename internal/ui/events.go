@@ -3,6 +3,8 @@ package ui
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/klejdi94/docker-tea/internal/docker"
@@ -13,70 +15,147 @@ type DockerEventMsg struct {
 	Event docker.DockerEvent
 }
 
-// SetupEventListener creates a goroutine that listens for Docker events and
-// forwards them to the Bubble Tea program
-func SetupEventListener(ctx context.Context, dockerSvc *docker.Service, program *tea.Program) {
+// EventListener forwards Docker events to the Bubble Tea program. It can be
+// restarted, e.g. after the Docker client has been recreated.
+type EventListener struct {
+	mu        sync.Mutex
+	ctx       context.Context
+	dockerSvc *docker.Service
+	program   *tea.Program
+	cancel    context.CancelFunc
+}
+
+// NewEventListener creates an event listener; call Start once the program exists
+func NewEventListener(ctx context.Context, dockerSvc *docker.Service) *EventListener {
+	return &EventListener{ctx: ctx, dockerSvc: dockerSvc}
+}
+
+// Start begins forwarding events to the program
+func (l *EventListener) Start(program *tea.Program) {
+	l.mu.Lock()
+	l.program = program
+	l.mu.Unlock()
+
+	l.Restart()
+}
+
+// Restart stops the current subscription, if any, and subscribes again
+func (l *EventListener) Restart() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.cancel != nil {
+		l.cancel()
+	}
+
 	// Create a cancellable context for the event listener
-	eventCtx, cancel := context.WithCancel(ctx)
+	eventCtx, cancel := context.WithCancel(l.ctx)
+	l.cancel = cancel
+	program := l.program
 
 	// Start the event listener in a separate goroutine
 	go func() {
 		defer cancel() // Ensure context is cancelled when goroutine exits
 
-		// Subscribe to Docker events
-		err := dockerSvc.SubscribeToEvents(eventCtx, func(event docker.DockerEvent) {
-			// Only forward events if program is set
+		send := func(msg tea.Msg) {
+			// Only forward messages if program is set
 			if program != nil {
-				program.Send(DockerEventMsg{Event: event})
+				program.Send(msg)
+			}
+		}
+
+		// The events request only fails once it's streaming, so make sure
+		// the daemon answers before reporting the stream as connected
+		if _, err := l.dockerSvc.Ping(eventCtx); err != nil {
+			if eventCtx.Err() == nil {
+				send(eventStreamMsg{err: fmt.Errorf("Docker event subscription error: %w", err)})
 			}
+			return
+		}
+		send(eventStreamMsg{connected: true})
+
+		// Subscribe to Docker events
+		err := l.dockerSvc.SubscribeToEvents(eventCtx, func(event docker.DockerEvent) {
+			send(DockerEventMsg{Event: event})
 		})
 
-		// Log any errors that aren't just from context cancellation
+		// Report errors that aren't just from context cancellation
 		if err != nil && eventCtx.Err() == nil {
-			if program != nil {
-				program.Send(ErrorMsg{err: fmt.Errorf("Docker event subscription error: %w", err)})
-			}
+			send(eventStreamMsg{err: fmt.Errorf("Docker event subscription error: %w", err)})
 		}
 	}()
 }
 
-// ErrorMsg represents an error message from the event listener
-type ErrorMsg struct {
-	err error
-}
+// eventWindow is how long events are collected, from the first one, before
+// the lists they affect are refetched, so a burst such as a compose up
+// refetches them once. The window isn't extended by later events, so a
+// steady stream of them still refreshes the lists twice a second at most.
+const eventWindow = 500 * time.Millisecond
 
-// StartEventSubscription creates a command to start event subscription
-func StartEventSubscription(dockerSvc *docker.Service, program *tea.Program) tea.Cmd {
-	return func() tea.Msg {
-		// This will be run in a goroutine managed by Bubble Tea
-		SetupEventListener(context.Background(), dockerSvc, program)
-		return nil
-	}
-}
+// dockerEventsSettledMsg is sent when the window collecting events closes
+type dockerEventsSettledMsg struct{}
 
-// HandleDockerEvent processes a Docker event and returns appropriate commands
+// HandleDockerEvent processes a Docker event and returns appropriate commands.
+// The lists are refetched when the window opened by the first event of a
+// burst closes.
 func HandleDockerEvent(model *FullModel, event docker.DockerEvent) []tea.Cmd {
 	var cmds []tea.Cmd
 
-	// Update status message with event info
-	model.statusMsg = fmt.Sprintf("Docker event: %s %s for %s", event.Action, event.Type, event.ID)
+	model.recordEvent(event)
+	if cmd := model.notifyContainerDeath(event); cmd != nil {
+		cmds = append(cmds, cmd)
+	}
 
-	// Refresh resources based on event type
 	switch event.Type {
-	case "container":
-		cmds = append(cmds, model.fetchContainers)
-	case "image":
-		cmds = append(cmds, model.fetchImages)
-	case "volume":
-		cmds = append(cmds, model.fetchVolumes)
-	case "network":
-		cmds = append(cmds, model.fetchNetworks)
+	case "container", "image", "volume", "network":
+		if len(model.pendingEvents) == 0 {
+			model.pendingEvents = make(map[string]bool)
+			cmds = append(cmds, tea.Tick(eventWindow, func(time.Time) tea.Msg {
+				return dockerEventsSettledMsg{}
+			}))
+		}
+		model.pendingEvents[event.Type] = true
+
+		// Containers created by compose change the status of their project
+		if event.Type == "container" && event.Attributes[composeLabelProject] != "" {
+			model.pendingEvents["compose"] = true
+		}
 	}
 
-	// If in monitor mode and the event is about the currently monitored container
-	if model.currentMode == MonitorMode && model.selectedID == event.ID {
-		cmds = append(cmds, model.fetchStats)
+	// If in monitor mode and the event is about the currently monitored
+	// container; events carry the full ID and the selection the short one
+	if model.currentMode == MonitorMode && event.Type == "container" && model.selectedID != "" && model.selectedID == docker.ShortID(event.ID, 12) {
+		// Reopen the stats stream, which ends when the container stops and
+		// shows nothing useful while it's paused
+		switch event.Action {
+		case "start", "die", "pause", "unpause":
+			cmds = append(cmds, model.startMonitoring())
+		}
 	}
 
 	return cmds
 }
+
+// refreshAfterEvents refetches the lists affected by the collected events,
+// without replacing the status message
+func (m *FullModel) refreshAfterEvents() tea.Cmd {
+	var cmds []tea.Cmd
+	for eventType := range m.pendingEvents {
+		var fetch tea.Cmd
+		switch eventType {
+		case "container":
+			fetch = m.fetchContainers
+		case "image":
+			fetch = m.fetchImages
+		case "volume":
+			fetch = m.fetchVolumes
+		case "network":
+			fetch = m.fetchNetworks
+		case "compose":
+			fetch = m.fetchComposeProjects
+		}
+		cmds = append(cmds, func() tea.Msg { return autoRefreshMsg{msg: fetch()} })
+	}
+	m.pendingEvents = nil
+	return tea.Batch(cmds...)
+}
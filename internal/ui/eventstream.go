@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// eventStreamRetryDelay is how long after the event stream broke docker-tea
+// checks whether it can subscribe again
+const eventStreamRetryDelay = 5 * time.Second
+
+// eventStreamState is whether the Docker events are coming in, shown in the header
+type eventStreamState int
+
+const (
+	eventStreamUnknown eventStreamState = iota // no listener, or it hasn't reported yet
+	eventStreamConnected
+	eventStreamReconnecting
+)
+
+// eventStreamMsg is sent by the event listener once it's subscribed, or when
+// the subscription fails
+type eventStreamMsg struct {
+	connected bool
+	err       error
+}
+
+// eventStreamRetryMsg is sent a while after the event stream broke, to try again
+type eventStreamRetryMsg struct{}
+
+// handleEventStream records the state of the event stream. When it breaks,
+// e.g. because Docker was restarted, the connection is checked again after a
+// moment, which re-subscribes once Docker answers.
+func (m *FullModel) handleEventStream(msg eventStreamMsg) tea.Cmd {
+	if msg.connected {
+		m.eventStream = eventStreamConnected
+		return nil
+	}
+
+	wasConnected := m.eventStream == eventStreamConnected
+	m.eventStream = eventStreamReconnecting
+	if wasConnected {
+		m.statusMsg = fmt.Sprintf("Error: %v", msg.err)
+	}
+	return tea.Tick(eventStreamRetryDelay, func(time.Time) tea.Msg {
+		return eventStreamRetryMsg{}
+	})
+}
+
+// handleEventStreamRetry checks the connection, unless the stream has been
+// restarted meanwhile or the checks already repeat while Docker is down
+func (m *FullModel) handleEventStreamRetry() tea.Cmd {
+	if m.eventStream != eventStreamReconnecting || m.dockerDown() {
+		return nil
+	}
+	return m.checkDockerConnection
+}
+
+// renderEventStream renders the state of the event stream for the header
+func (m FullModel) renderEventStream() string {
+	switch m.eventStream {
+	case eventStreamConnected:
+		return lipgloss.NewStyle().Foreground(m.styles.Muted).Render("event stream: connected")
+	case eventStreamReconnecting:
+		return lipgloss.NewStyle().Foreground(m.styles.Warning).Render("event stream: reconnecting…")
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// exportInspect writes the inspected resource to a file in the working
+// directory. Compose projects are exported as their resolved compose config.
+func (m FullModel) exportInspect() tea.Cmd {
+	name := m.selectedName
+	if name == "" {
+		name = m.selectedID
+	}
+	content, suffix := m.inspectContent, "-inspect.json"
+	tab, path := m.currentTab, m.selectedPath
+
+	return func() tea.Msg {
+		if tab == ComposeTab {
+			suffix = "-compose.yaml"
+			if path == "" {
+				return fullActionResultMsg{success: false, message: "Can't export: the project's directory is unknown"}
+			}
+			config, err := m.docker.ComposeConfig(m.ctx, path)
+			if err != nil {
+				return fullActionResultMsg{success: false, message: err.Error()}
+			}
+			content = config
+		}
+		if content == "" {
+			return fullActionResultMsg{success: false, message: "Nothing to export yet"}
+		}
+
+		file, err := writeExportFile(sanitizeFileName(name)+suffix, []byte(content))
+		if err != nil {
+			return fullActionResultMsg{success: false, message: fmt.Sprintf("Export failed: %v", err)}
+		}
+		return fullActionResultMsg{success: true, message: fmt.Sprintf("Exported %s to %s", name, file)}
+	}
+}
+
+// writeExportFile writes data to a new file called name. If one by that name
+// already exists, a timestamp is added before the extension, and a counter
+// after it should that be taken too. Files are created exclusively, so an
+// export never overwrites another. It returns the name written to.
+func writeExportFile(name string, data []byte) (string, error) {
+	ext := filepath.Ext(name)
+	stem := fmt.Sprintf("%s-%s", strings.TrimSuffix(name, ext), time.Now().Format("20060102-150405"))
+
+	for attempt := 0; ; attempt++ {
+		path := name
+		switch {
+		case attempt == 1:
+			path = stem + ext
+		case attempt > 1:
+			path = fmt.Sprintf("%s-%d%s", stem, attempt, ext)
+		}
+
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		_, err = file.Write(data)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			// Don't leave a partial export behind
+			os.Remove(path)
+			return "", err
+		}
+		return path, nil
+	}
+}
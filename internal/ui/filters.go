@@ -0,0 +1,222 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// statusFilter narrows the rows shown on a tab by their status
+type statusFilter string
+
+const (
+	filterAll       statusFilter = "all"
+	filterRunning   statusFilter = "running"
+	filterStopped   statusFilter = "stopped"
+	filterPaused    statusFilter = "paused"
+	filterTagged    statusFilter = "tagged"
+	filterDangling  statusFilter = "dangling"
+	filterNamed     statusFilter = "named"
+	filterAnonymous statusFilter = "anonymous"
+	filterCustom    statusFilter = "custom"
+	filterBuiltin   statusFilter = "built-in"
+	filterOrphaned  statusFilter = "orphaned"
+	filterUnhealthy statusFilter = "unhealthy"
+
+	// Filters of the Events tab, by resource type
+	filterContainerEvents statusFilter = "containers"
+	filterImageEvents     statusFilter = "images"
+	filterVolumeEvents    statusFilter = "volumes"
+	filterNetworkEvents   statusFilter = "networks"
+)
+
+// tabFilters lists the filters available on each tab, in the order they're cycled through
+var tabFilters = map[Tab][]statusFilter{
+	ContainersTab: {filterAll, filterRunning, filterStopped, filterPaused, filterUnhealthy, filterOrphaned},
+	ImagesTab:     {filterAll, filterTagged, filterDangling},
+	VolumesTab:    {filterAll, filterNamed, filterAnonymous},
+	NetworksTab:   {filterAll, filterCustom, filterBuiltin},
+	ComposeTab:    {filterAll, filterRunning, filterStopped},
+	EventsTab:     {filterAll, filterContainerEvents, filterImageEvents, filterVolumeEvents, filterNetworkEvents},
+}
+
+// defaultTabFilters holds the filters used on tabs the user hasn't set one for
+var defaultTabFilters = map[Tab]statusFilter{
+	ContainersTab: filterRunning,
+}
+
+// tabStateKeys names each tab in the state file
+var tabStateKeys = map[Tab]string{
+	ContainersTab: "containers",
+	ImagesTab:     "images",
+	VolumesTab:    "volumes",
+	NetworksTab:   "networks",
+	ComposeTab:    "compose",
+	EventsTab:     "events",
+}
+
+// builtinNetworks are the networks Docker creates itself
+var builtinNetworks = map[string]bool{"bridge": true, "host": true, "none": true}
+
+// anonymousVolumeName matches the generated names of anonymous volumes
+var anonymousVolumeName = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// tabFilter returns the filter in effect on a tab
+func (m FullModel) tabFilter(tab Tab) statusFilter {
+	if saved, ok := m.state.TabFilters[tabStateKeys[tab]]; ok {
+		for _, f := range tabFilters[tab] {
+			if string(f) == saved {
+				return f
+			}
+		}
+	}
+	if f, ok := defaultTabFilters[tab]; ok {
+		return f
+	}
+	return filterAll
+}
+
+// cycleTabFilter switches the current tab to its next filter and remembers the choice
+func (m *FullModel) cycleTabFilter() {
+	filters := tabFilters[m.currentTab]
+	if len(filters) == 0 {
+		return
+	}
+
+	current := m.tabFilter(m.currentTab)
+	next := filters[0]
+	for i, f := range filters {
+		if f == current {
+			next = filters[(i+1)%len(filters)]
+			break
+		}
+	}
+
+	m.state.TabFilters[tabStateKeys[m.currentTab]] = string(next)
+	if m.currentTab == EventsTab {
+		m.eventLog.offset = 0
+	} else {
+		m.getCurrentTable().SetCursor(0)
+		m.refreshRows(m.currentTab)
+	}
+
+	m.statusMsg = fmt.Sprintf("Showing %s", next)
+	if err := m.state.Save(); err != nil {
+		m.statusMsg = fmt.Sprintf("Showing %s (filter not saved: %v)", next, err)
+	}
+}
+
+// visibleContainers returns the containers that pass the Containers tab filters
+func (m FullModel) visibleContainers() []docker.ContainerInfo {
+	filter := m.tabFilter(ContainersTab)
+	if filter == filterAll {
+		return matchText(m.containers, m.listFilter[ContainersTab], containerText)
+	}
+
+	var visible []docker.ContainerInfo
+	for _, c := range m.containers {
+		state := strings.ToLower(c.State)
+		switch filter {
+		case filterRunning:
+			if state == "running" || state == "restarting" {
+				visible = append(visible, c)
+			}
+		case filterStopped:
+			if state == "exited" || state == "created" || state == "dead" {
+				visible = append(visible, c)
+			}
+		case filterPaused:
+			if state == "paused" {
+				visible = append(visible, c)
+			}
+		case filterUnhealthy:
+			if c.Health == "unhealthy" {
+				visible = append(visible, c)
+			}
+		case filterOrphaned:
+			if m.orphans[c.ID] {
+				visible = append(visible, c)
+			}
+		}
+	}
+	return matchText(visible, m.listFilter[ContainersTab], containerText)
+}
+
+// visibleImages returns the images that pass the Images tab filters
+func (m FullModel) visibleImages() []docker.ImageInfo {
+	images := m.sortedImages()
+	filter := m.tabFilter(ImagesTab)
+	if filter == filterAll {
+		return matchText(images, m.listFilter[ImagesTab], imageText)
+	}
+
+	var visible []docker.ImageInfo
+	for _, img := range images {
+		dangling := len(img.RepoTags) == 0 || img.RepoTags[0] == "<none>:<none>"
+		if dangling == (filter == filterDangling) {
+			visible = append(visible, img)
+		}
+	}
+	return matchText(visible, m.listFilter[ImagesTab], imageText)
+}
+
+// visibleVolumes returns the volumes that pass the Volumes tab filters
+func (m FullModel) visibleVolumes() []docker.VolumeInfo {
+	filter := m.tabFilter(VolumesTab)
+	if filter == filterAll {
+		return matchText(m.volumes, m.listFilter[VolumesTab], volumeText)
+	}
+
+	var visible []docker.VolumeInfo
+	for _, v := range m.volumes {
+		anonymous := anonymousVolumeName.MatchString(v.Name)
+		if anonymous == (filter == filterAnonymous) {
+			visible = append(visible, v)
+		}
+	}
+	return matchText(visible, m.listFilter[VolumesTab], volumeText)
+}
+
+// visibleNetworks returns the networks that pass the Networks tab filters
+func (m FullModel) visibleNetworks() []docker.NetworkInfo {
+	filter := m.tabFilter(NetworksTab)
+	if filter == filterAll {
+		return matchText(m.networks, m.listFilter[NetworksTab], networkText)
+	}
+
+	var visible []docker.NetworkInfo
+	for _, n := range m.networks {
+		if builtinNetworks[n.Name] == (filter == filterBuiltin) {
+			visible = append(visible, n)
+		}
+	}
+	return matchText(visible, m.listFilter[NetworksTab], networkText)
+}
+
+// visibleComposeProjects returns the projects that pass the Compose tab filters
+func (m FullModel) visibleComposeProjects() []docker.ComposeInfo {
+	filter := m.tabFilter(ComposeTab)
+	if filter == filterAll {
+		return matchText(m.composeProjects, m.listFilter[ComposeTab], composeText)
+	}
+
+	var visible []docker.ComposeInfo
+	for _, p := range m.composeProjects {
+		running := strings.Contains(strings.ToLower(p.Status), "running")
+		if running == (filter == filterRunning) {
+			visible = append(visible, p)
+		}
+	}
+	return matchText(visible, m.listFilter[ComposeTab], composeText)
+}
+
+// filterSummary describes how many of a tab's resources are shown, for the status bar
+func (m FullModel) filterSummary(tab Tab, shown int) string {
+	filter := m.tabFilter(tab)
+	if filter == filterAll {
+		return ""
+	}
+	return fmt.Sprintf(" (%d shown, filter: %s)", shown, filter)
+}
@@ -0,0 +1,29 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// containerRunningMsg reports a container that wasn't removed because it's
+// still running
+type containerRunningMsg struct {
+	id, name string
+}
+
+// confirmForceRemove asks whether to kill and remove a running container the
+// user tried to remove
+func (m *FullModel) confirmForceRemove(msg containerRunningMsg) tea.Cmd {
+	m.statusMsg = fmt.Sprintf("%s is running", msg.name)
+	return m.confirmBulk("force-remove", "Container is running, force remove it", []string{msg.name}, func(m *FullModel) tea.Cmd {
+		m.stops.record(containerStopKey(msg.id))
+		m.statusMsg = fmt.Sprintf("Force removing %s...", msg.name)
+		return func() tea.Msg {
+			if err := m.docker.RemoveContainer(m.ctx, msg.id, true); err != nil {
+				return fullActionResultMsg{success: false, message: err.Error()}
+			}
+			return fullActionResultMsg{success: true, message: fmt.Sprintf("Force removed %s", msg.name), action: "remove"}
+		}
+	})
+}
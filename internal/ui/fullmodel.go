@@ -2,6 +2,7 @@ package ui
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -14,6 +15,7 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/docker/api/types/container"
 	"github.com/klejdi94/docker-tea/internal/config"
 	"github.com/klejdi94/docker-tea/internal/docker"
 	"github.com/klejdi94/docker-tea/internal/ui/views"
@@ -27,6 +29,7 @@ const (
 	IconVolume    = "💾 "
 	IconNetwork   = "🌐 "
 	IconCompose   = "🔄 "
+	IconEvents    = "📡 "
 
 	// Status icons
 	IconRunning    = "🟢 "
@@ -37,6 +40,11 @@ const (
 	IconExited     = "⏹️  "
 	IconDead       = "💀 "
 
+	// Health icons, shown after the status of containers with a healthcheck
+	IconHealthy        = "✅"
+	IconUnhealthy      = "❌"
+	IconHealthStarting = "⏳"
+
 	// Action icons
 	IconInspect = "🔍 "
 	IconLogs    = "📜 "
@@ -70,9 +78,13 @@ const (
 	VolumesTab
 	NetworksTab
 	ComposeTab
+	EventsTab
 	LogsTab
 )
 
+// tabCount is the number of tabs cycled through with tab and shift+tab
+const tabCount = EventsTab + 1
+
 // ResourceMode tracks current UI mode
 type Mode int
 
@@ -84,16 +96,38 @@ const (
 	ComposeServiceMode // New mode for viewing individual compose services
 )
 
+// inspectView tracks which sub-view of a resource is shown in InspectMode
+type inspectView int
+
+const (
+	inspectViewDefault inspectView = iota
+	inspectViewEnv                 // Container environment compared to its image
+	inspectViewTop                 // Processes running in the container
+	inspectViewDiff                // Filesystem changes of the container
+	inspectViewLayers              // Layer history of the image
+	inspectViewConfig              // Resolved configuration of the compose project
+	inspectViewPs                  // Containers of the compose project
+)
+
 // FullModel represents the complete Bubble Tea model for Docker TUI
 type FullModel struct {
 	config                   *config.Config
+	state                    *config.State
+	events                   *EventListener
+	eventStream              eventStreamState
+	pendingEvents            map[string]bool // resource types to refetch after a burst of events
 	docker                   *docker.Service
 	ctx                      context.Context
+	rowTargets               map[Tab][]rowTarget
+	styles                   views.Styles
+	stops                    *intentionalStops // containers stopped on purpose, whose deaths aren't reported
+	banner                   deathBanner       // shown when a container dies unexpectedly
 	width                    int
 	height                   int
 	loading                  bool
 	err                      error
 	dockerConnected          bool
+	dockerErr                error
 	containerTable           table.Model
 	imageTable               table.Model
 	volumeTable              table.Model
@@ -105,34 +139,93 @@ type FullModel struct {
 	statusMsg                string
 	containers               []docker.ContainerInfo
 	images                   []docker.ImageInfo
+	imagesBySize             bool // list the images largest first
 	volumes                  []docker.VolumeInfo
 	networks                 []docker.NetworkInfo
 	composeProjects          []docker.ComposeInfo
 	logContent               string
 	inspectContent           string
+	restartPolicy            container.RestartPolicy // of the inspected container
 	statsContent             string
 	selectedID               string
 	selectedName             string
 	selectedPath             string
 	showHelp                 bool
+	orphans                  map[string]bool // IDs of containers left behind by deleted compose projects
+	composeProjectsLoaded    bool
+	confirm                  confirmation
+	createForm               createForm
+	resourceForm             resourceForm
+	imageTransfer            *imageTransfer
+	transferView             viewport.Model // progress of the image transfer
+	usage                    containerUsage
+	history                  actionHistory
+	diskUsage                diskUsagePanel
+	daemonInfo               daemonInfoPanel
+	dashboard                statsDashboard
 	ticker                   *time.Ticker
 	composeServices          []docker.ComposeServiceInfo
 	composeServicesLoading   bool
 	selectedProject          string
 	selectedProjectPath      string
+	composeConfig            composeConfigMsg        // shown on the config sub-tab of compose inspect
+	composePs                []docker.ComposePsEntry // shown on the ps sub-tab of compose inspect
 	loadingCompose           bool
 	spinner                  spinner.Model
 	composeContainers        []docker.ContainerInfo
 	composeContainersLoading bool
 	systemInfo               docker.SystemInfo
 	systemInfoLoading        bool
+	picker                   picker
+	prompt                   prompt
+	inspectView              inspectView
+	inspectTree              inspectTree
+	topTable                 table.Model    // processes of the inspected container
+	topNote                  string         // shown instead of the processes when there are none
+	layers                   imageLayers    // layer history of the inspected image
+	listFilter               map[Tab]string // text filter typed with / on each tab
+	eventLog                 eventLog       // Docker events shown on the Events tab
+	pendingSelection         string         // container to select once the list reloads
+	sessionSelection         string         // resource selected at the last quit, until its list loads
+	autoRefresh              bool           // periodically refresh the list on screen
+	logGrep                  string
+	logGrepContext           int
+	viewportContent          string         // viewport content before search highlighting
+	lineWrap                 map[Mode]bool  // whether long lines are wrapped, by viewport mode
+	search                   viewportSearch // active search in the logs/inspect viewport
+	composeLogs              *composeLogStream
+	logFollow                *logFollowStream
+	logTail                  int // lines of history the logs view starts with, 0 for all
+	logStreams               docker.LogStreams
+	logWindow                docker.LogWindow // time range the logs view is limited to
+	logWindowInput           string           // what the time range was entered as
+	logDownload              *logDownload
+	lastLogExport            string                      // file the full logs were last downloaded to
+	composeServiceList       []docker.ComposeServiceInfo // services shown in the compose inspect view
+	composeServiceCursor     int                         // service that service actions apply to
+	networkContainerCursor   int                         // connected container that disconnect applies to
+	statsStream              *containerStatsStream
+	dockerContext            string
+	dockerContexts           []docker.DockerContext
 }
 
 // FullKeyMap defines the keybindings for the application
 type FullKeyMap struct {
 	// Global
-	Quit key.Binding
-	Help key.Binding
+	Quit          key.Binding
+	Help          key.Binding
+	SwitchContext key.Binding
+	HardRefresh   key.Binding
+	History       key.Binding
+	OpenAppLog    key.Binding
+	OpenLogExport key.Binding
+	ReloadConfig  key.Binding
+	AutoRefresh   key.Binding
+	DiskUsage     key.Binding
+	PruneAll      key.Binding
+	Dashboard     key.Binding
+	SwitchTheme   key.Binding
+	SystemInfo    key.Binding
 
 	// Navigation
 	Up         key.Binding
@@ -147,10 +240,14 @@ type FullKeyMap struct {
 	PrevTab key.Binding
 
 	// Resource management
+	Filter  key.Binding
 	Refresh key.Binding
 	Inspect key.Binding
 	Logs    key.Binding
 	Monitor key.Binding
+	Export  key.Binding
+	Copy    key.Binding
+	Prune   key.Binding
 	Back    key.Binding
 
 	// Container actions
@@ -161,50 +258,75 @@ type FullKeyMap struct {
 	Resume  key.Binding
 	Kill    key.Binding
 	Remove  key.Binding
+	Env     key.Binding
+	Top     key.Binding
+	Diff    key.Binding
+	Commit  key.Binding
+	Limits  key.Binding
+	Clone   key.Binding
+	New     key.Binding
+	Rename  key.Binding
+	Timeout key.Binding
+	Policy  key.Binding
+
+	// Search actions
+	Search    key.Binding
+	NextMatch key.Binding
+	PrevMatch key.Binding
+	MatchCase key.Binding
+	WrapLines key.Binding
+	Highlight key.Binding
+
+	// Inspect tree actions
+	Fold    key.Binding
+	RawJSON key.Binding
+
+	// Log actions
+	LogGrep      key.Binding
+	MoreContext  key.Binding
+	LessContext  key.Binding
+	DownloadLogs key.Binding
+	FollowLogs   key.Binding
+	CycleLogTail key.Binding
+	LogStreams   key.Binding
+	Timestamps   key.Binding
+	LogWindow    key.Binding
+	SoloService  key.Binding
+
+	// Image actions
+	PullImage key.Binding
+	TagImage  key.Binding
+	PushImage key.Binding
+	Layers    key.Binding
+	Expand    key.Binding
+	SearchHub key.Binding
+	Login     key.Binding
+	SortSize  key.Binding
 
 	// Compose actions
-	ComposeUp   key.Binding
-	ComposeDown key.Binding
-	ComposePull key.Binding
-}
-
-var FullKeyMapHelp = [][]key.Binding{
-	// Global
-	{
-		DefaultFullKeyMap.Quit,
-		DefaultFullKeyMap.Help,
-		DefaultFullKeyMap.Refresh,
-	},
-	// Navigation
-	{
-		DefaultFullKeyMap.Up,
-		DefaultFullKeyMap.Down,
-		DefaultFullKeyMap.NextTab,
-		DefaultFullKeyMap.PrevTab,
-	},
-	// Resource Actions
-	{
-		DefaultFullKeyMap.Inspect,
-		DefaultFullKeyMap.Logs,
-		DefaultFullKeyMap.Monitor,
-		DefaultFullKeyMap.Back,
-	},
-	// Container Actions
-	{
-		DefaultFullKeyMap.Start,
-		DefaultFullKeyMap.Stop,
-		DefaultFullKeyMap.Restart,
-		DefaultFullKeyMap.Pause,
-		DefaultFullKeyMap.Resume,
-		DefaultFullKeyMap.Kill,
-		DefaultFullKeyMap.Remove,
-	},
-	// Compose Actions
-	{
-		DefaultFullKeyMap.ComposeUp,
-		DefaultFullKeyMap.ComposeDown,
-		DefaultFullKeyMap.ComposePull,
-	},
+	ComposeUp          key.Binding
+	ComposeDown        key.Binding
+	ComposePull        key.Binding
+	ComposeTail        key.Binding
+	ServiceTail        key.Binding
+	ComposeScale       key.Binding
+	ComposeExec        key.Binding
+	ComposeOverrides   key.Binding
+	ComposeView        key.Binding
+	PrevService        key.Binding
+	NextService        key.Binding
+	ComposeServiceLogs key.Binding
+	RemoveOrphans      key.Binding
+	ComposeSearchPath  key.Binding
+	ComposeContainer   key.Binding
+
+	// Volume actions
+	CreateVolume key.Binding
+
+	// Network actions
+	CreateNetwork     key.Binding
+	ConnectNetwork    key.Binding
+	DisconnectNetwork key.Binding
 }
 
 var DefaultFullKeyMap = FullKeyMap{
@@ -221,6 +343,58 @@ var DefaultFullKeyMap = FullKeyMap{
 		key.WithKeys("r"),
 		key.WithHelp("r", "refresh"),
 	),
+	Filter: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "cycle status filter"),
+	),
+	SwitchContext: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "switch context"),
+	),
+	SwitchTheme: key.NewBinding(
+		key.WithKeys("ctrl+t"),
+		key.WithHelp("ctrl+t", "switch theme"),
+	),
+	HardRefresh: key.NewBinding(
+		key.WithKeys("ctrl+r"),
+		key.WithHelp("ctrl+r", "reconnect to docker"),
+	),
+	History: key.NewBinding(
+		key.WithKeys("H"),
+		key.WithHelp("H", "action history"),
+	),
+	OpenAppLog: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "open app log"),
+	),
+	OpenLogExport: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "open downloaded logs"),
+	),
+	ReloadConfig: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "reload config"),
+	),
+	AutoRefresh: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "pause/resume auto-refresh"),
+	),
+	DiskUsage: key.NewBinding(
+		key.WithKeys("U"),
+		key.WithHelp("U", "disk usage"),
+	),
+	PruneAll: key.NewBinding(
+		key.WithKeys("Z"),
+		key.WithHelp("Z", "prune everything unused"),
+	),
+	Dashboard: key.NewBinding(
+		key.WithKeys("M"),
+		key.WithHelp("M", "stats of all running containers"),
+	),
+	SystemInfo: key.NewBinding(
+		key.WithKeys("V"),
+		key.WithHelp("V", "docker info and version"),
+	),
 
 	// Navigation
 	Up: key.NewBinding(
@@ -271,6 +445,18 @@ var DefaultFullKeyMap = FullKeyMap{
 		key.WithKeys("m"),
 		key.WithHelp("m", "monitor"),
 	),
+	Export: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "export to file"),
+	),
+	Copy: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "copy to clipboard"),
+	),
+	Prune: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "prune unused"),
+	),
 	Back: key.NewBinding(
 		key.WithKeys("esc"),
 		key.WithHelp("esc", "back"),
@@ -299,12 +485,164 @@ var DefaultFullKeyMap = FullKeyMap{
 	),
 	Kill: key.NewBinding(
 		key.WithKeys("K"),
-		key.WithHelp("K", "kill"),
+		key.WithHelp("K", "kill (pick a signal)"),
+	),
+	Timeout: key.NewBinding(
+		key.WithKeys("W"),
+		key.WithHelp("W", "stop with a chosen timeout"),
+	),
+	Policy: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "restart policy"),
 	),
 	Remove: key.NewBinding(
 		key.WithKeys("delete"),
 		key.WithHelp("delete", "remove"),
 	),
+	Env: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "env vs image"),
+	),
+	Top: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "processes"),
+	),
+	Diff: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "filesystem changes"),
+	),
+	Commit: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "commit to image"),
+	),
+	Limits: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "resource limits"),
+	),
+	Clone: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "clone"),
+	),
+	New: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "new container"),
+	),
+	Rename: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "rename"),
+	),
+
+	// Search actions
+	Search: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "search"),
+	),
+	NextMatch: key.NewBinding(
+		key.WithKeys("n"),
+		key.WithHelp("n", "next match"),
+	),
+	PrevMatch: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "previous match"),
+	),
+	MatchCase: key.NewBinding(
+		key.WithKeys("I"),
+		key.WithHelp("I", "toggle case-sensitive search"),
+	),
+	WrapLines: key.NewBinding(
+		key.WithKeys("ctrl+w"),
+		key.WithHelp("ctrl+w", "toggle line wrap"),
+	),
+	Highlight: key.NewBinding(
+		key.WithKeys("ctrl+g"),
+		key.WithHelp("ctrl+g", "toggle JSON colors"),
+	),
+
+	// Inspect tree actions
+	Fold: key.NewBinding(
+		key.WithKeys("enter", " "),
+		key.WithHelp("enter", "expand/collapse"),
+	),
+	RawJSON: key.NewBinding(
+		key.WithKeys("J"),
+		key.WithHelp("J", "toggle raw JSON"),
+	),
+
+	// Log actions
+	LogGrep: key.NewBinding(
+		key.WithKeys("g"),
+		key.WithHelp("g", "grep with context"),
+	),
+	MoreContext: key.NewBinding(
+		key.WithKeys("+", "="),
+		key.WithHelp("+", "more context"),
+	),
+	LessContext: key.NewBinding(
+		key.WithKeys("-"),
+		key.WithHelp("-", "less context"),
+	),
+	DownloadLogs: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "download full logs"),
+	),
+	FollowLogs: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "pause/resume following"),
+	),
+	CycleLogTail: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "cycle history size"),
+	),
+	LogStreams: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "cycle stdout/stderr"),
+	),
+	Timestamps: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "cycle timestamps"),
+	),
+	LogWindow: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "time window"),
+	),
+	SoloService: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "show one service"),
+	),
+
+	// Image actions
+	PullImage: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "pull image"),
+	),
+	TagImage: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "tag image"),
+	),
+	PushImage: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "push image"),
+	),
+	Layers: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "layers"),
+	),
+	Expand: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "expand layer command"),
+	),
+	SearchHub: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "search Docker Hub"),
+	),
+	Login: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "log in to a registry"),
+	),
+	SortSize: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "sort by size"),
+	),
 
 	// Compose actions
 	ComposeUp: key.NewBinding(
@@ -319,45 +657,123 @@ var DefaultFullKeyMap = FullKeyMap{
 		key.WithKeys("p"),
 		key.WithHelp("p", "pull"),
 	),
+	ComposeTail: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "tail all services"),
+	),
+	ServiceTail: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "tail service replicas"),
+	),
+	PrevService: key.NewBinding(
+		key.WithKeys("["),
+		key.WithHelp("[", "previous service"),
+	),
+	NextService: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "next service"),
+	),
+	ComposeScale: key.NewBinding(
+		key.WithKeys("+"),
+		key.WithHelp("+", "scale service"),
+	),
+	ComposeExec: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "shell into service"),
+	),
+	ComposeOverrides: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "toggle override files"),
+	),
+	ComposeView: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "switch services/config/ps"),
+	),
+	ComposeServiceLogs: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "service logs"),
+	),
+	RemoveOrphans: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "remove orphaned compose containers"),
+	),
+	ComposeSearchPath: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "add compose search path"),
+	),
+	ComposeContainer: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "jump to a container"),
+	),
+
+	// Volume actions
+	CreateVolume: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "create volume"),
+	),
+
+	// Network actions
+	CreateNetwork: key.NewBinding(
+		key.WithKeys("c"),
+		key.WithHelp("c", "create network"),
+	),
+	ConnectNetwork: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "connect a container"),
+	),
+	DisconnectNetwork: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "disconnect the selected container"),
+	),
 }
 
 // NewFullModel creates a new model for Docker Tea
-func NewFullModel(dockerService *docker.Service, config *config.Config, ctx context.Context) FullModel {
+func NewFullModel(dockerService *docker.Service, cfg *config.Config, ctx context.Context) FullModel {
+	styles := views.NewStyles(cfg.Theme)
+
 	// Initialize spinner
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	s.Style = lipgloss.NewStyle().Foreground(styles.Special)
 
 	m := FullModel{
-		config:            config,
+		config:            cfg,
+		state:             config.LoadState(),
 		docker:            dockerService,
 		ctx:               ctx,
+		styles:            styles,
 		loading:           true,
-		dockerConnected:   true, // Assume connected, we'll check immediately
+		dockerConnected:   false, // Until the first Ping says otherwise
 		statusMsg:         "Initializing...",
 		currentTab:        ContainersTab,
 		currentMode:       ListMode,
 		viewport:          viewport.New(0, 0),
 		spinner:           s,
 		composeContainers: []docker.ContainerInfo{},
+		logGrepContext:    defaultGrepContext,
+		listFilter:        make(map[Tab]string),
+		rowTargets:        make(map[Tab][]rowTarget),
+		stops:             newIntentionalStops(),
+		lineWrap:          defaultLineWrap(),
+		autoRefresh:       true,
+		logTail:           cfg.LogTailLines,
 	}
 
+	views.SetSizeUnits(cfg.SizeUnits)
+	views.SetStyles(styles)
+	m.restoreSession()
+
 	return m
 }
 
 // Init initializes the model
 func (m FullModel) Init() tea.Cmd {
-	// Start loading data
+	// The resources are loaded once Docker answers the connection check
 	cmds := []tea.Cmd{
 		m.checkDockerConnection,
-		m.fetchContainers,
-		m.fetchImages,
-		m.fetchVolumes,
-		m.fetchNetworks,
-		m.fetchComposeProjects,
-		func() tea.Msg {
-			return m.fetchSystemInfo()
-		},
+		m.fetchDockerContexts(false),
+		usageTick(time.Second), // sample once the containers have loaded
+		autoRefreshTick(m.config.RefreshInterval),
 	}
 	return tea.Batch(cmds...)
 }
@@ -394,6 +810,10 @@ func (m FullModel) fetchContainers() tea.Msg {
 
 // fetchImages fetches image data from Docker
 func (m FullModel) fetchImages() tea.Msg {
+	if !m.dockerConnected {
+		return fullImagesMsg{images: []docker.ImageInfo{}}
+	}
+
 	m.statusMsg = "Fetching images..."
 	images, err := m.docker.ListImages(m.ctx)
 	if err != nil {
@@ -404,6 +824,10 @@ func (m FullModel) fetchImages() tea.Msg {
 
 // fetchVolumes fetches volume data from Docker
 func (m FullModel) fetchVolumes() tea.Msg {
+	if !m.dockerConnected {
+		return fullVolumesMsg{volumes: []docker.VolumeInfo{}}
+	}
+
 	m.statusMsg = "Fetching volumes..."
 	volumes, err := m.docker.ListVolumes(m.ctx)
 	if err != nil {
@@ -414,6 +838,10 @@ func (m FullModel) fetchVolumes() tea.Msg {
 
 // fetchNetworks fetches network data from Docker
 func (m FullModel) fetchNetworks() tea.Msg {
+	if !m.dockerConnected {
+		return fullNetworksMsg{networks: []docker.NetworkInfo{}}
+	}
+
 	m.statusMsg = "Fetching networks..."
 	networks, err := m.docker.ListNetworks(m.ctx)
 	if err != nil {
@@ -438,76 +866,8 @@ func (m FullModel) fetchComposeProjects() tea.Msg {
 	return composeProjectsMsg{projects: projects}
 }
 
-// fetchLogs fetches logs for a container
-func (m FullModel) fetchLogs() tea.Msg {
-	if m.selectedID == "" {
-		return fullLogsMsg{"No container selected"}
-	}
-	m.statusMsg = "Fetching logs..."
-	logs, err := m.docker.GetContainerLogs(m.ctx, m.selectedID)
-	if err != nil {
-		return fullErrMsg{err}
-	}
-	return fullLogsMsg{logs}
-}
-
-// fetchStats fetches monitoring statistics for a container
-func (m FullModel) fetchStats() tea.Msg {
-	if m.selectedID == "" {
-		return fullStatsMsg{"No container selected"}
-	}
-
-	m.statusMsg = "Fetching container stats..."
-	stats, err := m.docker.GetProcessedStats(m.ctx, m.selectedID)
-	if err != nil {
-		return fullErrMsg{err}
-	}
-
-	var sb strings.Builder
-
-	// Format CPU usage with bar
-	cpuBar := createUsageBar(stats.CPUPercentage, 50)
-
-	// Format memory usage with bar
-	memBar := createUsageBar(stats.MemoryPercentage, 50)
-
-	// Create header
-	headerStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("#5f87ff"))
-
-	// CPU section
-	sb.WriteString(headerStyle.Render("CPU Usage:"))
-	sb.WriteString(fmt.Sprintf("\n%.2f%%\n", stats.CPUPercentage))
-	sb.WriteString(cpuBar)
-	sb.WriteString("\n\n")
-
-	// Memory section
-	sb.WriteString(headerStyle.Render("Memory Usage:"))
-	sb.WriteString(fmt.Sprintf("\n%.2f%% (%s / %s)\n",
-		stats.MemoryPercentage,
-		formatBytes(stats.MemoryUsage),
-		formatBytes(stats.MemoryLimit)))
-	sb.WriteString(memBar)
-	sb.WriteString("\n\n")
-
-	// Network I/O
-	sb.WriteString(headerStyle.Render("Network I/O:"))
-	sb.WriteString(fmt.Sprintf("\n📥 RX: %s / 📤 TX: %s\n\n",
-		formatBytes(stats.NetworkRx),
-		formatBytes(stats.NetworkTx)))
-
-	// Block I/O
-	sb.WriteString(headerStyle.Render("Block I/O:"))
-	sb.WriteString(fmt.Sprintf("\n📄 Read: %s / 📝 Write: %s\n",
-		formatBytes(stats.BlockRead),
-		formatBytes(stats.BlockWrite)))
-
-	return fullStatsMsg{sb.String()}
-}
-
 // createUsageBar creates a text-based usage bar
-func createUsageBar(percentage float64, width int) string {
+func (m FullModel) createUsageBar(percentage float64, width int) string {
 	filled := int((percentage / 100.0) * float64(width))
 	if filled > width {
 		filled = width
@@ -517,19 +877,19 @@ func createUsageBar(percentage float64, width int) string {
 	var barColor lipgloss.Color
 	var icon string
 	if percentage < 60 {
-		barColor = lipgloss.Color("#4CAF50") // Green
+		barColor = m.styles.Success
 		icon = "🟩 "
 	} else if percentage < 85 {
-		barColor = lipgloss.Color("#FFC107") // Yellow
+		barColor = m.styles.Warning
 		icon = "🟨 "
 	} else {
-		barColor = lipgloss.Color("#F44336") // Red
+		barColor = m.styles.Error
 		icon = "🟥 "
 	}
 
 	// Create filled and empty segments with proper styling
 	filledStyle := lipgloss.NewStyle().Foreground(barColor)
-	emptyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#333333"))
+	emptyStyle := lipgloss.NewStyle().Foreground(m.styles.Border)
 
 	filledBar := filledStyle.Render(strings.Repeat("█", filled))
 	emptyBar := emptyStyle.Render(strings.Repeat("░", width-filled))
@@ -542,18 +902,6 @@ func createUsageBar(percentage float64, width int) string {
 		percentage)
 }
 
-// startStatsRefresh starts a ticker to refresh container stats
-func (m FullModel) startStatsRefresh() tea.Cmd {
-	return tea.Tick(time.Second*2, func(t time.Time) tea.Msg {
-		return tickMsg{}
-	})
-}
-
-// stopStatsRefresh stops the stats refresh ticker
-func (m FullModel) stopStatsRefresh() tea.Cmd {
-	return tea.Batch()
-}
-
 // inspectResource fetches details for a resource
 func (m FullModel) inspectResource() tea.Msg {
 	if m.selectedID == "" {
@@ -581,6 +929,30 @@ func (m FullModel) inspectResource() tea.Msg {
 	return fullInspectMsg{details}
 }
 
+// fetchContainerEnv compares the selected container's environment with its image defaults
+func (m FullModel) fetchContainerEnv() tea.Msg {
+	imageID := views.ContainerImageID(m.inspectContent)
+	if imageID == "" {
+		return fullErrMsg{fmt.Errorf("unable to determine the image of %s", m.selectedName)}
+	}
+
+	imageContent, err := m.docker.InspectImage(m.ctx, imageID)
+	if err != nil {
+		return fullErrMsg{err}
+	}
+
+	return containerEnvMsg{views.EnvComparison(m.inspectContent, imageContent)}
+}
+
+// fetchContainerDiff lists the filesystem changes of the selected container
+func (m FullModel) fetchContainerDiff() tea.Msg {
+	changes, err := m.docker.ContainerDiff(m.ctx, m.selectedID)
+	if err != nil {
+		return fullErrMsg{err}
+	}
+	return containerDiffMsg{content: views.FilesystemDiff(changes), changes: len(changes)}
+}
+
 // inspectComposeProject fetches details for a Docker Compose project
 func (m *FullModel) inspectComposeProject() tea.Msg {
 	if m.selectedPath == "" {
@@ -681,21 +1053,19 @@ func (m FullModel) composeAction(action string) tea.Cmd {
 		m.statusMsg = fmt.Sprintf("Performing %s on %s...", action, m.selectedName)
 		var err error
 
+		if action == "down" || action == "restart" {
+			m.stops.record(composeStopKey(m.selectedID, ""))
+		}
+
 		switch action {
 		case "up":
 			err = m.docker.ComposeUp(m.ctx, m.selectedPath)
 		case "down":
 			err = m.docker.ComposeDown(m.ctx, m.selectedPath)
+		case "restart":
+			err = m.docker.ComposeRestart(m.ctx, m.selectedPath)
 		case "pull":
 			err = m.docker.ComposePull(m.ctx, m.selectedPath)
-		case "logs":
-			// For logs, we need to fetch and format them
-			logs, logErr := m.docker.ComposeLogs(m.ctx, m.selectedPath)
-			if logErr != nil {
-				err = logErr
-			} else {
-				return fullLogsMsg{logs}
-			}
 		}
 
 		if err != nil {
@@ -720,23 +1090,32 @@ func (m FullModel) containerAction(action string) tea.Cmd {
 		m.statusMsg = fmt.Sprintf("Performing %s on %s...", action, m.selectedName)
 		var err error
 
+		timeout := m.config.StopTimeoutSeconds
+		switch action {
+		case "stop", "restart":
+			m.stops.recordFor(containerStopKey(m.selectedID), time.Duration(timeout)*time.Second)
+		case "remove":
+			m.stops.record(containerStopKey(m.selectedID))
+		}
+
 		switch action {
 		case "start":
 			err = m.docker.StartContainer(m.ctx, m.selectedID)
 		case "stop":
-			err = m.docker.StopContainer(m.ctx, m.selectedID)
+			err = m.docker.StopContainer(m.ctx, m.selectedID, timeout)
 		case "restart":
-			err = m.docker.RestartContainer(m.ctx, m.selectedID)
+			err = m.docker.RestartContainer(m.ctx, m.selectedID, timeout)
 		case "pause":
 			err = m.docker.PauseContainer(m.ctx, m.selectedID)
 		case "unpause":
 			err = m.docker.UnpauseContainer(m.ctx, m.selectedID)
-		case "kill":
-			err = m.docker.KillContainer(m.ctx, m.selectedID)
 		case "remove":
-			err = m.docker.RemoveContainer(m.ctx, m.selectedID)
+			err = m.docker.RemoveContainer(m.ctx, m.selectedID, false)
 		}
 
+		if errors.Is(err, docker.ErrContainerRunning) {
+			return containerRunningMsg{id: m.selectedID, name: m.selectedName}
+		}
 		if err != nil {
 			return fullActionResultMsg{success: false, message: err.Error()}
 		}
@@ -788,7 +1167,7 @@ func (m FullModel) volumeAction(action string) tea.Cmd {
 
 		switch action {
 		case "remove":
-			err = m.docker.RemoveVolume(m.ctx, m.selectedID, true)
+			err = m.docker.RemoveVolume(m.ctx, m.selectedID, false)
 		}
 
 		if err != nil {
@@ -832,94 +1211,42 @@ func (m FullModel) networkAction(action string) tea.Cmd {
 
 // initializeTable creates a table for a specific resource type
 func (m *FullModel) initializeTable(resourceType Tab) table.Model {
-	var columns []table.Column
-
-	switch resourceType {
-	case ContainersTab:
-		columns = []table.Column{
-			{Title: "NAME", Width: 20},
-			{Title: "STATUS", Width: 15},
-			{Title: "IMAGE", Width: 30},
-			{Title: "ID", Width: 15},
-		}
-	case ImagesTab:
-		columns = []table.Column{
-			{Title: "REPOSITORY", Width: 40},
-			{Title: "SIZE", Width: 15},
-			{Title: "ID", Width: 20},
-		}
-	case VolumesTab:
-		columns = []table.Column{
-			{Title: "NAME", Width: 30},
-			{Title: "DRIVER", Width: 15},
-			{Title: "MOUNTPOINT", Width: 35},
-		}
-	case NetworksTab:
-		columns = []table.Column{
-			{Title: "NAME", Width: 30},
-			{Title: "DRIVER", Width: 15},
-			{Title: "SCOPE", Width: 15},
-			{Title: "ID", Width: 20},
-		}
-	case ComposeTab:
-		columns = []table.Column{
-			{Title: "NAME", Width: 25},
-			{Title: "STATUS", Width: 15},
-			{Title: "PATH", Width: 40},
-		}
-	}
-
 	t := table.New(
-		table.WithColumns(columns),
+		table.WithColumns(tableColumns(resourceType, m.width)),
 		table.WithHeight(m.height-12),
 		table.WithWidth(m.width),
 		table.WithFocused(true),
 	)
+	t.SetStyles(m.tableStyles())
+
+	return t
+}
 
-	// Set table styles
+// tableStyles returns the styles shared by all tables
+func (m FullModel) tableStyles() table.Styles {
 	s := table.DefaultStyles()
 	s.Header = s.Header.
 		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("240")).
+		BorderForeground(m.styles.Border).
 		BorderBottom(true).
 		Bold(true)
 	s.Selected = s.Selected.
-		Foreground(lipgloss.Color("229")).
-		Background(lipgloss.Color("57")).
+		Foreground(m.styles.SelectedText).
+		Background(m.styles.SelectedBackground).
 		Bold(true)
-	t.SetStyles(s)
-
-	return t
+	return s
 }
 
 // updateTables updates dimensions for all tables
 func (m *FullModel) updateTables() {
 	height := m.height - 12 // Adjust for header, footer, etc.
 
-	if m.containerTable.Height() != height {
-		m.containerTable.SetHeight(height)
-		m.containerTable.SetWidth(m.width)
-	}
-
-	if m.imageTable.Height() != height {
-		m.imageTable.SetHeight(height)
-		m.imageTable.SetWidth(m.width)
-	}
-
-	if m.volumeTable.Height() != height {
-		m.volumeTable.SetHeight(height)
-		m.volumeTable.SetWidth(m.width)
-	}
-
-	if m.networkTable.Height() != height {
-		m.networkTable.SetHeight(height)
-		m.networkTable.SetWidth(m.width)
-	}
-
-	if m.composeTable.Height() != height {
-		m.composeTable.SetHeight(height)
-		m.composeTable.SetWidth(m.width)
-	}
+	// The container and image lists have a summary line under them
+	m.resizeTable(&m.containerTable, ContainersTab, height-1)
+	m.resizeTable(&m.imageTable, ImagesTab, height-1)
+	m.resizeTable(&m.volumeTable, VolumesTab, height)
+	m.resizeTable(&m.networkTable, NetworksTab, height)
+	m.resizeTable(&m.composeTable, ComposeTab, height)
 
 	// Set viewport height based on current mode
 	var viewportHeight int
@@ -933,7 +1260,127 @@ func (m *FullModel) updateTables() {
 
 	if m.viewport.Height != viewportHeight {
 		m.viewport.Height = viewportHeight
-		m.viewport.Width = m.width
+	}
+	m.viewport.Width = m.contentWidth()
+}
+
+// contentWidth returns the width of the details viewport, capped by MaxContentWidth
+func (m FullModel) contentWidth() int {
+	if m.config.MaxContentWidth > 0 && m.width > m.config.MaxContentWidth {
+		return m.config.MaxContentWidth
+	}
+	return m.width
+}
+
+// renderViewport renders the details viewport, centered when its width is capped
+func (m FullModel) renderViewport() string {
+	if m.viewport.Width < m.width {
+		return lipgloss.PlaceHorizontal(m.width, lipgloss.Center, m.viewport.View())
+	}
+	return m.viewport.View()
+}
+
+// rowTarget is the resource shown in a table row, which actions apply to
+type rowTarget struct {
+	id, name, path string
+}
+
+// refreshRows rebuilds a tab's table rows from its resources, applying the tab's filter
+func (m *FullModel) refreshRows(tab Tab) {
+	rows := []table.Row{}
+	var targets []rowTarget
+
+	switch tab {
+	case ContainersTab:
+		for _, c := range m.visibleContainers() {
+			// Add status icon based on container state
+			statusWithIcon := c.State
+			switch {
+			case strings.Contains(strings.ToLower(c.State), "running"):
+				statusWithIcon = IconRunning + c.State
+			case strings.Contains(strings.ToLower(c.State), "exited"):
+				statusWithIcon = IconExited + c.State
+			case strings.Contains(strings.ToLower(c.State), "created"):
+				statusWithIcon = IconCreated + c.State
+			case strings.Contains(strings.ToLower(c.State), "paused"):
+				statusWithIcon = IconPaused + c.State
+			case strings.Contains(strings.ToLower(c.State), "restarting"):
+				statusWithIcon = IconRestarting + c.State
+			case strings.Contains(strings.ToLower(c.State), "dead"):
+				statusWithIcon = IconDead + c.State
+			}
+			switch c.Health {
+			case "healthy":
+				statusWithIcon += " " + IconHealthy
+			case "unhealthy":
+				statusWithIcon += " " + IconUnhealthy
+			case "starting":
+				statusWithIcon += " " + IconHealthStarting
+			}
+
+			name := c.Name
+			if m.orphans[c.ID] {
+				name += " ⚠ orphaned"
+			}
+
+			row := table.Row{name, statusWithIcon, c.Image, portsCell(c.Ports), uptimeCell(c), docker.ShortID(c.ID, 12)}
+			rows = append(rows, row)
+			targets = append(targets, rowTarget{id: c.ID, name: c.Name})
+		}
+		m.containerTable.SetRows(rows)
+
+	case ImagesTab:
+		for _, img := range m.visibleImages() {
+			repoTag := "<none>:<none>"
+			if len(img.RepoTags) > 0 {
+				repoTag = img.RepoTags[0]
+			}
+
+			// Format size
+			size := formatBytes(img.Size)
+
+			row := table.Row{repoTag, size, docker.ShortID(img.ID, 12)}
+			rows = append(rows, row)
+			name := ""
+			if len(img.RepoTags) > 0 {
+				name = img.RepoTags[0]
+			}
+			targets = append(targets, rowTarget{id: img.ID, name: name})
+		}
+		m.imageTable.SetRows(rows)
+
+	case VolumesTab:
+		for _, v := range m.visibleVolumes() {
+			row := table.Row{v.Name, v.Driver, v.Mountpoint}
+			rows = append(rows, row)
+			targets = append(targets, rowTarget{id: v.Name, name: v.Name})
+		}
+		m.volumeTable.SetRows(rows)
+
+	case NetworksTab:
+		for _, n := range m.visibleNetworks() {
+			row := table.Row{n.Name, n.Driver, n.Scope, docker.ShortID(n.ID, 12)}
+			rows = append(rows, row)
+			targets = append(targets, rowTarget{id: n.ID, name: n.Name})
+		}
+		m.networkTable.SetRows(rows)
+
+	case ComposeTab:
+		for _, p := range m.visibleComposeProjects() {
+			row := table.Row{p.Name, p.Status, p.Path}
+			rows = append(rows, row)
+			targets = append(targets, rowTarget{id: p.Name, name: p.Name, path: p.Path})
+		}
+		m.composeTable.SetRows(rows)
+	}
+	m.rowTargets[tab] = targets
+
+	// Give actions a target right away instead of waiting for the first key press
+	if m.config.AutoSelectFirstRow && tab == m.currentTab && m.currentMode == ListMode {
+		if t := m.getCurrentTable(); t.Cursor() >= len(rows) || t.Cursor() < 0 {
+			t.SetCursor(0)
+		}
+		m.updateSelection()
 	}
 }
 
@@ -958,75 +1405,46 @@ func (m *FullModel) getCurrentTable() *table.Model {
 // updateSelection updates the selected resource based on the current table cursor
 func (m *FullModel) updateSelection() {
 	table := m.getCurrentTable()
-	selectedRow := table.SelectedRow()
+	if m.currentTab == EventsTab {
+		m.selectedID = ""
+		m.selectedName = ""
+		m.selectedPath = ""
+		return
+	}
 
-	if len(selectedRow) == 0 {
+	// The rows' resources were recorded when the rows were built, so moving
+	// the cursor doesn't filter the whole list again
+	targets := m.rowTargets[m.currentTab]
+	cursor := table.Cursor()
+	if cursor < 0 || cursor >= len(targets) {
 		m.selectedID = ""
 		m.selectedName = ""
 		m.selectedPath = ""
 		return
 	}
 
-	switch m.currentTab {
-	case ContainersTab:
-		if len(m.containers) > 0 && table.Cursor() < len(m.containers) {
-			m.selectedID = m.containers[table.Cursor()].ID
-			m.selectedName = m.containers[table.Cursor()].Name
-		}
+	target := targets[cursor]
+	m.selectedID = target.id
+	m.selectedName = target.name
+	m.selectedPath = target.path
 
-	case ImagesTab:
-		if len(m.images) > 0 && table.Cursor() < len(m.images) {
-			m.selectedID = m.images[table.Cursor()].ID
-			m.selectedName = ""
-			if len(m.images[table.Cursor()].RepoTags) > 0 {
-				m.selectedName = m.images[table.Cursor()].RepoTags[0]
+	// If a compose project's path is empty, try to search for it by name
+	if m.currentTab == ComposeTab && m.selectedPath == "" && m.selectedID != "" {
+		for _, p := range m.composeProjects {
+			if p.Name == m.selectedID {
+				m.selectedPath = p.Path
+				m.statusMsg = fmt.Sprintf("Found project path: %s", m.selectedPath)
+				break
 			}
 		}
 
-	case VolumesTab:
-		if len(m.volumes) > 0 && table.Cursor() < len(m.volumes) {
-			m.selectedID = m.volumes[table.Cursor()].Name
-			m.selectedName = m.volumes[table.Cursor()].Name
-		}
-
-	case NetworksTab:
-		if len(m.networks) > 0 && table.Cursor() < len(m.networks) {
-			m.selectedID = m.networks[table.Cursor()].ID
-			m.selectedName = m.networks[table.Cursor()].Name
-		}
-
-	case ComposeTab:
-		if len(m.composeProjects) > 0 && table.Cursor() < len(m.composeProjects) {
-			cursorIndex := table.Cursor()
-			if cursorIndex >= len(m.composeProjects) {
-				// Stay safe
-				cursorIndex = 0
-			}
-
-			selectedProject := m.composeProjects[cursorIndex]
-			m.selectedID = selectedProject.Name
-			m.selectedName = selectedProject.Name
-			m.selectedPath = selectedProject.Path
-
-			// If path is empty, try to search for it by name
-			if m.selectedPath == "" && m.selectedID != "" {
-				for _, p := range m.composeProjects {
-					if p.Name == m.selectedID {
-						m.selectedPath = p.Path
-						m.statusMsg = fmt.Sprintf("Found project path: %s", m.selectedPath)
-						break
-					}
-				}
-
-				// If still no path, check if there are any projects with paths at all
-				if m.selectedPath == "" {
-					for _, p := range m.composeProjects {
-						if p.Path != "" {
-							m.selectedPath = p.Path
-							m.statusMsg = fmt.Sprintf("Using fallback path from project %s: %s", p.Name, p.Path)
-							break
-						}
-					}
+		// If still no path, check if there are any projects with paths at all
+		if m.selectedPath == "" {
+			for _, p := range m.composeProjects {
+				if p.Path != "" {
+					m.selectedPath = p.Path
+					m.statusMsg = fmt.Sprintf("Using fallback path from project %s: %s", p.Name, p.Path)
+					break
 				}
 			}
 		}
@@ -1040,22 +1458,122 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// The picker overlay and the prompt capture all keys while they're open
+		if m.picker.active {
+			cmd = m.handlePickerKey(msg)
+			return m, cmd
+		}
+		if m.prompt.active {
+			cmd = m.handlePromptKey(msg)
+			return m, cmd
+		}
+		if m.confirm.active {
+			cmd = m.handleConfirmKey(msg)
+			return m, cmd
+		}
+		if m.history.open {
+			cmd = m.handleHistoryKey(msg)
+			return m, cmd
+		}
+		if m.diskUsage.open {
+			cmd = m.handleDiskUsageKey(msg)
+			return m, cmd
+		}
+		if m.daemonInfo.open {
+			cmd = m.handleDaemonInfoKey(msg)
+			return m, cmd
+		}
+		if m.dashboard.open {
+			cmd = m.handleDashboardKey(msg)
+			return m, cmd
+		}
+		if m.createForm.active {
+			cmd = m.handleCreateFormKey(msg)
+			return m, cmd
+		}
+		if m.resourceForm.active {
+			cmd = m.handleResourceFormKey(msg)
+			return m, cmd
+		}
+		if m.imageTransfer != nil {
+			cmd = m.handleImageTransferKey(msg)
+			return m, cmd
+		}
+
 		// Handle global key bindings
 		switch {
 		case key.Matches(msg, DefaultFullKeyMap.Quit):
-			m.statusMsg = "Quitting..."
-			return m, tea.Quit
+			return m, m.quit()
 
 		case key.Matches(msg, DefaultFullKeyMap.Help):
 			m.showHelp = !m.showHelp
 			return m, nil
 
+		case key.Matches(msg, DefaultFullKeyMap.SwitchContext):
+			m.statusMsg = "Loading Docker contexts..."
+			return m, m.fetchDockerContexts(true)
+
+		case key.Matches(msg, DefaultFullKeyMap.SwitchTheme):
+			m.pickTheme()
+			return m, nil
+
+		case key.Matches(msg, DefaultFullKeyMap.HardRefresh):
+			m.statusMsg = "Reinitializing Docker connection..."
+			return m, m.reinitialize
+
+		case key.Matches(msg, DefaultFullKeyMap.AutoRefresh):
+			m.toggleAutoRefresh()
+			return m, nil
+
+		case key.Matches(msg, DefaultFullKeyMap.History):
+			m.toggleHistory()
+			return m, nil
+
+		case key.Matches(msg, DefaultFullKeyMap.DiskUsage):
+			cmd = m.toggleDiskUsage()
+			return m, cmd
+
+		case key.Matches(msg, DefaultFullKeyMap.SystemInfo):
+			cmd = m.toggleDaemonInfo()
+			return m, cmd
+
+		case key.Matches(msg, DefaultFullKeyMap.PruneAll):
+			cmd = m.pruneEverything()
+			return m, cmd
+
+		case key.Matches(msg, DefaultFullKeyMap.Dashboard):
+			cmd = m.toggleDashboard()
+			return m, cmd
+
+		case key.Matches(msg, DefaultFullKeyMap.OpenAppLog):
+			cmd = m.openAppLog()
+			return m, cmd
+
+		case key.Matches(msg, DefaultFullKeyMap.OpenLogExport):
+			cmd = m.openLastLogExport()
+			return m, cmd
+
+		case key.Matches(msg, DefaultFullKeyMap.ReloadConfig):
+			m.statusMsg = "Reloading config..."
+			return m, reloadConfig
+
 		case key.Matches(msg, DefaultFullKeyMap.Refresh):
 			if m.currentMode == MonitorMode {
-				return m, m.fetchStats
+				return m, m.startMonitoring()
 			}
 
 			if m.currentMode == InspectMode {
+				switch m.inspectView {
+				case inspectViewTop:
+					return m, m.fetchContainerTop
+				case inspectViewDiff:
+					return m, m.fetchContainerDiff
+				case inspectViewLayers:
+					return m, m.fetchImageLayers
+				case inspectViewConfig, inspectViewPs:
+					cmd = m.fetchComposeView(m.inspectView)
+					return m, cmd
+				}
 				// Refresh the inspection
 				if m.currentTab == ComposeTab {
 					return m, m.inspectComposeProject
@@ -1075,7 +1593,7 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, DefaultFullKeyMap.NextTab):
 			if m.currentMode == ListMode {
 				prevTab := m.currentTab
-				m.currentTab = (m.currentTab + 1) % 5 // Cycle through the 5 tabs (including Compose)
+				m.currentTab = (m.currentTab + 1) % tabCount
 
 				// If we're switching to a different tab, ensure data is refreshed
 				if prevTab != m.currentTab {
@@ -1090,6 +1608,10 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						return m, m.fetchNetworks
 					case ComposeTab:
 						return m, m.fetchComposeProjects
+					case EventsTab:
+						// Nothing can be selected, so actions can't hit the previous tab's resource
+						m.updateSelection()
+						return m, nil
 					}
 				}
 
@@ -1119,7 +1641,7 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, DefaultFullKeyMap.PrevTab):
 			if m.currentMode == ListMode {
 				prevTab := m.currentTab
-				m.currentTab = (m.currentTab - 1 + 5) % 5 // Cycle through the 5 tabs (including Compose)
+				m.currentTab = (m.currentTab - 1 + tabCount) % tabCount
 
 				// If we're switching to a different tab, ensure data is refreshed
 				if prevTab != m.currentTab {
@@ -1134,6 +1656,10 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						return m, m.fetchNetworks
 					case ComposeTab:
 						return m, m.fetchComposeProjects
+					case EventsTab:
+						// Nothing can be selected, so actions can't hit the previous tab's resource
+						m.updateSelection()
+						return m, nil
 					}
 				}
 
@@ -1148,10 +1674,26 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case key.Matches(msg, DefaultFullKeyMap.Back):
+			// Esc cancels a running log download before leaving the view
+			if m.logDownload != nil {
+				m.cancelLogDownload()
+				return m, nil
+			}
 			if m.currentMode == MonitorMode {
-				// Stop stats refresh when leaving monitor mode
+				// Stop streaming stats when leaving monitor mode
+				m.stopMonitoring()
 				m.currentMode = ListMode
-				return m, m.stopStatsRefresh()
+				return m, nil
+			}
+			if m.currentMode == LogsMode {
+				m.logGrep = ""
+				m.stopComposeTail()
+				m.stopLogFollow()
+			}
+			m.search = viewportSearch{}
+			if m.currentMode == ListMode && m.listFilter[m.currentTab] != "" {
+				m.setListFilter(m.currentTab, "")
+				return m, nil
 			}
 			if m.currentMode != ListMode {
 				m.currentMode = ListMode
@@ -1159,8 +1701,53 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Search within the inspect and logs viewports
+		if m.currentMode == InspectMode || m.currentMode == LogsMode {
+			switch {
+			case key.Matches(msg, DefaultFullKeyMap.Search):
+				cmd = m.openPrompt("/", m.search.query, func(m *FullModel, value string) tea.Cmd {
+					m.startSearch(strings.TrimSpace(value))
+					return nil
+				})
+				return m, cmd
+			case key.Matches(msg, DefaultFullKeyMap.NextMatch):
+				m.nextMatch(1)
+				return m, nil
+			case key.Matches(msg, DefaultFullKeyMap.PrevMatch):
+				m.nextMatch(-1)
+				return m, nil
+			case key.Matches(msg, DefaultFullKeyMap.Copy):
+				return m, m.copyViewContent()
+			case key.Matches(msg, DefaultFullKeyMap.MatchCase):
+				m.toggleSearchCase()
+				return m, nil
+			case key.Matches(msg, DefaultFullKeyMap.WrapLines):
+				m.toggleLineWrap()
+				return m, nil
+			case key.Matches(msg, DefaultFullKeyMap.Highlight) && m.currentMode == InspectMode:
+				m.toggleSyntaxHighlight()
+				return m, nil
+			case key.Matches(msg, DefaultFullKeyMap.RawJSON) && m.currentMode == InspectMode:
+				m.toggleRawInspect()
+				return m, nil
+			}
+		}
+
 		// Handle action keys in ListMode
 		if m.currentMode == ListMode {
+			if key.Matches(msg, DefaultFullKeyMap.Filter) {
+				m.cycleTabFilter()
+				return m, nil
+			}
+			if key.Matches(msg, DefaultFullKeyMap.Search) {
+				cmd = m.openListFilter()
+				return m, cmd
+			}
+			if m.currentTab == EventsTab {
+				cmd = m.handleEventLogKey(msg)
+				return m, cmd
+			}
+
 			// Update selection before performing actions
 			m.updateSelection()
 
@@ -1173,6 +1760,15 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, m.composeAction("down")
 				case key.Matches(msg, DefaultFullKeyMap.ComposePull):
 					return m, m.composeAction("pull")
+				case key.Matches(msg, DefaultFullKeyMap.Restart):
+					m.statusMsg = fmt.Sprintf("Restarting %s...", m.selectedName)
+					return m, m.composeAction("restart")
+				case key.Matches(msg, DefaultFullKeyMap.ComposeTail):
+					m.statusMsg = fmt.Sprintf("Finding containers of %s...", m.selectedName)
+					return m, m.fetchComposeTailContainers
+				case key.Matches(msg, DefaultFullKeyMap.ComposeSearchPath):
+					cmd = m.promptComposeSearchPath()
+					return m, cmd
 				}
 			}
 
@@ -1181,9 +1777,12 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case key.Matches(msg, DefaultFullKeyMap.Inspect):
 				if m.selectedID != "" {
 					m.currentMode = InspectMode
+					m.inspectView = inspectViewDefault
+					m.networkContainerCursor = 0
 					if m.currentTab == ComposeTab {
 						// Force update selection to ensure selectedPath is set properly
 						m.updateSelection()
+						m.composeServiceCursor = 0
 
 						// If path is still empty despite having a selected ID, try to find it in all projects
 						if m.selectedPath == "" && m.selectedID != "" && len(m.composeProjects) > 0 {
@@ -1210,7 +1809,7 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 						// Set the viewport content directly for immediate display
 						content := m.renderComposeInspect()
-						m.viewport.SetContent(content)
+						m.setViewportContent(content)
 						m.viewport.GotoTop()
 
 						// Then fetch services async
@@ -1223,21 +1822,26 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Containers and Compose projects have logs
 				if m.currentTab == ContainersTab && m.selectedID != "" {
 					m.currentMode = LogsMode
-					return m, m.fetchLogs
+					cmd = m.startLogFollow()
+					return m, cmd
 				} else if m.currentTab == ComposeTab && m.selectedPath != "" {
-					m.currentMode = LogsMode
-					return m, m.composeAction("logs")
+					cmd = m.startComposeLogs()
+					return m, cmd
 				}
 
 			case key.Matches(msg, DefaultFullKeyMap.Monitor):
 				// Only containers can be monitored
 				if m.currentTab == ContainersTab && m.selectedID != "" {
-					m.currentMode = MonitorMode
-					return m, tea.Batch(
-						m.fetchStats,
-						m.startStatsRefresh(),
-					)
+					return m, m.startMonitoring()
 				}
+
+			case key.Matches(msg, DefaultFullKeyMap.Export):
+				m.pickListExportFormat()
+				return m, nil
+
+			case key.Matches(msg, DefaultFullKeyMap.Prune):
+				cmd = m.pruneTab()
+				return m, cmd
 			}
 
 			// Handle tab-specific actions based on current tab
@@ -1255,27 +1859,82 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				case key.Matches(msg, DefaultFullKeyMap.Resume):
 					return m, m.containerAction("unpause")
 				case key.Matches(msg, DefaultFullKeyMap.Kill):
-					return m, m.containerAction("kill")
+					m.pickKillSignal()
+					return m, nil
+				case key.Matches(msg, DefaultFullKeyMap.Timeout):
+					cmd = m.promptStopTimeout()
+					return m, cmd
 				case key.Matches(msg, DefaultFullKeyMap.Remove):
 					return m, m.containerAction("remove")
+				case key.Matches(msg, DefaultFullKeyMap.Clone):
+					m.statusMsg = fmt.Sprintf("Reading configuration of %s...", m.selectedName)
+					return m, m.fetchCloneTemplate
+				case key.Matches(msg, DefaultFullKeyMap.New):
+					cmd = m.openCreateForm("New container", docker.ContainerCreateConfig{})
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.Rename):
+					cmd = m.promptRename()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.Commit):
+					cmd = m.openCommitForm()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.ServiceTail):
+					cmd = m.tailSelectedReplicas()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.RemoveOrphans):
+					cmd = m.removeOrphans()
+					return m, cmd
 				}
 			case ImagesTab:
 				switch {
 				case key.Matches(msg, DefaultFullKeyMap.Remove):
 					return m, m.imageAction("remove")
+				case key.Matches(msg, DefaultFullKeyMap.PullImage):
+					cmd = m.promptImagePull("")
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.TagImage):
+					cmd = m.promptImageTag()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.PushImage):
+					cmd = m.promptImagePush()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.Layers):
+					cmd = m.toggleImageLayers()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.SearchHub):
+					cmd = m.promptHubSearch()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.Login):
+					cmd = m.openRegistryLogin()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.SortSize):
+					m.toggleImageSort()
+					return m, nil
 				}
 			case VolumesTab:
 				switch {
 				case key.Matches(msg, DefaultFullKeyMap.Remove):
 					return m, m.volumeAction("remove")
+				case key.Matches(msg, DefaultFullKeyMap.CreateVolume):
+					cmd = m.openVolumeForm()
+					return m, cmd
 				}
 			case NetworksTab:
 				switch {
 				case key.Matches(msg, DefaultFullKeyMap.Remove):
 					return m, m.networkAction("remove")
+				case key.Matches(msg, DefaultFullKeyMap.CreateNetwork):
+					cmd = m.openNetworkForm()
+					return m, cmd
 				}
 			}
 
+			// User-defined actions from the config file
+			if action, ok := m.findCustomAction(msg); ok {
+				cmd = m.runCustomAction(action)
+				return m, cmd
+			}
+
 			// Handle navigation keys for tables
 			table := m.getCurrentTable()
 			if table.Width() > 0 {
@@ -1283,10 +1942,33 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				cmds = append(cmds, cmd)
 			}
 		} else if m.currentMode == InspectMode {
+			if m.currentTab == NetworksTab {
+				switch {
+				case key.Matches(msg, DefaultFullKeyMap.PrevService):
+					m.moveNetworkContainerCursor(-1)
+					return m, nil
+				case key.Matches(msg, DefaultFullKeyMap.NextService):
+					m.moveNetworkContainerCursor(1)
+					return m, nil
+				case key.Matches(msg, DefaultFullKeyMap.ConnectNetwork):
+					cmd = m.connectToNetwork()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.DisconnectNetwork):
+					cmd = m.disconnectFromNetwork()
+					return m, cmd
+				}
+
+				// Number keys jump to a connected container
+				if num, err := strconv.Atoi(msg.String()); err == nil && num >= 1 && num <= 9 {
+					m.jumpToNetworkContainer(num - 1)
+					return m, nil
+				}
+			}
+
 			// Similar approach in inspect mode: handle ComposeTab actions first if applicable
 			if m.currentTab == ComposeTab {
 				// Add container selection feature
-				if msg.String() == "c" {
+				if key.Matches(msg, DefaultFullKeyMap.ComposeContainer) {
 					m.statusMsg = "Enter container number (1-9):"
 					return m, nil
 				}
@@ -1307,7 +1989,7 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							m.statusMsg = fmt.Sprintf("Switching to container: %s", selectedName)
 
 							// Jump to the container tab with that container selected
-							m.jumpToContainer(selectedID)
+							m.jumpToContainer(selectedID, "")
 
 							return m, nil
 						} else if err == nil && num >= 1 && num <= 9 {
@@ -1348,29 +2030,75 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							return afterActionMsg{action: "inspect"}
 						},
 					)
+				case key.Matches(msg, DefaultFullKeyMap.ComposeTail):
+					m.statusMsg = fmt.Sprintf("Finding containers of %s...", m.selectedName)
+					return m, m.fetchComposeTailContainers
+				case key.Matches(msg, DefaultFullKeyMap.Start):
+					cmd = m.selectedComposeServiceAction("start", "Starting")
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.Stop):
+					cmd = m.selectedComposeServiceAction("stop", "Stopping")
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.Restart):
+					cmd = m.selectedComposeServiceAction("restart", "Restarting")
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.ComposeScale):
+					cmd = m.promptComposeScale()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.ComposeExec):
+					cmd = m.execIntoComposeService()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.ComposeOverrides):
+					cmd = m.pickComposeOverride()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.ComposeView):
+					cmd = m.cycleComposeView()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.ServiceTail):
+					if m.composeServiceCursor < len(m.composeServiceList) {
+						service := m.composeServiceList[m.composeServiceCursor].Name
+						m.statusMsg = fmt.Sprintf("Finding replicas of %s...", service)
+						return m, m.fetchReplicaTailContainers(m.selectedName, service)
+					}
+					m.statusMsg = "No compose service selected"
+					return m, nil
+				case key.Matches(msg, DefaultFullKeyMap.PrevService):
+					m.moveComposeServiceCursor(-1)
+					return m, nil
+				case key.Matches(msg, DefaultFullKeyMap.NextService):
+					m.moveComposeServiceCursor(1)
+					return m, nil
+				case key.Matches(msg, DefaultFullKeyMap.ComposeServiceLogs):
+					if m.composeServiceCursor < len(m.composeServiceList) {
+						service := m.composeServiceList[m.composeServiceCursor].Name
+						m.currentMode = LogsMode
+						m.statusMsg = fmt.Sprintf("Loading logs for service %s...", service)
+						return m, m.fetchComposeServiceLogs(service)
+					}
+					m.statusMsg = "No compose service selected"
+					return m, nil
 				}
 			}
 
 			// Shared actions in inspect mode
 			switch {
+			case key.Matches(msg, DefaultFullKeyMap.Export):
+				return m, m.exportInspect()
 			case key.Matches(msg, DefaultFullKeyMap.Logs):
 				// Containers and Compose projects have logs
 				if m.currentTab == ContainersTab && m.selectedID != "" {
 					m.currentMode = LogsMode
-					return m, m.fetchLogs
+					cmd = m.startLogFollow()
+					return m, cmd
 				} else if m.currentTab == ComposeTab && m.selectedPath != "" {
-					m.currentMode = LogsMode
-					return m, m.composeAction("logs")
+					cmd = m.startComposeLogs()
+					return m, cmd
 				}
 
 			case key.Matches(msg, DefaultFullKeyMap.Monitor):
 				// Only containers can be monitored
 				if m.currentTab == ContainersTab && m.selectedID != "" {
-					m.currentMode = MonitorMode
-					return m, tea.Batch(
-						m.fetchStats,
-						m.startStatsRefresh(),
-					)
+					return m, m.startMonitoring()
 				}
 			}
 
@@ -1418,14 +2146,15 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							return afterActionMsg{action: "inspect"}
 						},
 					)
+				case key.Matches(msg, DefaultFullKeyMap.Timeout):
+					cmd = m.promptStopTimeout()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.Policy):
+					m.pickRestartPolicy()
+					return m, nil
 				case key.Matches(msg, DefaultFullKeyMap.Kill):
-					m.statusMsg = "Killing container..."
-					return m, tea.Batch(
-						m.containerAction("kill"),
-						func() tea.Msg {
-							return afterActionMsg{action: "list"}
-						},
-					)
+					m.pickKillSignal()
+					return m, nil
 				case key.Matches(msg, DefaultFullKeyMap.Remove):
 					m.statusMsg = "Removing container..."
 					return m, tea.Batch(
@@ -1434,6 +2163,41 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							return afterActionMsg{action: "list"}
 						},
 					)
+				case key.Matches(msg, DefaultFullKeyMap.Env):
+					// Toggle between the inspect output and the environment comparison
+					if m.inspectView == inspectViewEnv {
+						m.inspectView = inspectViewDefault
+						m.setViewportContent(m.renderInspectContent())
+						m.viewport.GotoTop()
+						return m, nil
+					}
+					m.statusMsg = "Comparing environment with image defaults..."
+					return m, m.fetchContainerEnv
+				case key.Matches(msg, DefaultFullKeyMap.Top):
+					cmd = m.toggleContainerTop()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.Commit):
+					cmd = m.openCommitForm()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.Limits):
+					cmd = m.loadContainerLimits()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.Diff):
+					// Toggle between the inspect output and the filesystem changes
+					if m.inspectView == inspectViewDiff {
+						m.inspectView = inspectViewDefault
+						m.setViewportContent(m.renderInspectContent())
+						m.viewport.GotoTop()
+						return m, nil
+					}
+					m.statusMsg = fmt.Sprintf("Comparing the filesystem of %s with its image...", m.selectedName)
+					return m, m.fetchContainerDiff
+				case key.Matches(msg, DefaultFullKeyMap.Clone):
+					m.statusMsg = fmt.Sprintf("Reading configuration of %s...", m.selectedName)
+					return m, m.fetchCloneTemplate
+				case key.Matches(msg, DefaultFullKeyMap.ServiceTail):
+					cmd = m.tailSelectedReplicas()
+					return m, cmd
 				}
 			case ImagesTab:
 				switch {
@@ -1445,6 +2209,28 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 							return afterActionMsg{action: "list"}
 						},
 					)
+				case key.Matches(msg, DefaultFullKeyMap.TagImage):
+					cmd = m.promptImageTag()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.PushImage):
+					cmd = m.promptImagePush()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.Layers):
+					cmd = m.toggleImageLayers()
+					return m, cmd
+				}
+				if m.inspectView == inspectViewLayers {
+					switch {
+					case key.Matches(msg, DefaultFullKeyMap.PrevService):
+						m.moveLayerCursor(-1)
+						return m, nil
+					case key.Matches(msg, DefaultFullKeyMap.NextService):
+						m.moveLayerCursor(1)
+						return m, nil
+					case key.Matches(msg, DefaultFullKeyMap.Expand):
+						m.toggleLayerExpanded()
+						return m, nil
+					}
 				}
 			case VolumesTab:
 				switch {
@@ -1470,18 +2256,106 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
-			// When in inspect mode, let the viewport handle navigation
+			// User-defined actions from the config file
+			if action, ok := m.findCustomAction(msg); ok {
+				cmd = m.runCustomAction(action)
+				return m, cmd
+			}
+
+			// When in inspect mode, let the viewport handle navigation, or the
+			// processes table when it's shown
 			var cmd tea.Cmd
+			if m.inspectView == inspectViewTop && m.topNote == "" {
+				m.topTable, cmd = m.topTable.Update(msg)
+				return m, cmd
+			}
+			if m.inspectTreeShown() && m.handleInspectTreeKey(msg) {
+				return m, nil
+			}
 			m.viewport, cmd = m.viewport.Update(msg)
 			if cmd != nil {
 				cmds = append(cmds, cmd)
 			}
 		} else if m.currentMode == LogsMode || m.currentMode == MonitorMode {
+			// Additional key handling for logs mode
+			if m.currentMode == LogsMode {
+				// Number keys toggle services while tailing a compose project
+				if m.composeLogs != nil {
+					if s := msg.String(); len(s) == 1 && s >= "1" && s <= "9" {
+						m.toggleComposeService(int(s[0] - '1'))
+						return m, nil
+					}
+					if key.Matches(msg, DefaultFullKeyMap.SoloService) {
+						m.soloComposeService()
+						return m, nil
+					}
+				}
+
+				switch {
+				case key.Matches(msg, DefaultFullKeyMap.LogGrep):
+					cmd = m.openPrompt("grep:", m.logGrep, func(m *FullModel, value string) tea.Cmd {
+						m.applyLogGrep(strings.TrimSpace(value))
+						return nil
+					})
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.MoreContext):
+					if m.logGrep != "" && m.logGrepContext < maxGrepContext {
+						m.logGrepContext++
+						m.applyLogGrep(m.logGrep)
+					}
+					return m, nil
+				case key.Matches(msg, DefaultFullKeyMap.LessContext):
+					if m.logGrep != "" && m.logGrepContext > 0 {
+						m.logGrepContext--
+						m.applyLogGrep(m.logGrep)
+					}
+					return m, nil
+				case key.Matches(msg, DefaultFullKeyMap.DownloadLogs):
+					// Only single containers have a log history to download
+					if m.currentTab == ContainersTab && m.composeLogs == nil && m.selectedID != "" {
+						cmd = m.startLogDownload()
+						return m, cmd
+					}
+					return m, nil
+				case key.Matches(msg, DefaultFullKeyMap.FollowLogs):
+					// Compose tails always follow; single containers can be paused
+					if m.currentTab == ContainersTab && m.composeLogs == nil && m.selectedID != "" {
+						cmd = m.toggleLogFollow()
+						return m, cmd
+					}
+					return m, nil
+				case key.Matches(msg, DefaultFullKeyMap.CycleLogTail):
+					if m.currentTab == ContainersTab && m.composeLogs == nil && m.selectedID != "" {
+						cmd = m.cycleLogTail()
+						return m, cmd
+					}
+					return m, nil
+				case key.Matches(msg, DefaultFullKeyMap.Timestamps):
+					cmd = m.cycleLogTimestamps()
+					return m, cmd
+				case key.Matches(msg, DefaultFullKeyMap.LogWindow):
+					if m.currentTab == ContainersTab && m.composeLogs == nil && m.selectedID != "" {
+						cmd = m.promptLogWindow()
+						return m, cmd
+					}
+					return m, nil
+				case key.Matches(msg, DefaultFullKeyMap.LogStreams):
+					if m.currentTab == ContainersTab && m.composeLogs == nil && m.selectedID != "" {
+						cmd = m.cycleLogStreams()
+						return m, cmd
+					}
+					return m, nil
+				}
+			}
+
 			// Additional key handling for monitor mode
 			if m.currentMode == MonitorMode {
 				switch {
 				case key.Matches(msg, DefaultFullKeyMap.Refresh):
-					return m, m.fetchStats
+					return m, m.startMonitoring()
+				case key.Matches(msg, DefaultFullKeyMap.Limits):
+					cmd = m.loadContainerLimits()
+					return m, cmd
 				}
 			}
 
@@ -1493,18 +2367,9 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case tickMsg:
-		// Only refresh stats if we're in monitor mode
-		if m.currentMode == MonitorMode {
-			cmds = append(cmds, m.fetchStats)
-			cmds = append(cmds, m.startStatsRefresh())
-		}
-
-		// Refresh system info every 5 seconds
-		if time.Now().Second()%5 == 0 {
-			cmds = append(cmds, func() tea.Msg {
-				return m.fetchSystemInfo()
-			})
+	case statsStreamMsg:
+		if cmd := m.handleStatsStream(msg); cmd != nil {
+			cmds = append(cmds, cmd)
 		}
 
 	case tea.WindowSizeMsg:
@@ -1520,114 +2385,82 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.composeTable = m.initializeTable(ComposeTab)
 
 			// Set up viewport for details panel
-			m.viewport = viewport.New(msg.Width, msg.Height-8)
+			m.viewport = viewport.New(m.contentWidth(), msg.Height-8)
 			m.viewport.Style = lipgloss.NewStyle().
 				BorderStyle(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("240")).
+				BorderForeground(m.styles.Border).
 				Padding(1, 2)
 
 		} else {
 			m.updateTables()
 		}
 
+		// Re-flow wrapped lines to the new width
+		if m.currentMode == InspectMode || m.currentMode == LogsMode {
+			m.setViewportContent(m.viewportContent)
+		}
+
 	case fullContainersMsg:
 		m.loading = false
 		m.containers = msg.containers
-
-		// Convert containers to table rows
-		rows := []table.Row{}
-		for _, c := range msg.containers {
-			// Add status icon based on container state
-			statusWithIcon := c.State
-			switch {
-			case strings.Contains(strings.ToLower(c.State), "running"):
-				statusWithIcon = IconRunning + c.State
-			case strings.Contains(strings.ToLower(c.State), "exited"):
-				statusWithIcon = IconExited + c.State
-			case strings.Contains(strings.ToLower(c.State), "created"):
-				statusWithIcon = IconCreated + c.State
-			case strings.Contains(strings.ToLower(c.State), "paused"):
-				statusWithIcon = IconPaused + c.State
-			case strings.Contains(strings.ToLower(c.State), "restarting"):
-				statusWithIcon = IconRestarting + c.State
-			case strings.Contains(strings.ToLower(c.State), "dead"):
-				statusWithIcon = IconDead + c.State
-			}
-
-			row := table.Row{c.Name, statusWithIcon, c.Image, c.ID[:12]}
-			rows = append(rows, row)
-		}
-
-		m.containerTable.SetRows(rows)
-		m.statusMsg = fmt.Sprintf("Loaded %d containers", len(msg.containers))
+		m.refreshOrphans()
+		m.refreshRows(ContainersTab)
+		m.restoreSessionSelection(ContainersTab)
+		m.restorePendingSelection()
+		m.statusMsg = fmt.Sprintf("Loaded %d containers%s", len(msg.containers),
+			m.filterSummary(ContainersTab, len(m.visibleContainers())))
 
 	case fullImagesMsg:
 		m.loading = false
 		m.images = msg.images
-
-		// Convert images to table rows
-		rows := []table.Row{}
-		for _, img := range msg.images {
-			repoTag := "<none>:<none>"
-			if len(img.RepoTags) > 0 {
-				repoTag = img.RepoTags[0]
-			}
-
-			// Format size
-			size := formatBytes(img.Size)
-
-			row := table.Row{repoTag, size, img.ID[:12]}
-			rows = append(rows, row)
-		}
-
-		m.imageTable.SetRows(rows)
-		m.statusMsg = fmt.Sprintf("Loaded %d images", len(msg.images))
+		m.refreshRows(ImagesTab)
+		m.restoreSessionSelection(ImagesTab)
+		m.statusMsg = fmt.Sprintf("Loaded %d images%s", len(msg.images),
+			m.filterSummary(ImagesTab, len(m.visibleImages())))
 
 	case fullVolumesMsg:
 		m.loading = false
 		m.volumes = msg.volumes
-
-		// Convert volumes to table rows
-		rows := []table.Row{}
-		for _, v := range msg.volumes {
-			row := table.Row{v.Name, v.Driver, v.Mountpoint}
-			rows = append(rows, row)
-		}
-
-		m.volumeTable.SetRows(rows)
-		m.statusMsg = fmt.Sprintf("Loaded %d volumes", len(msg.volumes))
+		m.refreshRows(VolumesTab)
+		m.restoreSessionSelection(VolumesTab)
+		m.statusMsg = fmt.Sprintf("Loaded %d volumes%s", len(msg.volumes),
+			m.filterSummary(VolumesTab, len(m.visibleVolumes())))
 
 	case fullNetworksMsg:
 		m.loading = false
 		m.networks = msg.networks
-
-		// Convert networks to table rows
-		rows := []table.Row{}
-		for _, n := range msg.networks {
-			row := table.Row{n.Name, n.Driver, n.Scope, n.ID[:12]}
-			rows = append(rows, row)
-		}
-
-		m.networkTable.SetRows(rows)
-		m.statusMsg = fmt.Sprintf("Loaded %d networks", len(msg.networks))
+		m.refreshRows(NetworksTab)
+		m.restoreSessionSelection(NetworksTab)
+		m.statusMsg = fmt.Sprintf("Loaded %d networks%s", len(msg.networks),
+			m.filterSummary(NetworksTab, len(m.visibleNetworks())))
 
 	case fullLogsMsg:
+		if msg.stream != nil {
+			cmd = m.handleFollowLogLines(msg)
+			return m, cmd
+		}
 		m.logContent = msg.content
-		m.viewport.SetContent(m.logContent)
+		m.setViewportContent(m.renderLogContent())
 		m.viewport.GotoTop()
 		m.statusMsg = fmt.Sprintf("Showing logs for %s", m.selectedName)
 
 	case fullInspectMsg:
 		m.inspectContent = msg.content
+		m.loadInspectTree(msg.content)
+		m.inspectView = inspectViewDefault
+		m.restartPolicy = container.RestartPolicy{}
+		if m.currentTab == ContainersTab {
+			m.restartPolicy, _ = views.ContainerRestartPolicy(msg.content)
+		}
 
 		// Special handling for Compose tab
 		if m.currentTab == ComposeTab && m.currentMode == InspectMode {
 			// Use our custom compose inspection renderer instead of the generic content
 			content := m.renderComposeInspect()
-			m.viewport.SetContent(content)
+			m.setViewportContent(content)
 		} else {
 			// Normal handling for other tabs
-			m.viewport.SetContent(m.inspectContent)
+			m.setViewportContent(m.renderInspectContent())
 		}
 
 		m.viewport.GotoTop()
@@ -1635,6 +2468,7 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case fullActionResultMsg:
 		m.statusMsg = msg.message
+		m.recordAction(msg)
 		if msg.success && msg.action != "" {
 			// Refresh data after successful action
 			switch m.currentTab {
@@ -1658,20 +2492,9 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.err = msg.err
 		m.statusMsg = fmt.Sprintf("Error: %v", msg.err)
 
-	case fullStatsMsg:
-		m.statsContent = msg.content
-		m.viewport.SetContent(m.statsContent)
-		m.viewport.GotoTop()
-		m.statusMsg = fmt.Sprintf("Monitoring %s", m.selectedName)
-
 	case dockerConnectionMsg:
-		m.dockerConnected = msg.connected
-		if !m.dockerConnected {
-			m.statusMsg = fmt.Sprintf("Docker connection error: %v", msg.err)
-			// Start periodic check for reconnection
-			return m, m.startConnectionCheck()
-		}
-		return m, nil
+		cmd = m.handleDockerConnection(msg)
+		return m, cmd
 
 	case connectionCheckTickMsg:
 		// Time to check the connection again
@@ -1685,6 +2508,12 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.inspectComposeProject
 			}
 			return m, m.inspectResource
+		} else if msg.action == "monitor" {
+			// Restart monitoring so the stats reflect the change
+			if m.currentMode == MonitorMode {
+				cmd = m.startMonitoring()
+				return m, cmd
+			}
 		} else if msg.action == "list" {
 			// Return to list mode
 			m.currentMode = ListMode
@@ -1698,16 +2527,13 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case composeProjectsMsg:
 		m.loading = false
 		m.composeProjects = msg.projects
-
-		// Convert compose projects to table rows
-		rows := []table.Row{}
-		for _, p := range msg.projects {
-			row := table.Row{p.Name, p.Status, p.Path}
-			rows = append(rows, row)
-		}
-
-		m.composeTable.SetRows(rows)
-		m.statusMsg = fmt.Sprintf("Loaded %d Docker Compose projects", len(msg.projects))
+		m.composeProjectsLoaded = true
+		m.refreshOrphans()
+		m.refreshRows(ContainersTab)
+		m.refreshRows(ComposeTab)
+		m.restoreSessionSelection(ComposeTab)
+		m.statusMsg = fmt.Sprintf("Loaded %d Docker Compose projects%s", len(msg.projects),
+			m.filterSummary(ComposeTab, len(m.visibleComposeProjects())))
 
 	case fullComposeServicesMsg:
 		m.composeServicesLoading = false
@@ -1723,7 +2549,7 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.currentMode == InspectMode && m.currentTab == ComposeTab {
 			// Re-render the content with the updated services
 			content := m.renderComposeInspect()
-			m.viewport.SetContent(content)
+			m.setViewportContent(content)
 
 			// Preserve scroll position if possible, or go to top if new content
 			if len(m.composeServices) > 0 {
@@ -1752,7 +2578,7 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.currentMode == InspectMode && m.currentTab == ComposeTab {
 			// Re-render the content with the updated containers
 			content := m.renderComposeInspect()
-			m.viewport.SetContent(content)
+			m.setViewportContent(content)
 
 			// Preserve scroll position if possible
 			currentY := m.viewport.YOffset
@@ -1766,7 +2592,7 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.currentTab = ComposeTab
 		m.currentMode = ListMode
 		// Instead of using m.listTable, we'll update the UI through the table model
-		m.composeTable = buildComposeTableModel(m.composeProjects, m.width)
+		m.composeTable = m.buildComposeTableModel(m.composeProjects, m.width)
 		return m, nil
 
 	// Add handling for Docker Compose service actions
@@ -1798,6 +2624,203 @@ func (m FullModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case statusClearMsg:
 		m.statusMsg = ""
 
+	case configReloadedMsg:
+		m.handleConfigReloaded(msg)
+
+	case usageTickMsg:
+		return m, m.sampleUsage()
+
+	case usageMsg:
+		cmd = m.handleUsage(msg)
+		return m, cmd
+
+	case logFileClosedMsg:
+		m.handleLogFileClosed(msg)
+
+	case composeTailContainersMsg:
+		cmd = m.startComposeTail(msg)
+		return m, cmd
+
+	case composeLogLinesMsg:
+		cmd = m.handleComposeLogLines(msg)
+		return m, cmd
+
+	case logDownloadTickMsg:
+		cmd = m.handleLogDownloadTick(msg)
+		return m, cmd
+
+	case autoRefreshTickMsg:
+		return m, m.handleAutoRefreshTick()
+
+	case DockerEventMsg:
+		cmd = tea.Batch(HandleDockerEvent(&m, msg.Event)...)
+		return m, cmd
+
+	case dockerEventsSettledMsg:
+		cmd = m.refreshAfterEvents()
+		return m, cmd
+
+	case deathBannerExpiredMsg:
+		m.handleDeathBannerExpired(msg)
+		return m, nil
+
+	case eventStreamMsg:
+		cmd = m.handleEventStream(msg)
+		return m, cmd
+
+	case eventStreamRetryMsg:
+		cmd = m.handleEventStreamRetry()
+		return m, cmd
+
+	case autoRefreshMsg:
+		// Keep the status message, unless the refresh failed
+		status := m.statusMsg
+		model, cmd := m.Update(msg.msg)
+		refreshed := model.(FullModel)
+		if _, failed := msg.msg.(fullErrMsg); !failed {
+			refreshed.statusMsg = status
+		}
+		return refreshed, cmd
+
+	case clipboardMsg:
+		m.handleClipboard(msg)
+		return m, nil
+
+	case daemonInfoMsg:
+		m.handleDaemonInfo(msg)
+		return m, nil
+
+	case diskUsageMsg:
+		m.handleDiskUsage(msg)
+		return m, nil
+
+	case dashboardStatsMsg:
+		cmd = m.handleDashboardStats(msg)
+		return m, cmd
+
+	case dashboardTickMsg:
+		cmd = m.handleDashboardTick(msg)
+		return m, cmd
+
+	case resourceCreatedMsg:
+		if msg.list == nil {
+			return m.Update(msg.result)
+		}
+		// Load the list first, so the result isn't replaced by its summary
+		model, listCmd := m.Update(msg.list)
+		created := model.(FullModel)
+		created.selectResource(msg.tab, msg.id)
+		model, cmd = created.Update(msg.result)
+		return model, tea.Batch(listCmd, cmd)
+
+	case pruneCandidatesMsg:
+		cmd = m.handlePruneCandidates(msg)
+		return m, cmd
+
+	case composeExecMsg:
+		cmd = m.handleComposeExec(msg)
+		return m, cmd
+
+	case pruneDoneMsg:
+		// Report the prune like any action, then update the disk usage panel
+		model, cmd := m.Update(msg.result)
+		pruned := model.(FullModel)
+		if pruned.diskUsage.open {
+			return pruned, tea.Batch(cmd, pruned.fetchDiskUsage())
+		}
+		return pruned, cmd
+
+	case imageTransferTickMsg:
+		cmd = m.handleImageTransferTick(msg)
+		return m, cmd
+
+	case pullBeforeCreateMsg:
+		config := msg.config
+		cmd = m.startImagePull(config.Image, func(m *FullModel) tea.Cmd {
+			m.statusMsg = fmt.Sprintf("Pulled %s, creating the container...", config.Image)
+			return m.createContainer(config)
+		})
+		return m, cmd
+
+	case spinner.TickMsg:
+		// The spinner only animates while an image transfer, disk usage or
+		// daemon info query or first dashboard sample is shown
+		if m.imageTransfer != nil || m.diskUsage.loading || m.daemonInfo.loading || (m.dashboard.open && m.dashboard.at.IsZero()) {
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+
+	case logFileLoadedMsg:
+		m.handleLogFileLoaded(msg)
+		return m, nil
+
+	case reinitializedMsg:
+		cmd = m.handleReinitialized(msg)
+		return m, cmd
+
+	case cloneTemplateMsg:
+		cmd = m.handleCloneTemplate(msg)
+		return m, cmd
+
+	case containerDiffMsg:
+		if m.currentMode == InspectMode {
+			// Stay in place when refreshing the diff
+			refresh := m.inspectView == inspectViewDiff
+			m.inspectView = inspectViewDiff
+			m.setViewportContent(msg.content)
+			if !refresh {
+				m.viewport.GotoTop()
+			}
+			m.statusMsg = fmt.Sprintf("%d filesystem changes in %s (%s to go back)", msg.changes, m.selectedName, DefaultFullKeyMap.Diff.Help().Key)
+		}
+
+	case containerTopMsg:
+		m.handleContainerTop(msg)
+
+	case imageLayersMsg:
+		m.handleImageLayers(msg)
+
+	case composeConfigMsg:
+		m.handleComposeConfig(msg)
+
+	case composePsMsg:
+		m.handleComposePs(msg)
+
+	case containerLimitsMsg:
+		cmd = m.openLimitsForm(msg)
+		return m, cmd
+
+	case containerEnvMsg:
+		if m.currentMode == InspectMode {
+			m.inspectView = inspectViewEnv
+			m.setViewportContent(msg.content)
+			m.viewport.GotoTop()
+			m.statusMsg = fmt.Sprintf("Environment of %s (%s to go back)", m.selectedName, DefaultFullKeyMap.Env.Help().Key)
+		}
+
+	case dockerContextsMsg:
+		return m, m.handleDockerContexts(msg)
+
+	case contextSwitchedMsg:
+		return m, m.handleContextSwitched(msg)
+
+	case composeSearchPathSavedMsg:
+		cmd = m.handleComposeSearchPathSaved(msg)
+		return m, cmd
+
+	case hubSearchMsg:
+		m.handleHubSearch(msg)
+		return m, nil
+
+	case logTimestampsSavedMsg:
+		m.handleLogTimestampsSaved(msg)
+		return m, nil
+
+	case containerRunningMsg:
+		cmd = m.confirmForceRemove(msg)
+		return m, cmd
+
 	}
 
 	// Apply any pending commands
@@ -1815,22 +2838,42 @@ func (m FullModel) View() string {
 	// Create a header with tabs
 	header := lipgloss.NewStyle().
 		Bold(true).
-		Foreground(lipgloss.Color("#88c0d0")).
+		Foreground(m.styles.Title).
 		Render("Docker Tea")
 
 	// Tab bar
 	tabBar := m.renderTabBar()
 
 	sb.WriteString(header)
+	if m.dockerContext != "" {
+		contextStyle := lipgloss.NewStyle().Foreground(m.styles.Success)
+		sb.WriteString(" ")
+		sb.WriteString(contextStyle.Render("⎈ " + m.dockerContext))
+	}
+	if m.docker.IsRemote() {
+		// Make it obvious that actions affect another machine
+		remoteStyle := lipgloss.NewStyle().
+			Bold(true).
+			Foreground(m.styles.Emphasis).
+			Background(m.styles.Error).
+			Padding(0, 1)
+		sb.WriteString(" ")
+		sb.WriteString(remoteStyle.Render("REMOTE: " + m.docker.Host()))
+	}
+	if stream := m.renderEventStream(); stream != "" {
+		sb.WriteString(" ")
+		sb.WriteString(stream)
+	}
 	sb.WriteString("  ")
 	sb.WriteString(tabBar)
 	sb.WriteString("\n\n")
 
-	// Show Docker connection alert if not connected
-	if !m.dockerConnected {
+	// Show Docker connection alert if not connected, unless the lists are
+	// replaced by the screen saying so
+	if m.dockerDown() && m.currentMode != ListMode {
 		alertStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#ffffff")).
-			Background(lipgloss.Color("#ff0000")).
+			Foreground(m.styles.Emphasis).
+			Background(m.styles.Error).
 			Bold(true).
 			Padding(0, 1).
 			MarginBottom(1).
@@ -1840,9 +2883,35 @@ func (m FullModel) View() string {
 		sb.WriteString("\n\n")
 	}
 
+	// Report containers that died unexpectedly for a few seconds
+	if m.banner.text != "" {
+		sb.WriteString(m.renderDeathBanner())
+		sb.WriteString("\n\n")
+	}
+
 	// Main content area
-	switch m.currentMode {
-	case ListMode:
+	switch {
+	case m.confirm.active:
+		sb.WriteString(m.renderConfirm())
+	case m.picker.active:
+		sb.WriteString(m.renderPicker())
+	case m.history.open:
+		sb.WriteString(m.renderHistory())
+	case m.diskUsage.open:
+		sb.WriteString(m.renderDiskUsage())
+	case m.daemonInfo.open:
+		sb.WriteString(m.renderDaemonInfo())
+	case m.dashboard.open:
+		sb.WriteString(m.renderDashboard())
+	case m.createForm.active:
+		sb.WriteString(m.renderCreateForm())
+	case m.resourceForm.active:
+		sb.WriteString(m.renderResourceForm())
+	case m.imageTransfer != nil:
+		sb.WriteString(m.renderImageTransfer())
+	case m.currentMode == ListMode && m.dockerDown():
+		sb.WriteString(m.renderDockerDown())
+	case m.currentMode == ListMode:
 		// Render the appropriate table based on the current tab
 		switch m.currentTab {
 		case ContainersTab:
@@ -1850,12 +2919,16 @@ func (m FullModel) View() string {
 				sb.WriteString("Loading containers...\n")
 			} else {
 				sb.WriteString(m.containerTable.View())
+				sb.WriteString("\n")
+				sb.WriteString(lipgloss.NewStyle().Foreground(m.styles.Title).Render(m.renderUsage()))
 			}
 		case ImagesTab:
 			if m.loading && m.imageTable.Width() == 0 {
 				sb.WriteString("Loading images...\n")
 			} else {
 				sb.WriteString(m.imageTable.View())
+				sb.WriteString("\n")
+				sb.WriteString(lipgloss.NewStyle().Foreground(m.styles.Title).Render(m.renderImageTotal()))
 			}
 		case VolumesTab:
 			if m.loading && m.volumeTable.Width() == 0 {
@@ -1871,13 +2944,19 @@ func (m FullModel) View() string {
 			}
 		case ComposeTab:
 			sb.WriteString(m.renderComposeTab())
+		case EventsTab:
+			sb.WriteString(m.renderEventLog())
 		}
-	case InspectMode:
+	case m.currentMode == InspectMode:
 		// Render inspect view
+		title := fmt.Sprintf("Inspecting %s", m.selectedName)
+		if m.currentTab == ContainersTab && m.inspectContent != "" {
+			title += fmt.Sprintf(" · restart policy: %s", restartPolicyLabel(m.restartPolicy))
+		}
 		inspectHeader := lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("#88c0d0")).
-			Render(fmt.Sprintf("Inspecting %s", m.selectedName))
+			Foreground(m.styles.Title).
+			Render(title)
 
 		sb.WriteString(inspectHeader)
 		sb.WriteString("\n\n")
@@ -1890,37 +2969,59 @@ func (m FullModel) View() string {
 			m.viewport.Height = inspectHeight
 		}
 
-		sb.WriteString(m.viewport.View())
+		if m.inspectView == inspectViewTop && m.topNote == "" {
+			sb.WriteString(m.topTable.View())
+		} else {
+			sb.WriteString(m.renderViewport())
+		}
 
 		// Add action panel after the viewport
 		sb.WriteString("\n\n")
 		sb.WriteString(m.renderActionPanel())
 
-	case LogsMode:
+	case m.currentMode == LogsMode:
 		// Render logs view
+		title := fmt.Sprintf("Logs for %s", m.selectedName)
+		if m.logStreams != docker.LogsBoth {
+			title += fmt.Sprintf(" [%s]", m.logStreams)
+		}
+		if !m.logWindow.IsZero() {
+			title += " " + logWindowLabel(m.logWindow)
+		}
+		if m.logFollow != nil {
+			title += " (following)"
+		}
+		if m.composeLogs != nil {
+			title = m.composeLogs.title()
+		}
 		logsHeader := lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("#88c0d0")).
-			Render(fmt.Sprintf("Logs for %s", m.selectedName))
+			Foreground(m.styles.Title).
+			Render(title)
 
 		sb.WriteString(logsHeader)
-		sb.WriteString("\n\n")
-		sb.WriteString(m.viewport.View())
-	case MonitorMode:
+		sb.WriteString("\n")
+		if m.composeLogs != nil {
+			sb.WriteString(m.composeLogs.legend(m.styles))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+		sb.WriteString(m.renderViewport())
+	case m.currentMode == MonitorMode:
 		// Render monitoring view
 		monitorHeader := lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("#88c0d0")).
+			Foreground(m.styles.Title).
 			Render(fmt.Sprintf("Monitoring %s", m.selectedName))
 
 		sb.WriteString(monitorHeader)
 		sb.WriteString("\n\n")
-		sb.WriteString(m.viewport.View())
-	case ComposeServiceMode:
+		sb.WriteString(m.renderViewport())
+	case m.currentMode == ComposeServiceMode:
 		// Render Docker Compose service view
 		serviceHeader := lipgloss.NewStyle().
 			Bold(true).
-			Foreground(lipgloss.Color("#88c0d0")).
+			Foreground(m.styles.Title).
 			Render(fmt.Sprintf("Docker Compose Service: %s", m.selectedName))
 
 		sb.WriteString(serviceHeader)
@@ -1934,7 +3035,7 @@ func (m FullModel) View() string {
 			m.viewport.Height = serviceHeight
 		}
 
-		sb.WriteString(m.viewport.View())
+		sb.WriteString(m.renderViewport())
 
 		// Add action panel after the viewport
 		sb.WriteString("\n\n")
@@ -1959,15 +3060,32 @@ func (m FullModel) View() string {
 		footerText = m.statusMsg
 	}
 
+	// Show the active list filter or viewport search
+	if m.currentMode == ListMode {
+		if summary := m.listFilterSummary(); summary != "" {
+			footerText = fmt.Sprintf("%s | %s", footerText, summary)
+		}
+		footerText = fmt.Sprintf("%s | %s", footerText, m.autoRefreshSummary())
+	}
+	if m.currentMode == InspectMode || m.currentMode == LogsMode {
+		if summary := m.searchSummary(); summary != "" {
+			footerText = fmt.Sprintf("%s | %s", footerText, summary)
+		}
+	}
+
 	// Add help hint
 	footerText = fmt.Sprintf("%s | Press ? for help", footerText)
 
 	// Style and render footer
 	footer := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#4c566a")).
+		Foreground(m.styles.StatusBar).
 		Render(footerText)
 
 	sb.WriteString("\n")
+	if m.prompt.active {
+		sb.WriteString(m.renderPrompt())
+		sb.WriteString("\n")
+	}
 	sb.WriteString(footer)
 
 	// Help section
@@ -1979,6 +3097,29 @@ func (m FullModel) View() string {
 	return sb.String()
 }
 
+// renderInspectContent renders the inspect viewport content for the current resource
+func (m FullModel) renderInspectContent() string {
+	content := m.inspectContent
+	if m.inspectTreeShown() {
+		content = m.renderInspectTree()
+	} else if m.config.SyntaxHighlight {
+		content = m.highlightJSON(content)
+	}
+
+	if m.currentTab == ContainersTab && m.currentMode == InspectMode {
+		// Prepend a readable summary to the raw container JSON
+		if summary := views.ContainerSummary(m.inspectContent); summary != "" {
+			return summary + content
+		}
+	}
+	if m.currentTab == NetworksTab && m.currentMode == InspectMode {
+		if summary := views.NetworkSummary(m.inspectContent, m.networkContainerCursor); summary != "" {
+			return summary + content
+		}
+	}
+	return content
+}
+
 // renderTabBar renders the tab bar
 func (m FullModel) renderTabBar() string {
 	tabs := []string{
@@ -1987,6 +3128,7 @@ func (m FullModel) renderTabBar() string {
 		IconVolume + "Volumes",
 		IconNetwork + "Networks",
 		IconCompose + "Compose",
+		IconEvents + "Events",
 	}
 
 	var renderedTabs []string
@@ -1994,10 +3136,15 @@ func (m FullModel) renderTabBar() string {
 		style := lipgloss.NewStyle().
 			Padding(0, 2)
 
+		// Show which filter is narrowing the tab
+		if filter := m.tabFilter(Tab(i)); filter != filterAll {
+			t += fmt.Sprintf(" [%s]", filter)
+		}
+
 		if i == int(m.currentTab) {
 			style = style.
-				Foreground(lipgloss.Color("#ffffff")).
-				Background(lipgloss.Color("#5f87ff")).
+				Foreground(m.styles.Emphasis).
+				Background(m.styles.Accent).
 				Bold(true)
 		}
 
@@ -2010,34 +3157,71 @@ func (m FullModel) renderTabBar() string {
 // renderHelp renders the help text
 func (m FullModel) renderHelp() string {
 	var sb strings.Builder
+	km := DefaultFullKeyMap
+	hints := func(items ...string) string { return "  " + strings.Join(items, ", ") }
 
 	sb.WriteString(lipgloss.NewStyle().Bold(true).Render("Keyboard Shortcuts:"))
 	sb.WriteString("\n\n")
 
 	// Global commands
-	sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#5f87ff")).
+	sb.WriteString(lipgloss.NewStyle().Foreground(m.styles.Accent).
 		Render("Global:"))
 	sb.WriteString("\n")
-	sb.WriteString(fmt.Sprintf("  %sQuit, %sToggle help, %sRefresh", IconQuit, IconHelp, IconRefresh))
+	sb.WriteString(hints(
+		IconQuit+keyHint(km.Quit, "Quit"), IconHelp+keyHint(km.Help, "Toggle help"), IconRefresh+keyHint(km.Refresh, "Refresh"),
+		keyHint(km.Filter, "Cycle status filter"), keyHint(km.Search, "Filter list by text"), keyHint(km.SwitchContext, "Switch Docker context"),
+		keyHint(km.SwitchTheme, "Switch theme"), keyHint(km.HardRefresh, "Reconnect"), keyHint(km.History, "Action history"),
+		keyHint(km.OpenAppLog, "Open app log"), keyHint(km.OpenLogExport, "Open downloaded logs"), keyHint(km.ReloadConfig, "Reload config"),
+		keyHint(km.AutoRefresh, "Pause/resume auto-refresh"), keyHint(km.DiskUsage, "Disk usage"), keyHint(km.SystemInfo, "Docker info and version"),
+		keyHint(km.PruneAll, "Prune everything unused"), keyHint(km.Dashboard, "Stats of all running containers"),
+	))
 	sb.WriteString("\n\n")
 
 	// Navigation
-	sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#5f87ff")).
+	sb.WriteString(lipgloss.NewStyle().Foreground(m.styles.Accent).
 		Render("Navigation:"))
 	sb.WriteString("\n")
-	sb.WriteString("  ↑/k: Up, ↓/j: Down, Tab/→: Next tab, Shift+Tab/←: Previous tab")
+	sb.WriteString(hints(keyHint(km.Up, "Up"), keyHint(km.Down, "Down"), keyHint(km.NextTab, "Next tab"), keyHint(km.PrevTab, "Previous tab")))
 	sb.WriteString("\n\n")
 
 	// Resource actions
-	sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#5f87ff")).
+	sb.WriteString(lipgloss.NewStyle().Foreground(m.styles.Accent).
 		Render("Resource Actions:"))
 	sb.WriteString("\n")
-	sb.WriteString(fmt.Sprintf("  %sInspect, %sLogs, %sMonitor, %sBack",
-		IconInspect, IconLogs, IconMonitor, IconBack))
+	sb.WriteString(hints(
+		IconInspect+keyHint(km.Inspect, "Inspect"), IconLogs+keyHint(km.Logs, "Logs"), IconMonitor+keyHint(km.Monitor, "Monitor"),
+		keyHint(km.Export, "Export the list (CSV/JSON) or the inspected resource to a file"),
+		keyHint(km.Prune, "Prune unused (containers/images/volumes)"), IconBack+keyHint(km.Back, "Back"),
+	))
+	sb.WriteString("\n\n")
+
+	// Search
+	sb.WriteString(lipgloss.NewStyle().Foreground(m.styles.Accent).
+		Render("Search (Inspect/Logs):"))
+	sb.WriteString("\n")
+	sb.WriteString(hints(
+		keyHint(km.Search, "Search (regex)"), keyPair(km.NextMatch, km.PrevMatch)+": Next/previous match", keyHint(km.MatchCase, "Toggle case sensitivity"),
+		keyHint(km.WrapLines, "Toggle line wrap"), keyHint(km.Highlight, "Toggle JSON colors (inspect)"), keyHint(km.Copy, "Copy to clipboard"),
+	))
+	sb.WriteString("\n")
+	sb.WriteString("  Inspect tree: " + strings.Join([]string{
+		keyPair(km.Up, km.Down) + ": Select", keyHint(km.Fold, "Expand/collapse"), keyHint(km.RawJSON, "Raw JSON"),
+	}, ", "))
+	sb.WriteString("\n\n")
+
+	// Logs view
+	sb.WriteString(lipgloss.NewStyle().Foreground(m.styles.Accent).
+		Render("Logs View:"))
+	sb.WriteString("\n")
+	sb.WriteString(hints(keyHint(km.LogGrep, "Grep with context"), keyPair(km.MoreContext, km.LessContext)+": More/less context lines", keyHint(km.DownloadLogs, "Download full logs")))
+	sb.WriteString("\n")
+	sb.WriteString(hints(keyHint(km.FollowLogs, "Pause/resume following"), keyHint(km.CycleLogTail, "Cycle history size (100/500/1000/all lines)"), keyHint(km.LogStreams, "Show stdout, stderr or both")))
+	sb.WriteString("\n")
+	sb.WriteString(hints(keyHint(km.Timestamps, "Show timestamps in UTC, in local time or not at all"), keyHint(km.LogWindow, "Limit to a time window (15m, 14:00 to 14:30)")))
 	sb.WriteString("\n\n")
 
 	// Footer legend
-	sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#5f87ff")).
+	sb.WriteString(lipgloss.NewStyle().Foreground(m.styles.Accent).
 		Render("Footer Stats Legend:"))
 	sb.WriteString("\n")
 	sb.WriteString(fmt.Sprintf("  %s Running/Paused/Stopped containers", IconContainer))
@@ -2048,17 +3232,76 @@ func (m FullModel) renderHelp() string {
 	// Tab-specific actions
 	switch m.currentTab {
 	case ContainersTab:
-		sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#5f87ff")).
+		sb.WriteString(lipgloss.NewStyle().Foreground(m.styles.Accent).
 			Render("Container Actions:"))
 		sb.WriteString("\n")
-		sb.WriteString(fmt.Sprintf("  %sStart, %sStop, %sRestart, %sPause, %sUnpause, %sKill, %sRemove",
-			IconStart, IconStop, IconRestart, IconPause, IconUnpause, IconKill, IconRemove))
+		sb.WriteString(hints(
+			IconStart+keyHint(km.Start, "Start"), IconStop+keyHint(km.Stop, "Stop"), keyHint(km.Timeout, "Stop with a chosen timeout"),
+			IconRestart+keyHint(km.Restart, "Restart"), IconPause+keyHint(km.Pause, "Pause"), IconUnpause+keyHint(km.Resume, "Unpause"),
+			IconKill+keyHint(km.Kill, "Kill"), IconRemove+keyHint(km.Remove, "Remove"), keyHint(km.Clone, "Clone"), keyHint(km.New, "New container"),
+			keyHint(km.Rename, "Rename"), keyHint(km.Commit, "Commit to image"), keyHint(km.Limits, "Resource limits (inspect/monitor view)"),
+			keyHint(km.Policy, "Restart policy (inspect view)"), keyHint(km.Top, "Processes"), keyHint(km.Diff, "Filesystem changes (inspect view)"),
+			keyHint(km.ServiceTail, "Tail service replicas"), keyHint(km.RemoveOrphans, "Remove orphaned compose containers"),
+		))
+	case ImagesTab:
+		sb.WriteString(lipgloss.NewStyle().Foreground(m.styles.Accent).
+			Render("Image Actions:"))
+		sb.WriteString("\n")
+		sb.WriteString(hints(
+			IconRemove+keyHint(km.Remove, "Remove"), keyHint(km.PullImage, "Pull image"), keyHint(km.SearchHub, "Search Docker Hub"),
+			keyHint(km.Login, "Log in to a registry"), keyHint(km.TagImage, "Tag"), keyHint(km.PushImage, "Push"), keyHint(km.SortSize, "Sort by size"),
+			keyHint(km.Layers, fmt.Sprintf("Layers (%s select, %s)", keyPair(km.PrevService, km.NextService), keyHint(km.Expand, "expand command"))),
+		))
+	case VolumesTab:
+		sb.WriteString(lipgloss.NewStyle().Foreground(m.styles.Accent).
+			Render("Volume Actions:"))
+		sb.WriteString("\n")
+		sb.WriteString(hints(IconRemove+keyHint(km.Remove, "Remove"), keyHint(km.CreateVolume, "Create volume")))
+	case NetworksTab:
+		sb.WriteString(lipgloss.NewStyle().Foreground(m.styles.Accent).
+			Render("Network Actions:"))
+		sb.WriteString("\n")
+		sb.WriteString(hints(
+			IconRemove+keyHint(km.Remove, "Remove"), keyHint(km.CreateNetwork, "Create network"),
+			keyPair(km.PrevService, km.NextService)+": Select connected container", keyHint(km.ConnectNetwork, "Connect a container"),
+			keyHint(km.DisconnectNetwork, "Disconnect it"), "1-9: Jump to it (inspect view)",
+		))
 	case ComposeTab:
-		sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#5f87ff")).
+		sb.WriteString(lipgloss.NewStyle().Foreground(m.styles.Accent).
 			Render("Compose Actions:"))
 		sb.WriteString("\n")
-		sb.WriteString(fmt.Sprintf("  %sUp, %sDown, %sPull, %sLogs",
-			IconStart, IconStop, IconRefresh, IconLogs))
+		sb.WriteString(hints(
+			IconStart+keyHint(km.ComposeUp, "Up"), IconStop+keyHint(km.ComposeDown, "Down"), IconRefresh+keyHint(km.ComposePull, "Pull"),
+			IconLogs+keyHint(km.Logs, fmt.Sprintf("Logs (followed, 1-9 toggle a service, %s)", keyHint(km.SoloService, "Show one service"))),
+			keyHint(km.ComposeTail, "Tail all services"), keyHint(km.Restart, "Restart project"),
+			keyPair(km.PrevService, km.NextService)+": Select service", keyHint(km.ComposeServiceLogs, "Service logs"),
+			keyHint(km.ServiceTail, "Tail service replicas"),
+			keyPair(km.Start, km.Stop)+"/"+km.Restart.Help().Key+": Start/stop/restart the service", keyHint(km.ComposeScale, "Scale it"),
+			keyHint(km.ComposeExec, "Open a shell in it"), keyHint(km.ComposeOverrides, "Toggle override files"),
+			keyHint(km.ComposeView, "Switch services/config/ps (inspect view)"), keyHint(km.ComposeContainer, "Jump to a container by number (inspect view)"),
+			keyHint(km.ComposeSearchPath, "Add a directory to look for projects in"),
+		))
+	case EventsTab:
+		sb.WriteString(lipgloss.NewStyle().Foreground(m.styles.Accent).
+			Render("Events:"))
+		sb.WriteString("\n")
+		sb.WriteString(hints(
+			keyPair(km.Up, km.Down)+", "+keyPair(km.PageUp, km.PageDown)+": Scroll back through events", keyHint(km.GoToBottom, "Follow new events"),
+			keyHint(km.Filter, "Filter by type"), keyHint(km.Search, "Filter by name"),
+		))
+	}
+
+	// User-defined actions from the config file
+	if custom := m.customActionsForTab(m.currentTab); len(custom) > 0 {
+		items := make([]string, len(custom))
+		for i, action := range custom {
+			items[i] = fmt.Sprintf("%s: %s", action.Key, action.Label)
+		}
+		sb.WriteString("\n\n")
+		sb.WriteString(lipgloss.NewStyle().Foreground(m.styles.Accent).
+			Render("Custom Actions:"))
+		sb.WriteString("\n  ")
+		sb.WriteString(strings.Join(items, ", "))
 	}
 
 	return sb.String()
@@ -2070,74 +3313,99 @@ func (m FullModel) renderActionPanel() string {
 
 	// Style for the panel title
 	titleStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#5f87ff")).
+		Foreground(m.styles.Accent).
 		Bold(true)
 
 	// Style for action buttons
 	actionStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#2e3440")).
-		Background(lipgloss.Color("#88c0d0")).
+		Foreground(m.styles.Header).
+		Background(m.styles.Title).
 		Padding(0, 1).
 		Margin(0, 1, 0, 0)
 
 	sb.WriteString(titleStyle.Render("Available Actions:") + "\n")
 
-	// Create a row of action buttons
+	// Create a row of action buttons, each showing the key it's bound to now
 	var actions []string
+	km := DefaultFullKeyMap
+	button := func(icon, label string, binding key.Binding) string {
+		return actionStyle.Render(fmt.Sprintf("%s %s [%s]", icon, label, binding.Help().Key))
+	}
 
 	// Common actions for all inspect views
-	actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Refresh [r]", IconRefresh)))
-	actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Back [Esc]", IconBack)))
-
-	// Remove the early return for ComposeServiceMode
-	// if m.currentMode == ComposeServiceMode {
-	//	// Actions for individual Docker Compose services
-	//	actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Up [u]", IconStart)))
-	//	actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Down [d]", IconStop)))
-	//	actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Restart [R]", IconRestart)))
-	//	actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Pull [p]", IconRefresh)))
-	//	actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Logs [l]", IconLogs)))
-	//	return boxStyle.Render(sb.String())
-	// }
+	actions = append(actions, button(IconRefresh, "Refresh", km.Refresh))
+	actions = append(actions, button(IconInspect, "Export", km.Export))
+	actions = append(actions, button(IconInspect, "Copy", km.Copy))
+	actions = append(actions, button(IconBack, "Back", km.Back))
 
 	// Tab-specific actions
 	if m.currentMode == ComposeServiceMode {
 		// Actions for individual Docker Compose services
-		actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Up [u]", IconStart)))
-		actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Down [d]", IconStop)))
-		actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Restart [R]", IconRestart)))
-		actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Pull [p]", IconRefresh)))
-		actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Logs [l]", IconLogs)))
+		actions = append(actions, button(IconStart, "Up", km.ComposeUp))
+		actions = append(actions, button(IconStop, "Down", km.ComposeDown))
+		actions = append(actions, button(IconRestart, "Restart", km.Restart))
+		actions = append(actions, button(IconRefresh, "Pull", km.ComposePull))
+		actions = append(actions, button(IconLogs, "Logs", km.Logs))
 	} else {
 		switch m.currentTab {
 		case ContainersTab:
-			actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Start [s]", IconStart)))
-			actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Stop [S]", IconStop)))
-			actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Restart [R]", IconRestart)))
-			actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Logs [l]", IconLogs)))
-			actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Monitor [m]", IconMonitor)))
-			actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Remove [d]", IconRemove)))
+			actions = append(actions, button(IconStart, "Start", km.Start))
+			actions = append(actions, button(IconStop, "Stop", km.Stop))
+			actions = append(actions, button(IconStop, "Stop After...", km.Timeout))
+			actions = append(actions, button(IconRestart, "Restart", km.Restart))
+			actions = append(actions, button(IconLogs, "Logs", km.Logs))
+			actions = append(actions, button(IconMonitor, "Monitor", km.Monitor))
+			actions = append(actions, button(IconInspect, "Env", km.Env))
+			actions = append(actions, button(IconMonitor, "Processes", km.Top))
+			actions = append(actions, button(IconInspect, "Diff", km.Diff))
+			actions = append(actions, button(IconImage, "Commit", km.Commit))
+			actions = append(actions, button(IconMonitor, "Limits", km.Limits))
+			actions = append(actions, button(IconRestart, "Restart Policy", km.Policy))
+			actions = append(actions, button(IconStart, "Clone", km.Clone))
+			actions = append(actions, button(IconLogs, "Replicas", km.ServiceTail))
+			actions = append(actions, button(IconRemove, "Remove", km.Remove))
+			actions = append(actions, button(IconRemove, "Orphans", km.RemoveOrphans))
 		case ImagesTab:
-			actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Remove [d]", IconRemove)))
+			actions = append(actions, button(IconImage, "Tag", km.TagImage))
+			actions = append(actions, button(IconImage, "Push", km.PushImage))
+			actions = append(actions, button(IconInspect, "Layers", km.Layers))
+			actions = append(actions, button(IconRemove, "Remove", km.Remove))
 		case VolumesTab:
-			actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Remove [d]", IconRemove)))
+			actions = append(actions, button(IconRemove, "Remove", km.Remove))
 		case NetworksTab:
-			actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Remove [d]", IconRemove)))
+			actions = append(actions, button(IconNetwork, "Connect", km.ConnectNetwork))
+			actions = append(actions, button(IconNetwork, "Disconnect", km.DisconnectNetwork))
+			actions = append(actions, button(IconRemove, "Remove", km.Remove))
 		case ComposeTab:
-			actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Up [u]", IconStart)))
-			actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Down [d]", IconStop)))
-			actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Pull [p]", IconRefresh)))
-			actions = append(actions, actionStyle.Render(fmt.Sprintf("%s Logs [l]", IconLogs)))
+			actions = append(actions, button(IconStart, "Up", km.ComposeUp))
+			actions = append(actions, button(IconStop, "Down", km.ComposeDown))
+			actions = append(actions, button(IconRefresh, "Pull", km.ComposePull))
+			actions = append(actions, button(IconLogs, "Logs", km.Logs))
+			actions = append(actions, button(IconLogs, "Tail", km.ComposeTail))
+			actions = append(actions, button(IconLogs, "Service Logs", km.ComposeServiceLogs))
+			actions = append(actions, button(IconStart, "Start Service", km.Start))
+			actions = append(actions, button(IconStop, "Stop Service", km.Stop))
+			actions = append(actions, button(IconRestart, "Restart Service", km.Restart))
+			actions = append(actions, button(IconStart, "Scale", km.ComposeScale))
+			actions = append(actions, button(IconInspect, "Shell", km.ComposeExec))
+			actions = append(actions, button(IconInspect, "Files", km.ComposeOverrides))
+			actions = append(actions, button(IconInspect, "Config/ps", km.ComposeView))
+			actions = append(actions, button(IconLogs, "Replicas", km.ServiceTail))
 		}
 	}
 
+	// User-defined actions from the config file
+	for _, action := range m.customActionsForTab(m.currentTab) {
+		actions = append(actions, actionStyle.Render(fmt.Sprintf("%s [%s]", action.Label, action.Key)))
+	}
+
 	// Render the action buttons in a row
 	sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, actions...))
 
 	// Create a box around the whole thing
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#4c566a")).
+		BorderForeground(m.styles.Border).
 		Padding(1).
 		Width(m.width - 4)
 
@@ -2148,16 +3416,62 @@ func (m FullModel) renderActionPanel() string {
 
 // formatBytes converts bytes to a human-readable format
 func formatBytes(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
+	return views.FormatBytes(bytes)
+}
+
+// uptimeCell shows how long a running container has been up, as Docker
+// reports it in the status, and when other containers were created
+func uptimeCell(c docker.ContainerInfo) string {
+	if strings.EqualFold(c.State, "running") {
+		if uptime, ok := strings.CutPrefix(c.Status, "Up "); ok {
+			uptime, _, _ = strings.Cut(uptime, " (") // e.g. "(healthy)"
+			return uptime
+		}
+	}
+	return "created " + humanizeDuration(c.Created)
+}
+
+// humanizeDuration describes how long ago a time was, e.g. "2 hours ago"
+func humanizeDuration(t time.Time) string {
+	elapsed := time.Since(t)
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return fmt.Sprintf("1 %s ago", unit)
+		}
+		return fmt.Sprintf("%d %ss ago", n, unit)
+	}
+
+	switch {
+	case elapsed < time.Minute:
+		return "just now"
+	case elapsed < time.Hour:
+		return plural(int(elapsed.Minutes()), "minute")
+	case elapsed < 24*time.Hour:
+		return plural(int(elapsed.Hours()), "hour")
+	case elapsed < 30*24*time.Hour:
+		return plural(int(elapsed.Hours()/24), "day")
+	case elapsed < 365*24*time.Hour:
+		return plural(int(elapsed.Hours()/24/30), "month")
+	default:
+		return plural(int(elapsed.Hours()/24/365), "year")
 	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
+}
+
+// portsCell formats a container's ports for the list
+func portsCell(ports []container.Port) string {
+	formatted := views.FormatPorts(ports)
+	if len(formatted) == 0 {
+		return "-"
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// truncateCell shortens text to fit a column of the given width
+func truncateCell(text string, width int) string {
+	if runes := []rune(text); len(runes) > width {
+		return string(runes[:width-1]) + "…"
 	}
-	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+	return text
 }
 
 // Message types for handling async operations
@@ -2177,8 +3491,13 @@ type fullNetworksMsg struct {
 	networks []docker.NetworkInfo
 }
 
+// fullLogsMsg delivers logs to the logs view: all of them at once, or the
+// next lines of a followed container log stream
 type fullLogsMsg struct {
 	content string
+	stream  *logFollowStream
+	lines   []string
+	done    bool // the followed stream has ended
 }
 
 type fullInspectMsg struct {
@@ -2195,11 +3514,14 @@ type fullErrMsg struct {
 	err error
 }
 
-type fullStatsMsg struct {
+type containerEnvMsg struct {
 	content string
 }
 
-type tickMsg struct{}
+type containerDiffMsg struct {
+	content string
+	changes int
+}
 
 type dockerConnectionMsg struct {
 	connected bool
@@ -2246,7 +3568,7 @@ func (m *FullModel) renderComposeTab() string {
 	// If no projects are found, show a helpful message
 	if len(m.composeProjects) == 0 {
 		helpStyle := lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#5f87ff")).
+			Foreground(m.styles.Accent).
 			Bold(true).
 			Padding(1)
 
@@ -2265,6 +3587,9 @@ func (m *FullModel) renderComposeTab() string {
 		sb.WriteString(infoStyle.Render("3. Your Docker Compose version might not support the 'ls' command"))
 		sb.WriteString("\n\n")
 		sb.WriteString(infoStyle.Render("Try running 'docker compose ls' in your terminal to verify."))
+		sb.WriteString("\n")
+		sb.WriteString(infoStyle.Render(fmt.Sprintf("Projects that aren't running are looked for in the current directory and composeSearchPaths; press %s to add a directory.",
+			DefaultFullKeyMap.ComposeSearchPath.Help().Key)))
 
 		return sb.String()
 	}
@@ -2274,7 +3599,7 @@ func (m *FullModel) renderComposeTab() string {
 
 // renderComposeInspect renders the compose inspection view in a fancy style
 func (m *FullModel) renderComposeInspect() string {
-	content, updatedContainers := views.ComposeInspect(
+	content, updatedContainers, services := views.ComposeInspect(
 		m.selectedName,
 		m.selectedPath,
 		m.selectedProject,
@@ -2287,9 +3612,12 @@ func (m *FullModel) renderComposeInspect() string {
 		m.containers,
 		m.viewport.Width,
 		m.viewport.Height,
+		m.composeServiceCursor,
 		m.ctx,
 		m.docker,
+		composeKeys(),
 	)
+	m.composeServiceList = services
 
 	// Update the composeContainers with the potentially modified containers
 	if len(updatedContainers) > 0 && len(m.composeContainers) == 0 {
@@ -2297,7 +3625,13 @@ func (m *FullModel) renderComposeInspect() string {
 		m.statusMsg = fmt.Sprintf("Found %d containers for project %s", len(updatedContainers), m.selectedName)
 	}
 
-	return content
+	switch m.inspectView {
+	case inspectViewConfig:
+		content = m.renderComposeConfig()
+	case inspectViewPs:
+		content = m.renderComposePs()
+	}
+	return m.renderComposeViewTabs() + "\n\n" + content
 }
 
 // fetchComposeContainers fetches containers for a Docker Compose project
@@ -2327,13 +3661,17 @@ func (m FullModel) fetchComposeContainers() tea.Msg {
 	}
 }
 
-// Helper function to jump to a specific container
-func (m *FullModel) jumpToContainer(id string) {
+// jumpToContainer selects a container in the Containers tab, by ID or, failing
+// that, by name. Without a name, the one of the compose container with that
+// ID is used.
+func (m *FullModel) jumpToContainer(id, name string) {
 	// First, refresh the container list to ensure we have the latest data
 	containers, err := m.docker.ListContainers(m.ctx, true)
 	if err == nil {
 		m.containers = containers
+		m.refreshRows(ContainersTab)
 	}
+	visible := m.visibleContainers()
 
 	// Switch to Containers tab
 	m.currentTab = ContainersTab
@@ -2342,32 +3680,34 @@ func (m *FullModel) jumpToContainer(id string) {
 	// Find the container in the list and select it
 	foundIndex := -1
 
-	// First try exact ID match
-	for i, container := range m.containers {
-		if strings.HasPrefix(container.ID, id) {
+	// First try exact ID match. The list has short IDs, others may be full ones.
+	for i, container := range visible {
+		if strings.HasPrefix(container.ID, id) || strings.HasPrefix(id, container.ID) {
 			foundIndex = i
 			break
 		}
 	}
 
 	// If not found by ID, try name match (for cases where the ID in compose view might be different)
-	if foundIndex == -1 && len(m.composeContainers) > 0 {
-		// Find the container name from composeContainers
-		var containerName string
-		for _, c := range m.composeContainers {
-			if strings.HasPrefix(c.ID, id) {
-				containerName = c.Name
-				// Handle service name in parentheses
-				if idx := strings.Index(containerName, " ("); idx > 0 {
-					containerName = containerName[:idx]
+	if foundIndex == -1 {
+		containerName := name
+		if containerName == "" {
+			// Find the container name from composeContainers
+			for _, c := range m.composeContainers {
+				if strings.HasPrefix(c.ID, id) {
+					containerName = c.Name
+					// Handle service name in parentheses
+					if idx := strings.Index(containerName, " ("); idx > 0 {
+						containerName = containerName[:idx]
+					}
+					break
 				}
-				break
 			}
 		}
 
 		// If we found a name, look for it in the main containers list
 		if containerName != "" {
-			for i, container := range m.containers {
+			for i, container := range visible {
 				// Some container names have a leading slash that needs to be trimmed
 				name := strings.TrimPrefix(container.Name, "/")
 				if name == containerName {
@@ -2381,9 +3721,8 @@ func (m *FullModel) jumpToContainer(id string) {
 	// If still not found, try a more fuzzy matching approach with container IDs
 	if foundIndex == -1 {
 		// Try matching just the first few characters of the ID
-		for i, container := range m.containers {
-			if len(id) >= 6 && len(container.ID) >= 6 &&
-				strings.EqualFold(container.ID[:6], id[:6]) {
+		for i, container := range visible {
+			if len(id) >= 6 && strings.EqualFold(docker.ShortID(container.ID, 6), id[:6]) {
 				foundIndex = i
 				break
 			}
@@ -2394,75 +3733,72 @@ func (m *FullModel) jumpToContainer(id string) {
 	if foundIndex >= 0 {
 		m.containerTable.SetCursor(foundIndex)
 		m.updateSelection()
-		m.statusMsg = fmt.Sprintf("Selected container: %s", m.containers[foundIndex].Name)
+		m.statusMsg = fmt.Sprintf("Selected container: %s", visible[foundIndex].Name)
+	} else if m.tabFilter(ContainersTab) != filterAll {
+		m.statusMsg = fmt.Sprintf("Container not found in the %s containers. Press %s to change the filter.", m.tabFilter(ContainersTab), DefaultFullKeyMap.Filter.Help().Key)
 	} else {
 		m.statusMsg = fmt.Sprintf("Container not found in main list. Try refreshing.")
 	}
 }
 
-// Add a new method to handle Docker Compose service actions
+// composeServiceAction starts, stops or restarts a single service of the
+// selected Docker Compose project
 func (m FullModel) composeServiceAction(serviceName, action string) tea.Cmd {
-	// Validate that we have a project path and service name
-	if m.selectedProjectPath == "" {
-		return func() tea.Msg {
-			return fullActionResultMsg{
-				success: false,
-				message: "No Docker Compose project path selected",
-				action:  action,
-			}
-		}
+	path := m.selectedProjectPath
+	if path == "" {
+		path = m.selectedPath
 	}
 
-	if serviceName == "" {
-		return func() tea.Msg {
-			return fullActionResultMsg{
-				success: false,
-				message: "No Docker Compose service selected",
-				action:  action,
-			}
+	return func() tea.Msg {
+		if path == "" {
+			return fullActionResultMsg{success: false, message: "No Docker Compose project path selected"}
+		}
+		if serviceName == "" {
+			return fullActionResultMsg{success: false, message: "No Docker Compose service selected"}
 		}
-	}
 
-	return func() tea.Msg {
 		// Create a context with timeout
-		ctx, cancel := context.WithTimeout(m.ctx, 30*time.Second)
+		ctx, cancel := context.WithTimeout(m.ctx, 2*time.Minute)
 		defer cancel()
 
-		// Set status message
-		var verb string
+		if action == "stop" || action == "restart" {
+			m.stops.record(composeStopKey(m.selectedName, serviceName))
+		}
+
+		var err error
 		switch action {
-		case "up":
-			verb = "starting"
-		case "down":
-			verb = "stopping"
-		case "restart":
-			verb = "restarting"
-		case "pull":
-			verb = "pulling"
+		case "start":
+			err = m.docker.ComposeStartService(ctx, path, serviceName)
+		case "stop":
+			err = m.docker.ComposeStopService(ctx, path, serviceName)
 		default:
-			verb = action + "ing"
+			err = m.docker.ComposeServiceAction(ctx, path, serviceName, action)
 		}
-
-		m.statusMsg = fmt.Sprintf("%s Docker Compose service: %s", verb, serviceName)
-
-		// Perform the action
-		err := m.docker.ComposeServiceAction(ctx, m.selectedProjectPath, serviceName, action)
 		if err != nil {
-			return fullActionResultMsg{
-				success: false,
-				message: fmt.Sprintf("Error %s service %s: %v", verb, serviceName, err),
-				action:  action,
-			}
+			return fullActionResultMsg{success: false, message: fmt.Sprintf("Error: %v", err)}
 		}
 
 		return fullActionResultMsg{
 			success: true,
-			message: fmt.Sprintf("Successfully %s service %s", verb, serviceName),
+			message: fmt.Sprintf("Successfully performed %s on service %s", action, serviceName),
 			action:  action,
 		}
 	}
 }
 
+// selectedComposeServiceAction runs a service action on the service selected
+// in the compose inspect view, then reloads the project's services and containers
+func (m *FullModel) selectedComposeServiceAction(action, verb string) tea.Cmd {
+	if m.composeServiceCursor >= len(m.composeServiceList) {
+		m.statusMsg = "No compose service selected"
+		return nil
+	}
+
+	service := m.composeServiceList[m.composeServiceCursor].Name
+	m.statusMsg = fmt.Sprintf("%s service %s...", verb, service)
+	return tea.Sequence(m.composeServiceAction(service, action), refreshInspect)
+}
+
 // Add a method to view details for a specific Docker Compose service
 func (m FullModel) viewComposeService(serviceName string) tea.Cmd {
 	return func() tea.Msg {
@@ -2481,6 +3817,7 @@ func (m FullModel) viewComposeService(serviceName string) tea.Cmd {
 			m.selectedProjectPath,
 			serviceName,
 			m.width,
+			composeKeys(),
 		)
 
 		// Return the content as an inspect message
@@ -2505,7 +3842,7 @@ type composeServiceViewMsg struct {
 }
 
 // Add a helper function to build the compose table model
-func buildComposeTableModel(projects []docker.ComposeInfo, width int) table.Model {
+func (m FullModel) buildComposeTableModel(projects []docker.ComposeInfo, width int) table.Model {
 	// Define columns for the table
 	columns := []table.Column{
 		{Title: "Name", Width: width / 5},
@@ -2535,18 +3872,7 @@ func buildComposeTableModel(projects []docker.ComposeInfo, width int) table.Mode
 		table.WithHeight(len(rows)),
 	)
 
-	// Define table styles
-	s := table.DefaultStyles()
-	s.Header = s.Header.
-		BorderStyle(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("240")).
-		BorderBottom(true).
-		Bold(true)
-	s.Selected = s.Selected.
-		Foreground(lipgloss.Color("229")).
-		Background(lipgloss.Color("57")).
-		Bold(true)
-	t.SetStyles(s)
+	t.SetStyles(m.tableStyles())
 
 	return t
 }
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// fakeDockerCLI puts a docker command on the PATH that prints compose's
+// progress and an error to stderr, then fails
+func fakeDockerCLI(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake docker command is a shell script")
+	}
+
+	dir := t.TempDir()
+	script := `#!/bin/sh
+echo " Container shop-web-1  Creating" >&2
+echo "Error response from daemon: driver failed programming external connectivity: Bind for 0.0.0.0:8080 failed: port is already allocated" >&2
+echo " Container shop-web-1  Created" >&2
+exit 1
+`
+	if err := os.WriteFile(filepath.Join(dir, "docker"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestComposeActionReportsStderr(t *testing.T) {
+	fakeDockerCLI(t)
+
+	m := FullModel{
+		docker:       docker.NewService(nil),
+		ctx:          context.Background(),
+		selectedName: "shop",
+		selectedPath: t.TempDir(),
+	}
+
+	msg, ok := m.composeAction("up")().(fullActionResultMsg)
+	if !ok {
+		t.Fatal("composeAction() didn't return a fullActionResultMsg")
+	}
+	if msg.success {
+		t.Error("composeAction() of a failing command succeeded")
+	}
+	want := "failed to start Docker Compose project: Error response from daemon: driver failed programming external connectivity: Bind for 0.0.0.0:8080 failed: port is already allocated"
+	if msg.message != want {
+		t.Errorf("message = %q, want %q", msg.message, want)
+	}
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Limits for the action history panel
+const (
+	maxHistoryEntries = 200 // results kept, oldest are dropped first
+	historyPageSize   = 15  // results shown at once
+)
+
+// historyEntry is the result of one action, as reported in the status bar
+type historyEntry struct {
+	at      time.Time
+	success bool
+	message string
+}
+
+// actionHistory keeps recent action results so they can be reviewed after
+// the status bar has moved on
+type actionHistory struct {
+	open    bool
+	entries []historyEntry // oldest first
+	offset  int            // entries scrolled past, counting from the newest
+}
+
+// recordAction adds an action result to the history
+func (m *FullModel) recordAction(msg fullActionResultMsg) {
+	m.history.entries = append(m.history.entries, historyEntry{
+		at:      time.Now(),
+		success: msg.success,
+		message: msg.message,
+	})
+	if len(m.history.entries) > maxHistoryEntries {
+		m.history.entries = m.history.entries[len(m.history.entries)-maxHistoryEntries:]
+	}
+}
+
+// WithWarning records a problem found before the UI started, such as a
+// failed startup check, so it can still be reviewed in the history panel
+func (m FullModel) WithWarning(message string) FullModel {
+	m.recordAction(fullActionResultMsg{success: false, message: message})
+	return m
+}
+
+// toggleHistory opens or closes the history panel
+func (m *FullModel) toggleHistory() {
+	m.history.open = !m.history.open
+	m.history.offset = 0
+}
+
+// handleHistoryKey processes key presses while the history panel is open
+func (m *FullModel) handleHistoryKey(msg tea.KeyMsg) tea.Cmd {
+	maxOffset := max(0, len(m.history.entries)-historyPageSize)
+
+	switch {
+	case key.Matches(msg, DefaultFullKeyMap.Up):
+		m.history.offset = max(0, m.history.offset-1)
+	case key.Matches(msg, DefaultFullKeyMap.Down):
+		m.history.offset = min(maxOffset, m.history.offset+1)
+	case key.Matches(msg, DefaultFullKeyMap.PageUp):
+		m.history.offset = max(0, m.history.offset-historyPageSize)
+	case key.Matches(msg, DefaultFullKeyMap.PageDown):
+		m.history.offset = min(maxOffset, m.history.offset+historyPageSize)
+	case key.Matches(msg, DefaultFullKeyMap.History), key.Matches(msg, DefaultFullKeyMap.Back):
+		m.toggleHistory()
+	case key.Matches(msg, DefaultFullKeyMap.Quit):
+		return m.quit()
+	}
+	return nil
+}
+
+// renderHistory renders the history panel, newest results first
+func (m FullModel) renderHistory() string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Accent)
+	timeStyle := lipgloss.NewStyle().Foreground(m.styles.Border)
+	okStyle := lipgloss.NewStyle().Foreground(m.styles.Success)
+	failStyle := lipgloss.NewStyle().Foreground(m.styles.Error)
+	hintStyle := lipgloss.NewStyle().Foreground(m.styles.Muted).Italic(true)
+
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("Action History (%d)", len(m.history.entries))))
+	sb.WriteString("\n\n")
+
+	if len(m.history.entries) == 0 {
+		sb.WriteString("  No actions performed yet\n")
+	}
+
+	shown := 0
+	for i := len(m.history.entries) - 1 - m.history.offset; i >= 0 && shown < historyPageSize; i-- {
+		entry := m.history.entries[i]
+		status := okStyle.Render("✓")
+		message := entry.message
+		if !entry.success {
+			status = failStyle.Render("✗")
+			message = failStyle.Render(message)
+		}
+		sb.WriteString(fmt.Sprintf("  %s %s %s\n", timeStyle.Render(entry.at.Format("15:04:05")), status, message))
+		shown++
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render(fmt.Sprintf("%s scroll • %s/%s close",
+		keyPair(DefaultFullKeyMap.Up, DefaultFullKeyMap.Down), DefaultFullKeyMap.History.Help().Key, DefaultFullKeyMap.Back.Help().Key)))
+	return sb.String()
+}
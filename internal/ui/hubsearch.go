@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/registry"
+)
+
+// hubSearchLimit is how many images a Docker Hub search lists
+const hubSearchLimit = 25
+
+// hubSearchMsg delivers the images found by a Docker Hub search
+type hubSearchMsg struct {
+	term    string
+	results []registry.SearchResult
+	err     error
+}
+
+// promptHubSearch asks for a term to search Docker Hub for
+func (m *FullModel) promptHubSearch() tea.Cmd {
+	return m.openPrompt("Search Docker Hub for:", "", func(m *FullModel, value string) tea.Cmd {
+		term := strings.TrimSpace(value)
+		if term == "" {
+			m.statusMsg = "Cancelled"
+			return nil
+		}
+		m.statusMsg = fmt.Sprintf("Searching Docker Hub for %s...", term)
+		return func() tea.Msg {
+			results, err := m.docker.SearchImages(m.ctx, term, hubSearchLimit)
+			return hubSearchMsg{term: term, results: results, err: err}
+		}
+	})
+}
+
+// handleHubSearch lists the images found, offering to pull the one picked
+func (m *FullModel) handleHubSearch(msg hubSearchMsg) {
+	if msg.err != nil {
+		m.statusMsg = msg.err.Error()
+		return
+	}
+	if len(msg.results) == 0 {
+		m.statusMsg = fmt.Sprintf("No images on Docker Hub match %s", msg.term)
+		return
+	}
+
+	nameWidth := 0
+	for _, result := range msg.results {
+		nameWidth = max(nameWidth, len(result.Name))
+	}
+	descriptionWidth := max(20, m.width-nameWidth-30)
+
+	items := make([]string, len(msg.results))
+	for i, result := range msg.results {
+		official := ""
+		if result.IsOfficial {
+			official = "official"
+		}
+		items[i] = fmt.Sprintf("%-*s %7s %-8s %s", nameWidth, result.Name, fmt.Sprintf("★%d", result.StarCount),
+			official, truncateCell(strings.TrimSpace(result.Description), descriptionWidth))
+	}
+
+	m.statusMsg = fmt.Sprintf("%d images on Docker Hub match %s", len(msg.results), msg.term)
+	m.openPicker(fmt.Sprintf("Docker Hub images matching %q", msg.term), items, 0, func(m *FullModel, index int) tea.Cmd {
+		return m.promptImagePull(msg.results[index].Name + ":latest")
+	})
+}
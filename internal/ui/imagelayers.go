@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/docker/api/types/image"
+)
+
+// highlightedLayers is how many of the largest layers are highlighted
+const highlightedLayers = 3
+
+// imageLayers is the layer history of the inspected image
+type imageLayers struct {
+	items    []image.HistoryResponseItem // newest first
+	cursor   int
+	expanded bool // show the selected layer's full command
+}
+
+// imageLayersMsg carries the layer history of an image
+type imageLayersMsg struct {
+	items []image.HistoryResponseItem
+}
+
+// fetchImageLayers loads the layer history of the selected image
+func (m FullModel) fetchImageLayers() tea.Msg {
+	items, err := m.docker.ImageHistory(m.ctx, m.selectedID)
+	if err != nil {
+		return fullErrMsg{err}
+	}
+	return imageLayersMsg{items: items}
+}
+
+// toggleImageLayers shows the layers of the selected image, or goes back to
+// the inspect output
+func (m *FullModel) toggleImageLayers() tea.Cmd {
+	if m.currentMode == InspectMode && m.inspectView == inspectViewLayers {
+		m.inspectView = inspectViewDefault
+		m.setViewportContent(m.renderInspectContent())
+		m.viewport.GotoTop()
+		return nil
+	}
+	if m.selectedID == "" {
+		m.statusMsg = "No image selected"
+		return nil
+	}
+
+	m.statusMsg = fmt.Sprintf("Loading layers of %s...", m.selectedName)
+	if m.currentMode == InspectMode {
+		return m.fetchImageLayers
+	}
+
+	// Load the inspect output first, so going back from the layers shows it
+	m.currentMode = InspectMode
+	m.inspectView = inspectViewDefault
+	return tea.Sequence(m.inspectResource, m.fetchImageLayers)
+}
+
+// handleImageLayers shows a loaded layer history
+func (m *FullModel) handleImageLayers(msg imageLayersMsg) {
+	if m.currentMode != InspectMode || m.currentTab != ImagesTab {
+		return
+	}
+
+	refresh := m.inspectView == inspectViewLayers
+	m.inspectView = inspectViewLayers
+	m.layers.items = msg.items
+	if !refresh {
+		m.layers.cursor = 0
+		m.layers.expanded = false
+	}
+	m.layers.cursor = min(m.layers.cursor, max(0, len(msg.items)-1))
+	m.setViewportContent(m.renderImageLayers())
+	if !refresh {
+		m.viewport.GotoTop()
+	}
+	m.statusMsg = fmt.Sprintf("%d layers in %s (%s select, %s expand, %s to go back)", len(msg.items), m.selectedName,
+		keyPair(DefaultFullKeyMap.PrevService, DefaultFullKeyMap.NextService), DefaultFullKeyMap.Expand.Help().Key, DefaultFullKeyMap.Layers.Help().Key)
+}
+
+// moveLayerCursor selects another layer, scrolling it into view
+func (m *FullModel) moveLayerCursor(delta int) {
+	if len(m.layers.items) == 0 {
+		return
+	}
+	m.layers.cursor = max(0, min(len(m.layers.items)-1, m.layers.cursor+delta))
+	m.layers.expanded = false
+	m.setViewportContent(m.renderImageLayers())
+
+	// The header takes two lines, then every collapsed layer one
+	line := m.layers.cursor + 2
+	if line < m.viewport.YOffset {
+		m.viewport.SetYOffset(line)
+	} else if bottom := m.viewport.YOffset + m.viewport.Height - 1; line >= bottom {
+		m.viewport.SetYOffset(line - m.viewport.Height + 2)
+	}
+}
+
+// toggleLayerExpanded shows the full command of the selected layer, or shortens it again
+func (m *FullModel) toggleLayerExpanded() {
+	m.layers.expanded = !m.layers.expanded
+	m.setViewportContent(m.renderImageLayers())
+}
+
+// renderImageLayers renders the layers with their size, age and the command
+// that created them, highlighting the largest ones
+func (m FullModel) renderImageLayers() string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Highlight)
+	largeStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Error)
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Emphasis)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.styles.Muted)
+
+	var total int64
+	sizes := make([]int64, 0, len(m.layers.items))
+	for _, item := range m.layers.items {
+		total += item.Size
+		sizes = append(sizes, item.Size)
+	}
+	sort.Slice(sizes, func(i, j int) bool { return sizes[i] > sizes[j] })
+	var threshold int64 = 1
+	if len(sizes) >= highlightedLayers {
+		threshold = max(1, sizes[highlightedLayers-1])
+	}
+
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("Layers (%d, %s in total), newest first:", len(m.layers.items), formatBytes(total))))
+	sb.WriteString("\n\n")
+
+	commandWidth := max(20, m.viewport.Width-40)
+	for i, item := range m.layers.items {
+		size := fmt.Sprintf("%10s", formatBytes(item.Size))
+		if item.Size >= threshold {
+			size = largeStyle.Render(size)
+		}
+		age := fmt.Sprintf("%-15s", humanizeDuration(time.Unix(item.Created, 0)))
+
+		command := strings.TrimPrefix(strings.Join(strings.Fields(item.CreatedBy), " "), "/bin/sh -c #(nop) ")
+		if command == "" {
+			command = "(no command)"
+		}
+
+		marker := "  "
+		if i == m.layers.cursor {
+			marker = "> "
+		}
+		if i == m.layers.cursor && m.layers.expanded {
+			sb.WriteString(fmt.Sprintf("%s%s  %s\n", marker, size, mutedStyle.Render(age)))
+			sb.WriteString(lipgloss.NewStyle().Width(commandWidth + 28).PaddingLeft(4).Render(command))
+			sb.WriteString("\n")
+			continue
+		}
+
+		command = truncateCell(command, commandWidth)
+		if i == m.layers.cursor {
+			command = selectedStyle.Render(command)
+		}
+		sb.WriteString(fmt.Sprintf("%s%s  %s %s\n", marker, size, mutedStyle.Render(age), command))
+	}
+
+	return sb.String()
+}
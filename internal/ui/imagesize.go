@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// sortedImages returns the images largest first while sorting by size is on,
+// otherwise in the order Docker lists them
+func (m FullModel) sortedImages() []docker.ImageInfo {
+	if !m.imagesBySize {
+		return m.images
+	}
+	sorted := slices.Clone(m.images)
+	slices.SortStableFunc(sorted, func(a, b docker.ImageInfo) int {
+		return cmp.Compare(b.Size, a.Size)
+	})
+	return sorted
+}
+
+// toggleImageSort switches the Images tab between sorting by size and
+// Docker's order, keeping the selected image selected
+func (m *FullModel) toggleImageSort() {
+	m.imagesBySize = !m.imagesBySize
+	m.refreshRows(ImagesTab)
+	if m.selectedID != "" {
+		m.selectResource(ImagesTab, m.selectedID)
+	}
+
+	if m.imagesBySize {
+		m.statusMsg = "Sorting images by size, largest first"
+	} else {
+		m.statusMsg = "Showing images in Docker's order"
+	}
+}
+
+// renderImageTotal renders the summary shown under the image list: how many
+// images there are and the sum of their sizes. Layers shared between images
+// count once per image, like the SIZE column of docker images.
+func (m FullModel) renderImageTotal() string {
+	var total int64
+	for _, img := range m.images {
+		total += img.Size
+	}
+
+	summary := fmt.Sprintf("Σ %d images | %s in total", len(m.images), formatBytes(total))
+	if m.imagesBySize {
+		summary += " | sorted by size"
+	}
+	return summary
+}
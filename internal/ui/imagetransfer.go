@@ -0,0 +1,221 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// imageTransfer tracks an image pull or push running in the background,
+// shown in a progress view until it finishes
+type imageTransfer struct {
+	action string // "pull" or "push"
+	ref    string
+	cancel context.CancelFunc
+	done   chan error
+	then   func(m *FullModel) tea.Cmd // run once the transfer succeeds, if set
+
+	mu     sync.Mutex
+	status string // latest progress summary, written by the transfer goroutine
+}
+
+// imageTransferTickMsg asks for the transfer's progress to be checked
+type imageTransferTickMsg struct {
+	transfer *imageTransfer
+}
+
+// pullBeforeCreateMsg reports that a container's image has to be pulled first
+type pullBeforeCreateMsg struct {
+	config docker.ContainerCreateConfig
+}
+
+// setStatus records the latest progress summary
+func (t *imageTransfer) setStatus(status string) {
+	t.mu.Lock()
+	t.status = status
+	t.mu.Unlock()
+}
+
+// currentStatus returns the latest progress summary
+func (t *imageTransfer) currentStatus() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.status
+}
+
+// promptImagePull asks for an image reference, starting from initial, and pulls it
+func (m *FullModel) promptImagePull(initial string) tea.Cmd {
+	return m.openPrompt("Pull image:", initial, func(m *FullModel, value string) tea.Cmd {
+		ref := strings.TrimSpace(value)
+		if ref == "" {
+			m.statusMsg = "Cancelled"
+			return nil
+		}
+		return m.startImagePull(ref, nil)
+	})
+}
+
+// startImagePull pulls an image in the background, showing its progress,
+// and runs then once the pull succeeds
+func (m *FullModel) startImagePull(ref string, then func(m *FullModel) tea.Cmd) tea.Cmd {
+	return m.startImageTransfer("pull", ref, then, func(ctx context.Context, progress func(string)) error {
+		return m.docker.PullImage(ctx, ref, progress)
+	})
+}
+
+// promptImageTag asks for a new repository and tag for the selected image
+func (m *FullModel) promptImageTag() tea.Cmd {
+	if m.selectedID == "" {
+		m.statusMsg = "No image selected"
+		return nil
+	}
+
+	source := m.selectedID
+	return m.openPrompt("Tag as (repo:tag):", m.selectedName, func(m *FullModel, value string) tea.Cmd {
+		target := strings.TrimSpace(value)
+		if target == "" {
+			m.statusMsg = "Cancelled"
+			return nil
+		}
+		return func() tea.Msg {
+			if err := m.docker.TagImage(m.ctx, source, target); err != nil {
+				return fullActionResultMsg{success: false, message: err.Error(), action: "tag"}
+			}
+			return fullActionResultMsg{success: true, message: fmt.Sprintf("Tagged as %s", target), action: "tag"}
+		}
+	})
+}
+
+// promptImagePush asks which tag of the selected image to push, then pushes it
+func (m *FullModel) promptImagePush() tea.Cmd {
+	if m.selectedName == "" || m.selectedName == "<none>:<none>" {
+		m.statusMsg = "Tag the image before pushing it (t)"
+		return nil
+	}
+
+	return m.openPrompt("Push image:", m.selectedName, func(m *FullModel, value string) tea.Cmd {
+		ref := strings.TrimSpace(value)
+		if ref == "" {
+			m.statusMsg = "Cancelled"
+			return nil
+		}
+		return m.startImageTransfer("push", ref, nil, func(ctx context.Context, progress func(string)) error {
+			auth, err := docker.RegistryAuth(ctx, ref)
+			if err != nil {
+				return err
+			}
+			return m.docker.PushImage(ctx, ref, auth, progress)
+		})
+	})
+}
+
+// startImageTransfer runs a pull or push in the background, showing its
+// progress, and runs then once it succeeds
+func (m *FullModel) startImageTransfer(action, ref string, then func(m *FullModel) tea.Cmd, run func(ctx context.Context, progress func(string)) error) tea.Cmd {
+	if m.imageTransfer != nil {
+		m.statusMsg = fmt.Sprintf("Already %s %s (esc to cancel)", m.imageTransfer.verb(), m.imageTransfer.ref)
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	transfer := &imageTransfer{
+		action: action,
+		ref:    ref,
+		cancel: cancel,
+		done:   make(chan error, 1),
+		then:   then,
+	}
+	go func() {
+		transfer.done <- run(ctx, transfer.setStatus)
+	}()
+
+	m.imageTransfer = transfer
+	m.transferView = viewport.New(m.width, max(m.height-12, 5))
+	m.statusMsg = fmt.Sprintf("%s %s... (esc to cancel)", transfer.verb(), ref)
+	return tea.Batch(imageTransferTick(transfer), m.spinner.Tick)
+}
+
+// verb describes the transfer in progress, e.g. "Pulling"
+func (t *imageTransfer) verb() string {
+	if t.action == "push" {
+		return "Pushing"
+	}
+	return "Pulling"
+}
+
+// imageTransferTick schedules the next progress check
+func imageTransferTick(transfer *imageTransfer) tea.Cmd {
+	return tea.Tick(200*time.Millisecond, func(time.Time) tea.Msg {
+		return imageTransferTickMsg{transfer: transfer}
+	})
+}
+
+// handleImageTransferTick shows the latest progress, and closes the progress
+// view once the transfer has finished
+func (m *FullModel) handleImageTransferTick(msg imageTransferTickMsg) tea.Cmd {
+	transfer := msg.transfer
+	if transfer != m.imageTransfer {
+		return nil
+	}
+
+	m.transferView.SetContent(transfer.currentStatus())
+	m.transferView.GotoBottom()
+
+	select {
+	case err := <-transfer.done:
+		m.imageTransfer = nil
+		transfer.cancel()
+		if err != nil {
+			m.statusMsg = fmt.Sprintf("Error: %v", err)
+			return nil
+		}
+		m.statusMsg = fmt.Sprintf("Pulled %s", transfer.ref)
+		if transfer.action == "push" {
+			m.statusMsg = fmt.Sprintf("Pushed %s", transfer.ref)
+		}
+		cmds := []tea.Cmd{m.fetchImages}
+		if transfer.then != nil {
+			cmds = append(cmds, transfer.then(m))
+		}
+		return tea.Batch(cmds...)
+	default:
+		return imageTransferTick(transfer)
+	}
+}
+
+// handleImageTransferKey processes key presses while the progress view is shown
+func (m *FullModel) handleImageTransferKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		transfer := m.imageTransfer
+		m.imageTransfer = nil
+		transfer.cancel()
+		m.statusMsg = fmt.Sprintf("Cancelled %s %s", strings.ToLower(transfer.verb()), transfer.ref)
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.transferView, cmd = m.transferView.Update(msg)
+	return cmd
+}
+
+// renderImageTransfer renders the progress view
+func (m FullModel) renderImageTransfer() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Title)
+	hintStyle := lipgloss.NewStyle().Foreground(m.styles.Muted).Italic(true)
+
+	var sb strings.Builder
+	sb.WriteString(titleStyle.Render(fmt.Sprintf("%s %s %s", m.spinner.View(), m.imageTransfer.verb(), m.imageTransfer.ref)))
+	sb.WriteString("\n\n")
+	sb.WriteString(m.transferView.View())
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render("esc cancel"))
+	return sb.String()
+}
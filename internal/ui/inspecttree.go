@@ -0,0 +1,294 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// jsonNode is a value of the inspect JSON, shown as a line of the tree
+type jsonNode struct {
+	key      string // member name, empty for array elements
+	index    int    // position in the parent array
+	path     string // identifies the node across refreshes of the same resource
+	value    string // JSON text of a scalar
+	kind     byte   // '{' or '[' for objects and arrays, 0 for scalars
+	children []*jsonNode
+}
+
+// inspectTree is the inspect JSON shown as a tree of collapsible objects and arrays
+type inspectTree struct {
+	root     *jsonNode
+	id       string          // the resource the expansion state belongs to
+	expanded map[string]bool // expanded objects and arrays, by path
+	cursor   int             // index into the visible rows
+	raw      bool            // show the flat JSON instead, for the rest of the session
+}
+
+// inspectTreeRow is a node shown in the tree, with how deep it's nested
+type inspectTreeRow struct {
+	node  *jsonNode
+	depth int
+}
+
+// parseJSONTree reads JSON text into a tree, keeping the order of the keys
+func parseJSONTree(content string) (*jsonNode, error) {
+	decoder := json.NewDecoder(strings.NewReader(content))
+	decoder.UseNumber()
+	root := &jsonNode{}
+	if err := decodeJSONNode(decoder, root); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// decodeJSONNode reads the next value of the decoder into node
+func decodeJSONNode(decoder *json.Decoder, node *jsonNode) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		node.kind = byte(t)
+		for decoder.More() {
+			child := &jsonNode{index: len(node.children)}
+			if node.kind == '{' {
+				name, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+				child.key, _ = name.(string)
+				child.path = node.path + "\x00" + child.key
+			} else {
+				child.path = node.path + "\x00" + strconv.Itoa(child.index)
+			}
+			if err := decodeJSONNode(decoder, child); err != nil {
+				return err
+			}
+			node.children = append(node.children, child)
+		}
+		// The closing brace or bracket
+		_, err = decoder.Token()
+		return err
+	case string:
+		// Keep <, > and & readable rather than escaped as \u003c and the like
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(t); err != nil {
+			return err
+		}
+		node.value = strings.TrimSuffix(buf.String(), "\n")
+	case json.Number:
+		node.value = t.String()
+	case bool:
+		node.value = strconv.FormatBool(t)
+	case nil:
+		node.value = "null"
+	}
+	return nil
+}
+
+// loadInspectTree builds the tree of freshly loaded inspect output. Refreshing
+// the same resource keeps what's expanded; another one starts collapsed.
+func (m *FullModel) loadInspectTree(content string) {
+	root, err := parseJSONTree(content)
+	if err != nil {
+		root = nil
+	}
+	m.inspectTree.root = root
+
+	if m.inspectTree.id != m.selectedID || m.inspectTree.expanded == nil {
+		m.inspectTree.id = m.selectedID
+		m.inspectTree.expanded = make(map[string]bool)
+		m.inspectTree.cursor = 0
+	}
+	m.inspectTree.cursor = min(m.inspectTree.cursor, max(0, len(m.inspectTreeRows())-1))
+}
+
+// inspectTreeShown reports whether the inspect output is shown as a tree
+func (m FullModel) inspectTreeShown() bool {
+	if m.currentMode != InspectMode || m.inspectView != inspectViewDefault || m.inspectTree.raw || m.inspectTree.root == nil {
+		return false
+	}
+	switch m.currentTab {
+	case ContainersTab, ImagesTab, VolumesTab, NetworksTab:
+		return true
+	}
+	return false
+}
+
+// inspectTreeRows lists the nodes shown, the members of expanded objects and
+// arrays following them
+func (m FullModel) inspectTreeRows() []inspectTreeRow {
+	var rows []inspectTreeRow
+	var walk func(node *jsonNode, depth int)
+	walk = func(node *jsonNode, depth int) {
+		for _, child := range node.children {
+			rows = append(rows, inspectTreeRow{node: child, depth: depth})
+			if child.kind != 0 && m.inspectTree.expanded[child.path] {
+				walk(child, depth+1)
+			}
+		}
+	}
+	if m.inspectTree.root != nil {
+		walk(m.inspectTree.root, 0)
+	}
+	return rows
+}
+
+// handleInspectTreeKey moves through the tree and expands or collapses the
+// selected object or array. It reports whether the key was used.
+func (m *FullModel) handleInspectTreeKey(msg tea.KeyMsg) bool {
+	rows := m.inspectTreeRows()
+	if len(rows) == 0 {
+		return false
+	}
+
+	page := max(1, m.viewport.Height-2)
+	switch {
+	case key.Matches(msg, DefaultFullKeyMap.Up):
+		m.inspectTree.cursor--
+	case key.Matches(msg, DefaultFullKeyMap.Down):
+		m.inspectTree.cursor++
+	case key.Matches(msg, DefaultFullKeyMap.PageUp):
+		m.inspectTree.cursor -= page
+	case key.Matches(msg, DefaultFullKeyMap.PageDown):
+		m.inspectTree.cursor += page
+	case key.Matches(msg, DefaultFullKeyMap.GoToTop):
+		m.inspectTree.cursor = 0
+	case key.Matches(msg, DefaultFullKeyMap.GoToBottom):
+		m.inspectTree.cursor = len(rows) - 1
+	case key.Matches(msg, DefaultFullKeyMap.Fold):
+		node := rows[m.inspectTree.cursor].node
+		if node.kind == 0 || len(node.children) == 0 {
+			return true
+		}
+		m.inspectTree.expanded[node.path] = !m.inspectTree.expanded[node.path]
+	default:
+		return false
+	}
+
+	m.inspectTree.cursor = max(0, min(len(m.inspectTreeRows())-1, m.inspectTree.cursor))
+	m.setViewportContent(m.renderInspectContent())
+	m.scrollToTreeCursor()
+	return true
+}
+
+// toggleRawInspect switches between the tree and the flat inspect JSON
+func (m *FullModel) toggleRawInspect() {
+	m.inspectTree.raw = !m.inspectTree.raw
+	if m.inspectView != inspectViewDefault || m.currentTab == ComposeTab {
+		return
+	}
+	m.setViewportContent(m.renderInspectContent())
+	if m.inspectTree.raw {
+		m.viewport.GotoTop()
+		m.statusMsg = "Showing the raw JSON"
+	} else {
+		m.scrollToTreeCursor()
+		m.statusMsg = "Showing the JSON as a tree"
+	}
+}
+
+// scrollToTreeCursor scrolls the viewport so the selected node is visible
+func (m *FullModel) scrollToTreeCursor() {
+	// The tree rows come last, one line each before wrapping
+	lines := strings.Split(m.viewportContent, "\n")
+	line := len(lines) - len(m.inspectTreeRows()) + m.inspectTree.cursor
+	if line < 0 || line >= len(lines) {
+		return
+	}
+	if m.lineWrapOn() && line > 0 {
+		line = strings.Count(wrapLines(strings.Join(lines[:line], "\n"), m.wrapWidth(), true), "\n") + 1
+	}
+
+	if line < m.viewport.YOffset {
+		m.viewport.SetYOffset(line)
+	} else if bottom := m.viewport.YOffset + m.viewport.Height - 1; line >= bottom {
+		m.viewport.SetYOffset(line - m.viewport.Height + 2)
+	}
+}
+
+// renderInspectTree renders the visible nodes of the tree, collapsed objects
+// and arrays with how many members they have
+func (m FullModel) renderInspectTree() string {
+	hintStyle := lipgloss.NewStyle().Foreground(m.styles.Muted).Italic(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(m.styles.Muted)
+	keyStyle := lipgloss.NewStyle()
+	if m.config.SyntaxHighlight {
+		keyStyle = keyStyle.Foreground(m.styles.Accent)
+	}
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Emphasis).Background(m.styles.Highlight)
+
+	var sb strings.Builder
+	sb.WriteString(hintStyle.Render(fmt.Sprintf("%s expand/collapse • %s raw JSON",
+		DefaultFullKeyMap.Fold.Help().Key, DefaultFullKeyMap.RawJSON.Help().Key)))
+
+	for i, row := range m.inspectTreeRows() {
+		node := row.node
+		sb.WriteString("\n")
+
+		marker := "  "
+		if i == m.inspectTree.cursor {
+			marker = "> "
+		}
+		sb.WriteString(marker + strings.Repeat("  ", row.depth))
+
+		fold := "  "
+		if node.kind != 0 && len(node.children) > 0 {
+			fold = "▸ "
+			if m.inspectTree.expanded[node.path] {
+				fold = "▾ "
+			}
+		}
+		sb.WriteString(mutedStyle.Render(fold))
+
+		name := fmt.Sprintf("%q:", node.key)
+		if node.key == "" {
+			name = fmt.Sprintf("[%d]", node.index)
+		}
+		if i == m.inspectTree.cursor {
+			sb.WriteString(selectedStyle.Render(name))
+		} else {
+			sb.WriteString(keyStyle.Render(name))
+		}
+		sb.WriteString(" ")
+
+		switch {
+		case node.kind == 0:
+			if m.config.SyntaxHighlight {
+				sb.WriteString(m.highlightJSON(node.value))
+			} else {
+				sb.WriteString(node.value)
+			}
+		case len(node.children) == 0 && node.kind == '{':
+			sb.WriteString("{}")
+		case len(node.children) == 0:
+			sb.WriteString("[]")
+		case m.inspectTree.expanded[node.path]:
+			sb.WriteString(string(node.kind))
+		case node.kind == '{':
+			sb.WriteString(mutedStyle.Render(fmt.Sprintf("{…} %d %s", len(node.children), countNoun(len(node.children), "key"))))
+		default:
+			sb.WriteString(mutedStyle.Render(fmt.Sprintf("[…] %d %s", len(node.children), countNoun(len(node.children), "item"))))
+		}
+	}
+	return sb.String()
+}
+
+// countNoun returns noun in the plural unless count is one
+func countNoun(count int, noun string) string {
+	if count == 1 {
+		return noun
+	}
+	return noun + "s"
+}
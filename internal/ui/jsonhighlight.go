@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// jsonToken is the kind of a piece of JSON text, which decides its color
+type jsonToken int
+
+const (
+	jsonPunctuation jsonToken = iota // braces, brackets, colons, commas and whitespace
+	jsonKey
+	jsonString
+	jsonNumber
+	jsonBool
+	jsonNull
+)
+
+// highlightJSON colors the keys, strings, numbers, booleans and nulls of
+// JSON text with the theme's colors. Anything that isn't valid JSON, such
+// as a message shown instead of the inspect output, is returned as it is.
+func (m FullModel) highlightJSON(content string) string {
+	if !json.Valid([]byte(content)) {
+		return content
+	}
+
+	styles := map[jsonToken]lipgloss.Style{
+		jsonKey:    lipgloss.NewStyle().Foreground(m.styles.Accent),
+		jsonString: lipgloss.NewStyle().Foreground(m.styles.Success),
+		jsonNumber: lipgloss.NewStyle().Foreground(m.styles.Warning),
+		jsonBool:   lipgloss.NewStyle().Foreground(m.styles.Special),
+		jsonNull:   lipgloss.NewStyle().Foreground(m.styles.Muted),
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(content) * 2)
+	tokenizeJSON(content, func(token jsonToken, text string) {
+		if style, ok := styles[token]; ok {
+			sb.WriteString(style.Render(text))
+		} else {
+			sb.WriteString(text)
+		}
+	})
+	return sb.String()
+}
+
+// toggleSyntaxHighlight turns the JSON colors of the inspect views on or off
+// for this session; syntaxHighlight in the config sets how docker-tea starts
+func (m *FullModel) toggleSyntaxHighlight() {
+	m.config.SyntaxHighlight = !m.config.SyntaxHighlight
+	if m.inspectView == inspectViewDefault && m.currentTab != ComposeTab {
+		m.setViewportContent(m.renderInspectContent())
+	}
+	if m.config.SyntaxHighlight {
+		m.statusMsg = "Coloring the inspect JSON"
+	} else {
+		m.statusMsg = "Showing the inspect JSON uncolored"
+	}
+}
+
+// tokenizeJSON splits valid JSON text into pieces, calling emit for each
+// with its kind. Joined back together the pieces give the text unchanged.
+func tokenizeJSON(content string, emit func(token jsonToken, text string)) {
+	for i := 0; i < len(content); {
+		switch c := content[i]; {
+		case c == '"':
+			end := i + 1
+			for end < len(content) && content[end] != '"' {
+				if content[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end = min(end+1, len(content))
+
+			// A string followed by a colon is an object key
+			token := jsonString
+			if rest := strings.TrimLeft(content[end:], " \t\r\n"); strings.HasPrefix(rest, ":") {
+				token = jsonKey
+			}
+			emit(token, content[i:end])
+			i = end
+
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(content) && strings.IndexByte("0123456789.eE+-", content[end]) >= 0 {
+				end++
+			}
+			emit(jsonNumber, content[i:end])
+			i = end
+
+		case strings.HasPrefix(content[i:], "true"):
+			emit(jsonBool, "true")
+			i += len("true")
+		case strings.HasPrefix(content[i:], "false"):
+			emit(jsonBool, "false")
+			i += len("false")
+		case strings.HasPrefix(content[i:], "null"):
+			emit(jsonNull, "null")
+			i += len("null")
+
+		default:
+			// Keep runs of punctuation and whitespace together
+			end := i + 1
+			for end < len(content) && strings.IndexByte("{}[]:, \t\r\n", content[end]) >= 0 {
+				end++
+			}
+			emit(jsonPunctuation, content[i:end])
+			i = end
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/klejdi94/docker-tea/internal/config"
+	"github.com/klejdi94/docker-tea/internal/ui/views"
+)
+
+// builtinKeyMap holds the default keys, which the config's keybindings are
+// applied on top of
+var builtinKeyMap = DefaultFullKeyMap
+
+// globalKeyActions work everywhere, so their keys can't be used by any other action
+var globalKeyActions = []string{
+	"quit", "help", "switchContext", "switchTheme", "hardRefresh", "history", "openAppLog", "openLogExport",
+	"reloadConfig", "autoRefresh", "diskUsage", "systemInfo", "pruneAll", "dashboard",
+	"up", "down", "pageUp", "pageDown", "goToTop", "goToBottom", "nextTab", "prevTab",
+	"refresh", "back",
+}
+
+// inspectKeyActions work in the inspect view of every tab
+var inspectKeyActions = []string{
+	"search", "nextMatch", "prevMatch", "matchCase", "wrapLines", "highlight", "fold", "rawJSON", "copy",
+}
+
+// keyContexts lists the actions available together in each part of the UI,
+// on top of the global ones. An action can be in several contexts; keys may
+// only be shared by actions that never are. The inspect view of a tab keeps
+// most of the tab's actions, so each gets a context of its own.
+var keyContexts = map[string][]string{
+	"containers": {
+		"filter", "search", "inspect", "logs", "monitor", "export", "prune",
+		"start", "stop", "timeout", "policy", "restart", "pause", "resume", "kill", "remove", "env", "top", "diff",
+		"commit", "limits", "clone", "new", "rename", "removeOrphans", "serviceTail",
+	},
+	"images": {
+		"filter", "search", "inspect", "export", "prune", "remove",
+		"pullImage", "tagImage", "pushImage", "layers", "expand", "searchHub", "login", "sortSize", "prevService", "nextService",
+	},
+	"volumes": {
+		"filter", "search", "inspect", "export", "prune", "remove", "createVolume",
+	},
+	"networks": {
+		"filter", "search", "inspect", "export", "remove",
+		"createNetwork", "connectNetwork", "disconnectNetwork", "prevService", "nextService",
+	},
+	"compose": {
+		"filter", "search", "inspect", "logs", "export", "start", "stop", "restart",
+		"composeUp", "composeDown", "composePull", "composeTail", "serviceTail", "composeScale",
+		"composeExec", "composeOverrides", "composeView", "prevService", "nextService", "composeServiceLogs", "composeSearchPath",
+	},
+	"container inspect view": slices.Concat(inspectKeyActions, []string{
+		"logs", "monitor", "export", "start", "stop", "timeout", "policy", "restart", "pause", "resume", "kill", "remove",
+		"env", "top", "diff", "commit", "limits", "clone", "serviceTail",
+	}),
+	"image inspect view": slices.Concat(inspectKeyActions, []string{
+		"export", "remove", "tagImage", "pushImage", "layers", "expand", "prevService", "nextService",
+	}),
+	"volume inspect view": slices.Concat(inspectKeyActions, []string{
+		"export", "remove",
+	}),
+	"network inspect view": slices.Concat(inspectKeyActions, []string{
+		"export", "remove", "connectNetwork", "disconnectNetwork", "prevService", "nextService",
+	}),
+	"compose inspect view": slices.Concat(inspectKeyActions, []string{
+		"logs", "export", "start", "stop", "restart", "composeUp", "composeDown", "composePull", "composeTail", "serviceTail",
+		"composeScale", "composeExec", "composeOverrides", "composeView", "prevService", "nextService", "composeServiceLogs",
+		"composeContainer",
+	}),
+	"logs view": {
+		"search", "nextMatch", "prevMatch", "matchCase", "wrapLines", "copy",
+		"logGrep", "moreContext", "lessContext", "downloadLogs", "followLogs", "cycleLogTail", "logStreams", "timestamps", "logWindow", "soloService",
+	},
+}
+
+// tabKeyContexts are the contexts custom actions run in on each tab: its
+// list and its inspect view
+var tabKeyContexts = map[string][]string{
+	"containers": {"containers", "container inspect view"},
+	"images":     {"images", "image inspect view"},
+	"volumes":    {"volumes", "volume inspect view"},
+	"networks":   {"networks", "network inspect view"},
+	"compose":    {"compose", "compose inspect view"},
+}
+
+func init() {
+	config.BuiltinKeys = builtinKeys
+}
+
+// keyActions returns the bindings of a keymap by action name, the field name
+// starting with a lowercase letter, e.g. "composeUp"
+func keyActions(km *FullKeyMap) map[string]*key.Binding {
+	actions := make(map[string]*key.Binding)
+	value := reflect.ValueOf(km).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		name := []rune(value.Type().Field(i).Name)
+		name[0] = unicode.ToLower(name[0])
+		actions[string(name)] = value.Field(i).Addr().Interface().(*key.Binding)
+	}
+	return actions
+}
+
+// ApplyKeyBindings replaces the default keys of the actions named in the
+// config's keybindings. Nothing changes if an action is unknown or two
+// actions used in the same context end up sharing a key.
+func ApplyKeyBindings(bindings map[string]config.KeyList) error {
+	km := builtinKeyMap
+	actions := keyActions(&km)
+
+	var errs []string
+	for name, keys := range bindings {
+		binding, ok := actions[name]
+		if !ok {
+			errs = append(errs, fmt.Sprintf("unknown action %q", name))
+			continue
+		}
+		binding.SetKeys(keys...)
+		binding.SetHelp(keys[0], binding.Help().Desc)
+	}
+	errs = append(errs, keyConflicts(actions)...)
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return errors.New(strings.Join(errs, "; "))
+	}
+	DefaultFullKeyMap = km
+	return nil
+}
+
+// builtinKeys returns the actions bound to each key on a tab once the
+// config's keybindings are applied, for checking custom actions against
+func builtinKeys(bindings map[string]config.KeyList, tab string) map[string][]string {
+	km := builtinKeyMap
+	actions := keyActions(&km)
+	for name, keys := range bindings {
+		if binding, ok := actions[name]; ok {
+			binding.SetKeys(keys...)
+		}
+	}
+
+	owners := make(map[string][]string)
+	for _, context := range tabKeyContexts[tab] {
+		for _, name := range slices.Concat(globalKeyActions, keyContexts[context]) {
+			for _, k := range actions[name].Keys() {
+				if !slices.Contains(owners[k], name) {
+					owners[k] = append(owners[k], name)
+				}
+			}
+		}
+	}
+	for _, names := range owners {
+		sort.Strings(names)
+	}
+	return owners
+}
+
+// keyConflicts describes every key bound to more than one action of a context
+func keyConflicts(actions map[string]*key.Binding) []string {
+	var conflicts []string
+	seen := make(map[string]bool)
+	for context, names := range keyContexts {
+		owners := make(map[string][]string)
+		for _, name := range append(append([]string{}, globalKeyActions...), names...) {
+			for _, k := range actions[name].Keys() {
+				if !slices.Contains(owners[k], name) {
+					owners[k] = append(owners[k], name)
+				}
+			}
+		}
+
+		for k, names := range owners {
+			if len(names) < 2 {
+				continue
+			}
+			sort.Strings(names)
+			conflict := fmt.Sprintf("%q is bound to %s", k, strings.Join(names, " and "))
+			if !isGlobalConflict(names) {
+				conflict += " in " + context
+			}
+			if !seen[conflict] {
+				seen[conflict] = true
+				conflicts = append(conflicts, conflict)
+			}
+		}
+	}
+	return conflicts
+}
+
+// isGlobalConflict reports whether all the actions sharing a key are global,
+// so the conflict is the same in every context
+func isGlobalConflict(names []string) bool {
+	for _, name := range names {
+		if !slices.Contains(globalKeyActions, name) {
+			return false
+		}
+	}
+	return true
+}
+
+// keyHint describes an action for the help and hints, e.g. "l: Logs", with
+// the key it's bound to now
+func keyHint(binding key.Binding, desc string) string {
+	return fmt.Sprintf("%s: %s", binding.Help().Key, desc)
+}
+
+// keyPair joins the keys of two related actions, e.g. "[/]"
+func keyPair(a, b key.Binding) string {
+	return a.Help().Key + "/" + b.Help().Key
+}
+
+// composeKeys returns the keys of the compose actions the compose views point to
+func composeKeys() views.ComposeKeys {
+	km := DefaultFullKeyMap
+	return views.ComposeKeys{
+		Up:        km.ComposeUp.Help().Key,
+		Down:      km.ComposeDown.Help().Key,
+		Pull:      km.ComposePull.Help().Key,
+		Restart:   km.Restart.Help().Key,
+		Container: km.ComposeContainer.Help().Key,
+	}
+}
@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// killSignals are the signals offered when killing a container, the default
+// SIGKILL first
+var killSignals = []struct {
+	name, use string
+}{
+	{"SIGKILL", "stop it right away"},
+	{"SIGTERM", "ask it to shut down"},
+	{"SIGINT", "interrupt it"},
+	{"SIGHUP", "reload its config, e.g. nginx"},
+	{"SIGQUIT", "quit, dumping core"},
+	{"SIGUSR1", "application-defined"},
+	{"SIGUSR2", "application-defined"},
+}
+
+// pickKillSignal asks which signal to send to the selected container, then sends it
+func (m *FullModel) pickKillSignal() {
+	if m.selectedID == "" {
+		m.statusMsg = "No container selected"
+		return
+	}
+
+	items := make([]string, len(killSignals))
+	for i, signal := range killSignals {
+		items[i] = fmt.Sprintf("%-8s %s", signal.name, signal.use)
+	}
+
+	id, name := m.selectedID, m.selectedName
+	inspecting := m.currentMode == InspectMode
+	m.openPicker(fmt.Sprintf("Send a signal to %s", name), items, 0, func(m *FullModel, index int) tea.Cmd {
+		signal := killSignals[index].name
+		m.stops.record(containerStopKey(id))
+		m.statusMsg = fmt.Sprintf("Sending %s to %s...", signal, name)
+
+		kill := func() tea.Msg {
+			if err := m.docker.KillContainer(m.ctx, id, signal); err != nil {
+				return fullActionResultMsg{success: false, message: err.Error()}
+			}
+			return fullActionResultMsg{success: true, message: fmt.Sprintf("Sent %s to %s", signal, name), action: "kill"}
+		}
+		if !inspecting {
+			return kill
+		}
+
+		// A container sent SIGKILL is gone, others may well keep running
+		after := "inspect"
+		if signal == "SIGKILL" {
+			after = "list"
+		}
+		return tea.Batch(kill, func() tea.Msg {
+			return afterActionMsg{action: after}
+		})
+	})
+}
@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/go-units"
+)
+
+// minMemoryLimit is the smallest memory limit Docker accepts
+const minMemoryLimit = 6 * 1024 * 1024
+
+// Fields of the resource limits form
+const (
+	limitsFormMemory = iota
+	limitsFormCPUShares
+)
+
+// containerLimitsMsg carries the current resource limits of a container
+type containerLimitsMsg struct {
+	id, name  string
+	memory    int64
+	cpuShares int64
+	err       error
+}
+
+// loadContainerLimits reads the current limits of the selected container to
+// fill in the limits form
+func (m *FullModel) loadContainerLimits() tea.Cmd {
+	if m.selectedID == "" {
+		m.statusMsg = "No container selected"
+		return nil
+	}
+
+	id, name := m.selectedID, m.selectedName
+	m.statusMsg = fmt.Sprintf("Reading resource limits of %s...", name)
+	return func() tea.Msg {
+		memory, cpuShares, err := m.docker.ContainerResources(m.ctx, id)
+		return containerLimitsMsg{id: id, name: name, memory: memory, cpuShares: cpuShares, err: err}
+	}
+}
+
+// openLimitsForm opens the form for changing the limits of a container,
+// filled in with the current ones
+func (m *FullModel) openLimitsForm(msg containerLimitsMsg) tea.Cmd {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", msg.err)
+		return nil
+	}
+
+	cpuShares := ""
+	if msg.cpuShares > 0 {
+		cpuShares = strconv.FormatInt(msg.cpuShares, 10)
+	}
+	fields := []formField{
+		limitsFormMemory:    textField("Memory", "e.g. 512m or 2g, blank to keep", formatMemoryLimit(msg.memory)),
+		limitsFormCPUShares: textField("CPU shares", "e.g. 512, 1024 is the default weight", cpuShares),
+	}
+	m.statusMsg = fmt.Sprintf("Current limits of %s: memory %s, CPU shares %s", msg.name,
+		orDefault(formatMemoryLimit(msg.memory), "unlimited"), orDefault(cpuShares, "default (1024)"))
+	return m.openResourceForm(fmt.Sprintf("Resource limits of %s", msg.name), fields, validateLimitsForm,
+		func(m *FullModel, values []string) tea.Cmd {
+			return updateContainerLimits(m, msg.id, msg.name, values)
+		})
+}
+
+// validateLimitsForm checks that the memory limit is one Docker accepts and
+// the CPU shares are a positive number
+func validateLimitsForm(values []string) []string {
+	errs := make([]string, len(values))
+	if value := values[limitsFormMemory]; value != "" {
+		memory, err := units.RAMInBytes(value)
+		if err != nil {
+			errs[limitsFormMemory] = "must be a size such as 512m or 2g"
+		} else if memory < minMemoryLimit {
+			errs[limitsFormMemory] = "must be at least 6m, Docker's minimum"
+		}
+	}
+	if value := values[limitsFormCPUShares]; value != "" {
+		if shares, err := strconv.ParseInt(value, 10, 64); err != nil || shares < 2 {
+			errs[limitsFormCPUShares] = "must be a whole number of at least 2"
+		}
+	}
+	if values[limitsFormMemory] == "" && values[limitsFormCPUShares] == "" {
+		errs[limitsFormMemory] = "set a memory limit, CPU shares or both"
+	}
+	return errs
+}
+
+// updateContainerLimits applies the submitted limits, then refreshes the
+// inspect or monitor view so they show the new ones
+func updateContainerLimits(m *FullModel, id, name string, values []string) tea.Cmd {
+	// Both values have been validated; blank ones are left unchanged
+	memory, _ := units.RAMInBytes(values[limitsFormMemory])
+	cpuShares, _ := strconv.ParseInt(values[limitsFormCPUShares], 10, 64)
+
+	refresh := refreshInspect
+	if m.currentMode == MonitorMode {
+		refresh = func() tea.Msg { return afterActionMsg{action: "monitor"} }
+	}
+
+	m.statusMsg = fmt.Sprintf("Updating resource limits of %s...", name)
+	return tea.Sequence(func() tea.Msg {
+		if err := m.docker.UpdateContainerResources(m.ctx, id, memory, cpuShares); err != nil {
+			return fullActionResultMsg{success: false, message: fmt.Sprintf("Error: %v", err)}
+		}
+		return fullActionResultMsg{success: true, message: fmt.Sprintf("Updated resource limits of %s (memory %s, CPU shares %s)",
+			name, orDefault(values[limitsFormMemory], "unchanged"), orDefault(values[limitsFormCPUShares], "unchanged"))}
+	}, refresh)
+}
+
+// formatMemoryLimit formats a memory limit in bytes the way it's typed into
+// the limits form, or returns an empty string if there is no limit
+func formatMemoryLimit(bytes int64) string {
+	if bytes <= 0 {
+		return ""
+	}
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"g", units.GiB}, {"m", units.MiB}, {"k", units.KiB}} {
+		if bytes%unit.size == 0 {
+			return fmt.Sprintf("%d%s", bytes/unit.size, unit.suffix)
+		}
+	}
+	return strconv.FormatInt(bytes, 10)
+}
+
+// orDefault returns value, or fallback if it's empty
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// wrapContinuationIndent is how much deeper than its line's own indentation
+// the rest of a wrapped inspect line starts, so it reads as a continuation
+const wrapContinuationIndent = 2
+
+// defaultLineWrap is whether long lines are wrapped in each viewport mode
+// until the user flips it: inspect output wraps, logs keep a line per entry
+func defaultLineWrap() map[Mode]bool {
+	return map[Mode]bool{InspectMode: true, LogsMode: false}
+}
+
+// lineWrapOn reports whether the viewport content is wrapped in the current mode
+func (m FullModel) lineWrapOn() bool {
+	// The compose ps table has its own layout, which wrapping would break
+	if m.currentMode == InspectMode && m.inspectView == inspectViewPs {
+		return false
+	}
+	return m.lineWrap[m.currentMode]
+}
+
+// toggleLineWrap flips line wrapping for the current mode and re-flows the viewport
+func (m *FullModel) toggleLineWrap() {
+	m.lineWrap[m.currentMode] = !m.lineWrap[m.currentMode]
+	m.setViewportContent(m.viewportContent)
+	if m.lineWrap[m.currentMode] {
+		m.statusMsg = "Wrapping long lines"
+	} else {
+		m.statusMsg = "Long lines are cut off at the edge"
+	}
+}
+
+// wrapWidth is the width the viewport content can take up, inside its border
+// and padding
+func (m FullModel) wrapWidth() int {
+	return m.viewport.Width - m.viewport.Style.GetHorizontalFrameSize()
+}
+
+// wrapLines re-flows every line wider than width. With keepIndent, as for
+// the inspect JSON, the rest of a line starts under its own indentation.
+func wrapLines(content string, width int, keepIndent bool) string {
+	if width <= 0 {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	wrapped := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if ansi.StringWidth(line) <= width {
+			wrapped = append(wrapped, line)
+			continue
+		}
+
+		indent := 0
+		if keepIndent {
+			plain := ansi.Strip(line)
+			indent = len(plain) - len(strings.TrimLeft(plain, " ")) + wrapContinuationIndent
+			// Deeply nested lines would have no room left, so start those at the edge
+			if indent > width/2 {
+				indent = 0
+			}
+		}
+
+		parts := strings.Split(ansi.Wrap(line, width-indent, ""), "\n")
+		wrapped = append(wrapped, parts[0])
+		for _, part := range parts[1:] {
+			wrapped = append(wrapped, strings.Repeat(" ", indent)+strings.TrimLeft(part, " "))
+		}
+	}
+	return strings.Join(wrapped, "\n")
+}
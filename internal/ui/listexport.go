@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klejdi94/docker-tea/internal/ui/views"
+)
+
+// listExportFormats are the formats a resource list can be exported in, in
+// the order they're offered
+var listExportFormats = []struct {
+	name, use string
+}{
+	{"csv", "one row per resource, for spreadsheets"},
+	{"json", "an array of objects, for scripts and diffing"},
+}
+
+// pickListExportFormat asks which format to export the current tab's list
+// in, then writes it to a file
+func (m *FullModel) pickListExportFormat() {
+	if m.currentTab == EventsTab {
+		m.statusMsg = "Only resource lists can be exported"
+		return
+	}
+
+	items := make([]string, len(listExportFormats))
+	for i, format := range listExportFormats {
+		items[i] = fmt.Sprintf("%-5s %s", strings.ToUpper(format.name), format.use)
+	}
+
+	m.openPicker(fmt.Sprintf("Export the %s list as", tabStateKeys[m.currentTab]), items, 0, func(m *FullModel, index int) tea.Cmd {
+		format := listExportFormats[index].name
+		snapshot := *m
+		m.statusMsg = fmt.Sprintf("Exporting the %s list...", tabStateKeys[m.currentTab])
+		return func() tea.Msg {
+			path, err := snapshot.exportCurrentList(format)
+			if err != nil {
+				return fullActionResultMsg{success: false, message: fmt.Sprintf("Export failed: %v", err)}
+			}
+			return fullActionResultMsg{success: true, message: fmt.Sprintf("Exported the %s list to %s", tabStateKeys[snapshot.currentTab], path)}
+		}
+	})
+}
+
+// exportCurrentList writes every resource of the current tab, whatever the
+// filters show, to a timestamped file in the working directory, as "csv" or
+// "json". It returns the file's path.
+func (m FullModel) exportCurrentList(format string) (string, error) {
+	columns, rows := m.listExportRows()
+	if columns == nil {
+		return "", fmt.Errorf("the %s tab has no list to export", tabStateKeys[m.currentTab])
+	}
+
+	var data []byte
+	switch format {
+	case "csv":
+		var buf bytes.Buffer
+		writer := csv.NewWriter(&buf)
+		_ = writer.Write(columns)
+		for _, row := range rows {
+			record := make([]string, len(row))
+			for i, value := range row {
+				record[i] = csvCell(value)
+			}
+			_ = writer.Write(record)
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return "", err
+		}
+		data = buf.Bytes()
+
+	case "json":
+		objects := make([]map[string]any, len(rows))
+		for i, row := range rows {
+			objects[i] = make(map[string]any, len(columns))
+			for j, column := range columns {
+				objects[i][column] = row[j]
+			}
+		}
+		encoded, err := json.MarshalIndent(objects, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		data = append(encoded, '\n')
+
+	default:
+		return "", fmt.Errorf("unknown export format %q", format)
+	}
+
+	name := fmt.Sprintf("%s-%s.%s", tabStateKeys[m.currentTab], time.Now().Format("20060102-150405"), format)
+	return writeExportFile(name, data)
+}
+
+// listExportRows returns the columns exported for the current tab and a row
+// of values for each of its resources. Sizes are in bytes and times are left
+// as they are, so JSON gets numbers and timestamps rather than display text.
+func (m FullModel) listExportRows() ([]string, [][]any) {
+	var rows [][]any
+	switch m.currentTab {
+	case ContainersTab:
+		for _, c := range m.containers {
+			rows = append(rows, []any{c.ID, c.Name, c.Image, c.State, c.Status, views.FormatPorts(c.Ports), exportTime(c.Created)})
+		}
+		return []string{"id", "name", "image", "state", "status", "ports", "created"}, rows
+	case ImagesTab:
+		for _, img := range m.images {
+			rows = append(rows, []any{img.RepoTags, img.Size, img.ID, exportTime(img.CreatedAt)})
+		}
+		return []string{"repoTags", "size", "id", "created"}, rows
+	case VolumesTab:
+		for _, v := range m.volumes {
+			rows = append(rows, []any{v.Name, v.Driver, v.Mountpoint, exportTime(v.CreatedAt)})
+		}
+		return []string{"name", "driver", "mountpoint", "created"}, rows
+	case NetworksTab:
+		for _, n := range m.networks {
+			rows = append(rows, []any{n.ID, n.Name, n.Driver, n.Scope, len(n.Containers)})
+		}
+		return []string{"id", "name", "driver", "scope", "containers"}, rows
+	case ComposeTab:
+		for _, p := range m.composeProjects {
+			rows = append(rows, []any{p.Name, p.Status, p.Path, p.Services, p.ConfigFiles})
+		}
+		return []string{"name", "status", "path", "services", "configFiles"}, rows
+	}
+	return nil, nil
+}
+
+// exportTime returns t, or nil when Docker didn't report the time
+func exportTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+// csvCell formats an exported value for a CSV cell; lists are joined with
+// commas and times written as RFC 3339
+func csvCell(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(v, ", ")
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
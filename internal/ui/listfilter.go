@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// matchText keeps the items for which any of the fields contains the query,
+// ignoring case. An empty query keeps everything.
+func matchText[T any](items []T, query string, fields func(T) []string) []T {
+	if query == "" {
+		return items
+	}
+	query = strings.ToLower(query)
+
+	var matched []T
+	for _, item := range items {
+		for _, field := range fields(item) {
+			if strings.Contains(strings.ToLower(field), query) {
+				matched = append(matched, item)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// Fields searched by the list filter on each tab
+func containerText(c docker.ContainerInfo) []string { return []string{c.Name, c.Image, c.ID} }
+func imageText(img docker.ImageInfo) []string       { return append([]string{img.ID}, img.RepoTags...) }
+func volumeText(v docker.VolumeInfo) []string       { return []string{v.Name} }
+func networkText(n docker.NetworkInfo) []string     { return []string{n.Name, n.ID} }
+func composeText(p docker.ComposeInfo) []string     { return []string{p.Name, p.Path} }
+
+// openListFilter opens the filter prompt, narrowing the current tab's rows as the user types
+func (m *FullModel) openListFilter() tea.Cmd {
+	tab := m.currentTab
+	cmd := m.openPrompt("filter:", m.listFilter[tab], func(m *FullModel, value string) tea.Cmd {
+		m.setListFilter(tab, strings.TrimSpace(value))
+		return nil
+	})
+	m.prompt.onChange = func(m *FullModel, value string) {
+		m.setListFilter(tab, strings.TrimSpace(value))
+	}
+	m.prompt.onCancel = func(m *FullModel) {
+		m.setListFilter(tab, "")
+	}
+	return cmd
+}
+
+// setListFilter changes a tab's text filter and rebuilds its rows
+func (m *FullModel) setListFilter(tab Tab, query string) {
+	if m.listFilter[tab] == query {
+		return
+	}
+	m.listFilter[tab] = query
+
+	// Row positions change with the filter, so start again from the top
+	if tab == EventsTab {
+		m.eventLog.offset = 0
+	} else {
+		m.getCurrentTable().SetCursor(0)
+		m.refreshRows(tab)
+		m.updateSelection()
+	}
+
+	if query == "" {
+		m.statusMsg = "Filter cleared"
+	}
+}
+
+// listFilterSummary describes the current tab's text filter for the footer
+func (m FullModel) listFilterSummary() string {
+	query := m.listFilter[m.currentTab]
+	if query == "" {
+		return ""
+	}
+	shown := len(m.getCurrentTable().Rows())
+	if m.currentTab == EventsTab {
+		shown = len(m.visibleEvents())
+	}
+	return fmt.Sprintf("filter %q: %d shown (esc to clear)", query, shown)
+}
@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxLogViewBytes caps how much of a downloaded log is loaded into the viewer.
+// The full log stays available in the downloaded file.
+const maxLogViewBytes = 20 * 1024 * 1024
+
+// logDownload tracks a full log download running in the background
+type logDownload struct {
+	name    string
+	path    string
+	cancel  context.CancelFunc
+	written atomic.Int64
+	done    chan error
+}
+
+// logDownloadTickMsg asks for the download's progress to be checked
+type logDownloadTickMsg struct {
+	download *logDownload
+}
+
+// logFileLoadedMsg carries the tail of a downloaded log, ready for the viewer
+type logFileLoadedMsg struct {
+	path      string
+	content   string
+	truncated bool
+	err       error
+}
+
+// startLogDownload downloads the selected container's full log history to a
+// temporary file without blocking the UI
+func (m *FullModel) startLogDownload() tea.Cmd {
+	if m.logDownload != nil {
+		m.statusMsg = "A log download is already running (esc to cancel)"
+		return nil
+	}
+
+	file, err := os.CreateTemp("", "docker-tea-"+sanitizeFileName(m.selectedName)+"-*.log")
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	download := &logDownload{
+		name:   m.selectedName,
+		path:   file.Name(),
+		cancel: cancel,
+		done:   make(chan error, 1),
+	}
+
+	containerID := m.selectedID
+	go func() {
+		err := m.docker.DownloadContainerLogs(ctx, containerID, file, func(written int64) {
+			download.written.Store(written)
+		})
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		download.done <- err
+	}()
+
+	m.logDownload = download
+	m.statusMsg = fmt.Sprintf("Downloading full logs for %s... (esc to cancel)", download.name)
+	return logDownloadTick(download)
+}
+
+// logDownloadTick schedules the next progress check
+func logDownloadTick(download *logDownload) tea.Cmd {
+	return tea.Tick(200*time.Millisecond, func(time.Time) tea.Msg {
+		return logDownloadTickMsg{download: download}
+	})
+}
+
+// handleLogDownloadTick reports progress, and loads the log once it's complete
+func (m *FullModel) handleLogDownloadTick(msg logDownloadTickMsg) tea.Cmd {
+	download := msg.download
+	if download != m.logDownload {
+		return nil
+	}
+
+	select {
+	case err := <-download.done:
+		m.logDownload = nil
+		download.cancel()
+		if err != nil {
+			os.Remove(download.path)
+			m.statusMsg = fmt.Sprintf("Error downloading logs: %v", err)
+			return nil
+		}
+		m.lastLogExport = download.path
+		m.statusMsg = fmt.Sprintf("Downloaded %s of logs to %s, loading...", formatBytes(download.written.Load()), download.path)
+		return loadLogFile(download.path)
+	default:
+		m.statusMsg = fmt.Sprintf("Downloading full logs for %s: %s read (esc to cancel)",
+			download.name, formatBytes(download.written.Load()))
+		return logDownloadTick(download)
+	}
+}
+
+// cancelLogDownload stops a running download and removes the partial file
+func (m *FullModel) cancelLogDownload() {
+	download := m.logDownload
+	if download == nil {
+		return
+	}
+	m.logDownload = nil
+	download.cancel()
+
+	// Remove the partial file once the download goroutine has let go of it
+	go func() {
+		<-download.done
+		os.Remove(download.path)
+	}()
+	m.statusMsg = "Log download cancelled"
+}
+
+// loadLogFile reads a downloaded log for the viewer, keeping only its most
+// recent part if it's too large to display comfortably
+func loadLogFile(path string) tea.Cmd {
+	return func() tea.Msg {
+		file, err := os.Open(path)
+		if err != nil {
+			return logFileLoadedMsg{path: path, err: err}
+		}
+		defer file.Close()
+
+		info, err := file.Stat()
+		if err != nil {
+			return logFileLoadedMsg{path: path, err: err}
+		}
+
+		truncated := info.Size() > maxLogViewBytes
+		if truncated {
+			if _, err := file.Seek(-maxLogViewBytes, io.SeekEnd); err != nil {
+				return logFileLoadedMsg{path: path, err: err}
+			}
+		}
+
+		data, err := io.ReadAll(file)
+		if err != nil {
+			return logFileLoadedMsg{path: path, err: err}
+		}
+
+		content := string(data)
+		if truncated {
+			// Drop the partial first line left by seeking into the middle of the file
+			if i := strings.IndexByte(content, '\n'); i >= 0 {
+				content = content[i+1:]
+			}
+		}
+		return logFileLoadedMsg{path: path, content: content, truncated: truncated}
+	}
+}
+
+// handleLogFileLoaded shows a downloaded log in the logs viewer
+func (m *FullModel) handleLogFileLoaded(msg logFileLoadedMsg) {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Error reading %s: %v", msg.path, msg.err)
+		return
+	}
+	if m.currentMode != LogsMode {
+		m.statusMsg = fmt.Sprintf("Full logs saved to %s (%s to open)", msg.path, DefaultFullKeyMap.OpenLogExport.Help().Key)
+		return
+	}
+
+	m.logContent = msg.content
+	m.setViewportContent(m.renderLogContent())
+	m.viewport.GotoBottom()
+
+	if msg.truncated {
+		m.statusMsg = fmt.Sprintf("Showing the last %s of the full logs; everything is in %s (%s to open)", formatBytes(maxLogViewBytes), msg.path, DefaultFullKeyMap.OpenLogExport.Help().Key)
+	} else {
+		m.statusMsg = fmt.Sprintf("Showing the full logs, also saved to %s (%s to open)", msg.path, DefaultFullKeyMap.OpenLogExport.Help().Key)
+	}
+}
+
+// sanitizeFileName makes a resource name safe to use in a file name
+func sanitizeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, name)
+}
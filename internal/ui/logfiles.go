@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// logFileClosedMsg reports that the pager showing a log file has exited
+type logFileClosedMsg struct {
+	path string
+	err  error
+}
+
+// openAppLog shows where the app writes its own logs and opens the file
+func (m *FullModel) openAppLog() tea.Cmd {
+	path := m.config.LogPath()
+	if path == "" {
+		m.statusMsg = "Logging is disabled (set logFilePath in the config file)"
+		return nil
+	}
+	return m.openLogFile(path)
+}
+
+// openLastLogExport opens the file the full container logs were last downloaded to
+func (m *FullModel) openLastLogExport() tea.Cmd {
+	if m.lastLogExport == "" {
+		m.statusMsg = fmt.Sprintf("No logs downloaded yet (%s in the logs view)", DefaultFullKeyMap.DownloadLogs.Help().Key)
+		return nil
+	}
+	return m.openLogFile(m.lastLogExport)
+}
+
+// openLogFile opens a log file in the user's pager, handing it the terminal until it exits
+func (m *FullModel) openLogFile(path string) tea.Cmd {
+	if _, err := os.Stat(path); err != nil {
+		m.statusMsg = fmt.Sprintf("Can't open %s: %v", path, err)
+		return nil
+	}
+
+	m.statusMsg = fmt.Sprintf("Opening %s...", path)
+	return tea.ExecProcess(pagerCommand(path), func(err error) tea.Msg {
+		return logFileClosedMsg{path: path, err: err}
+	})
+}
+
+// handleLogFileClosed reports where the file is once the pager exits
+func (m *FullModel) handleLogFileClosed(msg logFileClosedMsg) {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Couldn't show %s: %v", msg.path, msg.err)
+		return
+	}
+	m.statusMsg = fmt.Sprintf("Log file: %s", msg.path)
+}
+
+// pagerCommand opens a file with $PAGER, falling back to the platform's usual pager
+func pagerCommand(path string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", "more", path)
+	}
+	if pager := os.Getenv("PAGER"); pager != "" {
+		return exec.Command("sh", "-c", pager+` "$1"`, "sh", path)
+	}
+	return exec.Command("less", "+G", path)
+}
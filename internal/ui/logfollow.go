@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// Limits for following a single container's logs
+const (
+	maxFollowLogLines = 5000 // lines kept in memory while following, unless the whole log was asked for
+	followLogBatch    = 500  // maximum lines handed to the UI per update
+)
+
+// logTailPresets are the history sizes the logs view cycles through, where
+// zero is the full log
+var logTailPresets = []int{100, 500, 1000, 0}
+
+// logFollowStream follows the logs of the selected container. The stream
+// goroutine only writes to lines; everything else is owned by Update.
+type logFollowStream struct {
+	name   string
+	cancel context.CancelFunc
+	lines  chan string
+	buffer []string
+}
+
+// startLogFollow starts streaming the selected container's logs into the logs view
+func (m *FullModel) startLogFollow() tea.Cmd {
+	if m.selectedID == "" {
+		m.statusMsg = "No container selected"
+		return nil
+	}
+
+	m.stopLogFollow()
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	stream := &logFollowStream{
+		name:   m.selectedName,
+		cancel: cancel,
+		lines:  make(chan string, 256),
+	}
+
+	// A time window is shown whole rather than cut down to the history size
+	id, tail, window := m.selectedID, m.logTail, m.logWindow
+	if !window.IsZero() {
+		tail = 0
+	}
+	options := docker.LogOptions{Tail: tail, Timestamps: true, Streams: m.logStreams, Window: window}
+	go func() {
+		defer close(stream.lines)
+		send := func(line string) bool {
+			select {
+			case stream.lines <- line:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		logs, err := m.docker.StreamContainerLogs(ctx, id, true, options)
+		if err == nil {
+			err = readLogLines(ctx, logs, send)
+		}
+		if err != nil {
+			send(fmt.Sprintf("[log stream ended: %v]", err))
+		}
+	}()
+
+	m.logFollow = stream
+	m.logContent = ""
+	m.setViewportContent("")
+	from := tailLabel(m.logTail)
+	if !window.IsZero() {
+		from = logWindowLabel(window)
+	}
+	m.statusMsg = fmt.Sprintf("Following %s of %s from %s (%s to pause, %s to change, %s for other streams)", m.logStreams, m.selectedName, from,
+		DefaultFullKeyMap.FollowLogs.Help().Key, DefaultFullKeyMap.CycleLogTail.Help().Key, DefaultFullKeyMap.LogStreams.Help().Key)
+
+	return waitForFollowLogs(stream)
+}
+
+// readLogLines sends each line of a log stream until it ends or send gives
+// up, then closes it. Stopping the stream through ctx isn't an error.
+func readLogLines(ctx context.Context, logs io.ReadCloser, send func(line string) bool) error {
+	defer logs.Close()
+	scanner := bufio.NewScanner(logs)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if !send(scanner.Text()) {
+			return nil
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return scanner.Err()
+}
+
+// waitForFollowLogs waits for the next lines from the stream, batching any
+// that are already queued so bursts of output render in a single update
+func waitForFollowLogs(stream *logFollowStream) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-stream.lines
+		if !ok {
+			return fullLogsMsg{stream: stream, done: true}
+		}
+
+		lines := []string{line}
+		for len(lines) < followLogBatch {
+			select {
+			case line, ok := <-stream.lines:
+				if !ok {
+					return fullLogsMsg{stream: stream, lines: lines, done: true}
+				}
+				lines = append(lines, line)
+			default:
+				return fullLogsMsg{stream: stream, lines: lines}
+			}
+		}
+		return fullLogsMsg{stream: stream, lines: lines}
+	}
+}
+
+// handleFollowLogLines appends newly received lines and keeps listening
+func (m *FullModel) handleFollowLogLines(msg fullLogsMsg) tea.Cmd {
+	// Ignore lines from a stream that has since been stopped
+	if msg.stream != m.logFollow {
+		return nil
+	}
+
+	stream := msg.stream
+	stream.buffer = append(stream.buffer, msg.lines...)
+	if m.logTail > 0 && len(stream.buffer) > max(maxFollowLogLines, m.logTail) {
+		stream.buffer = stream.buffer[len(stream.buffer)-max(maxFollowLogLines, m.logTail):]
+	}
+
+	// Keep following new output unless the user has scrolled up to read
+	atBottom := m.viewport.AtBottom()
+	m.logContent = strings.Join(stream.buffer, "\n")
+	m.setViewportContent(m.renderLogContent())
+	if atBottom {
+		m.viewport.GotoBottom()
+	}
+
+	if msg.done {
+		m.logFollow = nil
+		m.statusMsg = fmt.Sprintf("Log stream for %s has ended", stream.name)
+		return nil
+	}
+	return waitForFollowLogs(stream)
+}
+
+// toggleLogFollow pauses following the logs, keeping what has been received
+// so far, or starts following them again
+func (m *FullModel) toggleLogFollow() tea.Cmd {
+	if m.logFollow != nil {
+		m.stopLogFollow()
+		m.statusMsg = fmt.Sprintf("Paused following logs for %s (%s to resume)", m.selectedName, DefaultFullKeyMap.FollowLogs.Help().Key)
+		return nil
+	}
+	return m.startLogFollow()
+}
+
+// stopLogFollow stops following the selected container's logs
+func (m *FullModel) stopLogFollow() {
+	if m.logFollow == nil {
+		return
+	}
+	m.logFollow.cancel()
+	m.logFollow = nil
+}
+
+// cycleLogTail switches to the next history size and streams the logs again
+func (m *FullModel) cycleLogTail() tea.Cmd {
+	next := logTailPresets[0]
+	for i, lines := range logTailPresets {
+		if lines == m.logTail && i+1 < len(logTailPresets) {
+			next = logTailPresets[i+1]
+			break
+		}
+	}
+	m.logTail = next
+	return m.startLogFollow()
+}
+
+// cycleLogStreams switches between showing stdout and stderr, stdout only
+// and stderr only, and streams the logs again
+func (m *FullModel) cycleLogStreams() tea.Cmd {
+	m.logStreams = m.logStreams.Next()
+	return m.startLogFollow()
+}
+
+// tailLabel describes a history size for the status bar
+func tailLabel(lines int) string {
+	if lines <= 0 {
+		return "the full log"
+	}
+	return fmt.Sprintf("the last %d lines", lines)
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Default and maximum number of context lines shown around grep matches
+const (
+	defaultGrepContext = 3
+	maxGrepContext     = 50
+)
+
+// compileLogPattern compiles a case-insensitive pattern, treating it as a
+// literal string if it isn't a valid regular expression
+func compileLogPattern(pattern string) *regexp.Regexp {
+	return compileSearchPattern(pattern, false)
+}
+
+// compileSearchPattern compiles a pattern, treating it as a literal string if
+// it isn't a valid regular expression
+func compileSearchPattern(pattern string, caseSensitive bool) *regexp.Regexp {
+	flags := "(?i)"
+	if caseSensitive {
+		flags = ""
+	}
+	re, err := regexp.Compile(flags + pattern)
+	if err != nil {
+		re = regexp.MustCompile(flags + regexp.QuoteMeta(pattern))
+	}
+	return re
+}
+
+// grepWithContext returns only the lines matching pattern, with contextLines
+// lines of surrounding context, in the style of `grep -C`
+func (m FullModel) grepWithContext(content, pattern string, contextLines int) (string, int) {
+	re := compileLogPattern(pattern)
+	lines := strings.Split(content, "\n")
+
+	matchStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Header).Background(m.styles.Warning)
+	lineNoStyle := lipgloss.NewStyle().Foreground(m.styles.Border)
+	separatorStyle := lipgloss.NewStyle().Foreground(m.styles.Accent)
+
+	// Mark every line that should be shown: the matches plus their context
+	show := make([]bool, len(lines))
+	isMatch := make([]bool, len(lines))
+	matches := 0
+	for i, line := range lines {
+		if !re.MatchString(line) {
+			continue
+		}
+		matches++
+		isMatch[i] = true
+		for j := i - contextLines; j <= i+contextLines; j++ {
+			if j >= 0 && j < len(lines) {
+				show[j] = true
+			}
+		}
+	}
+
+	var sb strings.Builder
+	lastShown := -1
+	for i, line := range lines {
+		if !show[i] {
+			continue
+		}
+
+		// Separate non-contiguous groups like grep does
+		if lastShown >= 0 && i > lastShown+1 {
+			sb.WriteString(separatorStyle.Render("--"))
+			sb.WriteString("\n")
+		}
+		lastShown = i
+
+		marker := " "
+		if isMatch[i] {
+			marker = ":"
+			line = re.ReplaceAllStringFunc(line, func(s string) string {
+				return matchStyle.Render(s)
+			})
+		}
+		sb.WriteString(lineNoStyle.Render(fmt.Sprintf("%6d%s ", i+1, marker)))
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+
+	return sb.String(), matches
+}
+
+// renderLogContent renders the logs viewport content, applying the grep filter if set
+func (m FullModel) renderLogContent() string {
+	if m.logGrep == "" {
+		return m.shownLogs()
+	}
+
+	content, matches := m.grepWithContext(m.shownLogs(), m.logGrep, m.logGrepContext)
+	if matches == 0 {
+		return fmt.Sprintf("No lines match %q", m.logGrep)
+	}
+	return content
+}
+
+// applyLogGrep sets the grep pattern and re-renders the logs viewport
+func (m *FullModel) applyLogGrep(pattern string) {
+	m.logGrep = pattern
+	m.setViewportContent(m.renderLogContent())
+	m.viewport.GotoTop()
+
+	if pattern == "" {
+		m.statusMsg = "Showing all log lines"
+		return
+	}
+	_, matches := m.grepWithContext(m.shownLogs(), pattern, m.logGrepContext)
+	m.statusMsg = fmt.Sprintf("%d lines match %q (±%d lines of context, +/- to adjust)", matches, pattern, m.logGrepContext)
+}
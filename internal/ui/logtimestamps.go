@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klejdi94/docker-tea/internal/config"
+)
+
+// localLogTimestampLayout is the compact local time log lines start with
+// when logTimestamps is "local"
+const localLogTimestampLayout = "Jan 02 15:04:05.000"
+
+// logTimestampModes are the logTimestamps settings the logs view cycles through
+var logTimestampModes = []string{config.LogTimestampsUTC, config.LogTimestampsLocal, config.LogTimestampsOff}
+
+// logTimestampsSavedMsg reports whether the logTimestamps setting could be
+// written to the config file
+type logTimestampsSavedMsg struct {
+	mode string
+	err  error
+}
+
+// shownLogs returns the log content with its timestamps shown the way the
+// config's logTimestamps asks for
+func (m FullModel) shownLogs() string {
+	return formatLogTimestamps(m.logContent, m.config.LogTimestamps)
+}
+
+// formatLogTimestamps rewrites the RFC 3339 timestamp Docker puts at the
+// start of each log line, converting it to local time or dropping it. Lines
+// without one, such as those of compose tails, are left as they are.
+func formatLogTimestamps(content, mode string) string {
+	if mode == config.LogTimestampsUTC || content == "" {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		stamp, rest, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339Nano, stamp)
+		if err != nil {
+			continue
+		}
+		if mode == config.LogTimestampsOff {
+			lines[i] = rest
+		} else {
+			lines[i] = t.Local().Format(localLogTimestampLayout) + " " + rest
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// cycleLogTimestamps switches to the next way of showing log timestamps and
+// saves it to the config file, so later sessions show them the same way
+func (m *FullModel) cycleLogTimestamps() tea.Cmd {
+	next := logTimestampModes[0]
+	for i, mode := range logTimestampModes {
+		if mode == m.config.LogTimestamps && i+1 < len(logTimestampModes) {
+			next = logTimestampModes[i+1]
+			break
+		}
+	}
+	m.config.LogTimestamps = next
+	m.setViewportContent(m.renderLogContent())
+
+	return func() tea.Msg {
+		return logTimestampsSavedMsg{mode: next, err: config.SaveLogTimestamps(next)}
+	}
+}
+
+// handleLogTimestampsSaved reports the timestamps now shown in the logs view
+func (m *FullModel) handleLogTimestampsSaved(msg logTimestampsSavedMsg) {
+	label := map[string]string{
+		config.LogTimestampsUTC:   "Showing log timestamps in UTC",
+		config.LogTimestampsLocal: "Showing log timestamps in local time",
+		config.LogTimestampsOff:   "Hiding log timestamps",
+	}[msg.mode]
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("%s for this session only, it couldn't be saved: %v", label, msg.err)
+		return
+	}
+	m.statusMsg = label
+}
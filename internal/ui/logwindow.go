@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// logWindowLayouts are the absolute times accepted for a logs time window,
+// the ones without a date meaning today
+var logWindowLayouts = []string{
+	"15:04",
+	"15:04:05",
+	"2006-01-02",
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+}
+
+// promptLogWindow asks for the time range to show the selected container's
+// logs from, then streams them again
+func (m *FullModel) promptLogWindow() tea.Cmd {
+	cmd := m.openPrompt("Logs from (15m, 14:00, 14:00 to 14:30; empty for all):", m.logWindowInput, func(m *FullModel, value string) tea.Cmd {
+		window, _ := parseLogWindow(value, time.Now())
+		m.logWindow = window
+		m.logWindowInput = strings.TrimSpace(value)
+		return m.startLogFollow()
+	})
+	m.prompt.validate = func(value string) string {
+		if _, err := parseLogWindow(value, time.Now()); err != nil {
+			return err.Error()
+		}
+		return ""
+	}
+	return cmd
+}
+
+// parseLogWindow parses a logs time window such as "15m", "14:00 to 14:30"
+// or "2h to 1h". Durations count back from now; times without a date are today's.
+func parseLogWindow(input string, now time.Time) (docker.LogWindow, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return docker.LogWindow{}, nil
+	}
+
+	from, to, hasEnd := strings.Cut(input, " to ")
+	since, err := parseLogTime(from, now)
+	if err != nil {
+		return docker.LogWindow{}, err
+	}
+	window := docker.LogWindow{Since: since}
+	if hasEnd {
+		if window.Until, err = parseLogTime(to, now); err != nil {
+			return docker.LogWindow{}, err
+		}
+		if !window.Until.After(window.Since) {
+			return docker.LogWindow{}, errors.New("the end has to be after the start")
+		}
+	}
+	return window, nil
+}
+
+// parseLogTime parses one end of a logs time window
+func parseLogTime(input string, now time.Time) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	if d, err := time.ParseDuration(input); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	for _, layout := range logWindowLayouts {
+		t, err := time.ParseInLocation(layout, input, now.Location())
+		if err != nil {
+			continue
+		}
+		if !strings.Contains(layout, "2006") {
+			t = time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, now.Location())
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%q isn't a duration like 15m or a time like 14:00", input)
+}
+
+// logWindowLabel describes the logs time window for the logs header
+func logWindowLabel(window docker.LogWindow) string {
+	today := time.Now().Format(time.DateOnly)
+	format := func(t time.Time) string {
+		if t.Format(time.DateOnly) == today {
+			return t.Format("15:04:05")
+		}
+		return t.Format("Jan 02 15:04:05")
+	}
+
+	switch {
+	case window.Until.IsZero():
+		return "since " + format(window.Since)
+	case window.Since.IsZero():
+		return "until " + format(window.Until)
+	default:
+		return fmt.Sprintf("from %s to %s", format(window.Since), format(window.Until))
+	}
+}
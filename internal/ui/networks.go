@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klejdi94/docker-tea/internal/docker"
+	"github.com/klejdi94/docker-tea/internal/ui/views"
+)
+
+// moveNetworkContainerCursor moves the container selection in the network inspect view
+func (m *FullModel) moveNetworkContainerCursor(delta int) {
+	endpoints := views.NetworkContainers(m.inspectContent)
+	if len(endpoints) == 0 {
+		return
+	}
+
+	m.networkContainerCursor = max(0, min(len(endpoints)-1, m.networkContainerCursor+delta))
+	m.setViewportContent(m.renderInspectContent())
+	m.statusMsg = fmt.Sprintf("Selected container %s (%s to disconnect it)", endpoints[m.networkContainerCursor].Name, DefaultFullKeyMap.DisconnectNetwork.Help().Key)
+}
+
+// jumpToNetworkContainer selects a container connected to the inspected
+// network, by its position in the list, in the Containers tab
+func (m *FullModel) jumpToNetworkContainer(index int) {
+	endpoints := views.NetworkContainers(m.inspectContent)
+	if len(endpoints) == 0 {
+		m.statusMsg = fmt.Sprintf("No containers are connected to %s", m.selectedName)
+		return
+	}
+	if index >= len(endpoints) {
+		m.statusMsg = fmt.Sprintf("Container %d not found. Valid range: 1-%d", index+1, min(9, len(endpoints)))
+		return
+	}
+
+	endpoint := endpoints[index]
+	m.jumpToContainer(endpoint.ContainerID, endpoint.Name)
+}
+
+// connectToNetwork picks a container not yet on the inspected network and connects it
+func (m *FullModel) connectToNetwork() tea.Cmd {
+	connected := make(map[string]bool)
+	for _, endpoint := range views.NetworkContainers(m.inspectContent) {
+		connected[endpoint.ContainerID] = true
+	}
+
+	var candidates []docker.ContainerInfo
+	var items []string
+	for _, c := range m.containers {
+		if isConnected(connected, c.ID) {
+			continue
+		}
+		candidates = append(candidates, c)
+		items = append(items, fmt.Sprintf("%s (%s, %s)", c.Name, c.Image, c.State))
+	}
+	if len(candidates) == 0 {
+		m.statusMsg = fmt.Sprintf("Every container is already connected to %s", m.selectedName)
+		return nil
+	}
+
+	networkID, networkName := m.selectedID, m.selectedName
+	m.openPicker(fmt.Sprintf("Connect a container to %s", networkName), items, 0, func(m *FullModel, index int) tea.Cmd {
+		c := candidates[index]
+		m.statusMsg = fmt.Sprintf("Connecting %s to %s...", c.Name, networkName)
+		return tea.Sequence(func() tea.Msg {
+			if err := m.docker.ConnectContainerToNetwork(m.ctx, networkID, c.ID); err != nil {
+				return fullActionResultMsg{success: false, message: fmt.Sprintf("Error: %v", err)}
+			}
+			return fullActionResultMsg{
+				success: true,
+				message: fmt.Sprintf("Connected %s to %s", c.Name, networkName),
+				action:  "connect",
+			}
+		}, refreshInspect)
+	})
+	return nil
+}
+
+// disconnectFromNetwork disconnects the selected container from the inspected network
+func (m *FullModel) disconnectFromNetwork() tea.Cmd {
+	endpoints := views.NetworkContainers(m.inspectContent)
+	if m.networkContainerCursor >= len(endpoints) {
+		m.statusMsg = "No connected container selected"
+		return nil
+	}
+
+	endpoint := endpoints[m.networkContainerCursor]
+	networkID, networkName := m.selectedID, m.selectedName
+	m.statusMsg = fmt.Sprintf("Disconnecting %s from %s...", endpoint.Name, networkName)
+	return tea.Sequence(func() tea.Msg {
+		if err := m.docker.DisconnectContainerFromNetwork(m.ctx, networkID, endpoint.ContainerID, false); err != nil {
+			return fullActionResultMsg{success: false, message: fmt.Sprintf("Error: %v", err)}
+		}
+		return fullActionResultMsg{
+			success: true,
+			message: fmt.Sprintf("Disconnected %s from %s", endpoint.Name, networkName),
+			action:  "disconnect",
+		}
+	}, refreshInspect)
+}
+
+// refreshInspect reloads the inspect view once an action on the inspected resource is done
+func refreshInspect() tea.Msg {
+	return afterActionMsg{action: "inspect"}
+}
+
+// isConnected reports whether the container with the given short ID is among
+// the connected ones, which are keyed by full ID
+func isConnected(connected map[string]bool, shortID string) bool {
+	for id := range connected {
+		if strings.HasPrefix(id, shortID) {
+			return true
+		}
+	}
+	return false
+}
+
+// networkDrivers are the drivers a network can be created with
+var networkDrivers = []string{"bridge", "overlay", "macvlan", "ipvlan"}
+
+// Fields of the network creation form
+const (
+	networkFormName = iota
+	networkFormDriver
+	networkFormInternal
+	networkFormAttachable
+)
+
+// openNetworkForm opens the form for creating a network
+func (m *FullModel) openNetworkForm() tea.Cmd {
+	fields := []formField{
+		networkFormName:       textField("Name", "e.g. backend", ""),
+		networkFormDriver:     textField("Driver", strings.Join(networkDrivers, ", "), "bridge"),
+		networkFormInternal:   choiceField("Internal", "no", "yes"),
+		networkFormAttachable: choiceField("Attachable", "no", "yes"),
+	}
+	return m.openResourceForm("New network", fields, validateNetworkForm, createNetwork)
+}
+
+// validateNetworkForm checks the network name and driver
+func validateNetworkForm(values []string) []string {
+	errs := make([]string, len(values))
+	if values[networkFormName] == "" {
+		errs[networkFormName] = "a name is required"
+	} else if !containerNamePattern.MatchString(values[networkFormName]) {
+		errs[networkFormName] = "may only contain letters, digits, _ . and -, and must start with a letter or digit"
+	}
+	if !slices.Contains(networkDrivers, values[networkFormDriver]) {
+		errs[networkFormDriver] = fmt.Sprintf("must be one of %s", strings.Join(networkDrivers, ", "))
+	}
+	return errs
+}
+
+// createNetwork creates a network from the submitted form
+func createNetwork(m *FullModel, values []string) tea.Cmd {
+	name, driver := values[networkFormName], values[networkFormDriver]
+	internal := values[networkFormInternal] == "yes"
+	attachable := values[networkFormAttachable] == "yes"
+
+	m.statusMsg = fmt.Sprintf("Creating network %s...", name)
+	return func() tea.Msg {
+		id, err := m.docker.CreateNetwork(m.ctx, name, driver, internal, attachable, nil)
+		if err != nil {
+			return resourceCreatedMsg{result: fullActionResultMsg{success: false, message: fmt.Sprintf("Error: %v", err)}}
+		}
+		return resourceCreatedMsg{
+			result: fullActionResultMsg{success: true, message: fmt.Sprintf("Created %s network %s (ID %s)", driver, name, docker.ShortID(id, 12))},
+			list:   m.fetchNetworks(),
+			tab:    NetworksTab,
+			id:     id,
+		}
+	}
+}
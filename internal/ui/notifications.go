@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/klejdi94/docker-tea/internal/docker"
+	"github.com/klejdi94/docker-tea/internal/notify"
+)
+
+const (
+	// stopSuppressWindow is how long after being stopped from docker-tea a
+	// container's death isn't reported. docker stop waits up to 10 seconds
+	// by default before killing it.
+	stopSuppressWindow = 15 * time.Second
+
+	// stopSuppressMargin is how long past its stop timeout a container's
+	// death is still expected, when stopped with a longer timeout
+	stopSuppressMargin = 5 * time.Second
+
+	// deathBannerDuration is how long the banner about a dead container stays up
+	deathBannerDuration = 8 * time.Second
+)
+
+// intentionalStops remembers the containers and compose projects or services
+// the user stopped recently, so their deaths aren't reported as unexpected.
+// Actions record them from their own goroutines, hence the lock.
+type intentionalStops struct {
+	mu    sync.Mutex
+	until map[string]time.Time
+}
+
+func newIntentionalStops() *intentionalStops {
+	return &intentionalStops{until: make(map[string]time.Time)}
+}
+
+// record notes that a container, or every container of a compose project or
+// service, is being stopped on purpose
+func (s *intentionalStops) record(key string) {
+	s.recordFor(key, 0)
+}
+
+// recordFor is record for a stop that gives the container up to timeout to
+// exit before it's killed
+func (s *intentionalStops) recordFor(key string, timeout time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.until[key] = time.Now().Add(max(stopSuppressWindow, timeout+stopSuppressMargin))
+}
+
+// recent reports whether any of the keys was stopped on purpose recently
+// enough for its death to be expected, forgetting older stops along the way
+func (s *intentionalStops) recent(keys ...string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found := false
+	for key, until := range s.until {
+		if time.Now().After(until) {
+			delete(s.until, key)
+			continue
+		}
+		for _, k := range keys {
+			if k == key {
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+// composeStopKey identifies a compose project, or one of its services, in
+// the intentional stops
+func composeStopKey(project, service string) string {
+	if service == "" {
+		return "compose:" + project
+	}
+	return "compose:" + project + "/" + service
+}
+
+// containerStopKey is the key a container is recorded under by its ID. The
+// lists have short IDs and events full ones, so both map to the short form.
+func containerStopKey(id string) string {
+	return docker.ShortID(id, 12)
+}
+
+// deathBanner is the transient banner shown when a container dies unexpectedly
+type deathBanner struct {
+	text string
+	seq  int // tells a stale expiry apart from the current banner's
+}
+
+// deathBannerExpiredMsg takes down a banner once it has been up long enough
+type deathBannerExpiredMsg struct {
+	seq int
+}
+
+// notifyContainerDeath reports a container that exited with a non-zero code
+// and wasn't stopped from docker-tea, with a banner and, if enabled in the
+// config, a desktop notification
+func (m *FullModel) notifyContainerDeath(event docker.DockerEvent) tea.Cmd {
+	if event.Type != "container" || event.Action != "die" {
+		return nil
+	}
+	exitCode := event.Attributes["exitCode"]
+	if exitCode == "" || exitCode == "0" {
+		return nil
+	}
+	project, service := event.Attributes[composeLabelProject], event.Attributes[composeLabelService]
+	if m.stops.recent(containerStopKey(event.ID), composeStopKey(project, ""), composeStopKey(project, service)) {
+		return nil
+	}
+
+	name := event.Resource
+	if name == "" {
+		name = docker.ShortID(event.ID, 12)
+	}
+	message := fmt.Sprintf("Container %s exited with code %s", name, exitCode)
+
+	m.banner.seq++
+	m.banner.text = message
+	seq := m.banner.seq
+	cmds := []tea.Cmd{tea.Tick(deathBannerDuration, func(time.Time) tea.Msg {
+		return deathBannerExpiredMsg{seq: seq}
+	})}
+
+	if m.config.Notifications {
+		cmds = append(cmds, func() tea.Msg {
+			if err := notify.Send("docker-tea: container died", message); err != nil {
+				return fullActionResultMsg{success: false, message: err.Error()}
+			}
+			return nil
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleDeathBannerExpired takes down the banner, unless a newer one replaced it
+func (m *FullModel) handleDeathBannerExpired(msg deathBannerExpiredMsg) {
+	if msg.seq == m.banner.seq {
+		m.banner.text = ""
+	}
+}
+
+// renderDeathBanner renders the banner about a dead container
+func (m FullModel) renderDeathBanner() string {
+	return lipgloss.NewStyle().
+		Foreground(m.styles.Emphasis).
+		Background(m.styles.Error).
+		Bold(true).
+		Padding(0, 1).
+		Render(IconWarning + m.banner.text)
+}
@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// composeLabelConfigFiles lists the compose files a container was created from
+const composeLabelConfigFiles = "com.docker.compose.project.config_files"
+
+// refreshOrphans works out which containers were left behind by compose
+// projects that no longer exist. A container is orphaned when its project
+// isn't listed by compose anymore and none of the compose files it was
+// created from are still on disk. Compose only lists running projects, and
+// the files of a remote daemon can't be checked, so there nothing is flagged.
+func (m *FullModel) refreshOrphans() {
+	m.orphans = make(map[string]bool)
+	if !m.composeProjectsLoaded || m.docker.IsRemote() {
+		return
+	}
+
+	projects := make(map[string]bool, len(m.composeProjects))
+	for _, p := range m.composeProjects {
+		projects[p.Name] = true
+	}
+
+	for _, c := range m.containers {
+		project := c.Labels[composeLabelProject]
+		if project == "" || projects[project] {
+			continue
+		}
+		if composeFilesExist(c.Labels[composeLabelConfigFiles]) {
+			continue
+		}
+		m.orphans[c.ID] = true
+	}
+}
+
+// composeFilesExist reports whether any of the comma-separated compose files exists
+func composeFilesExist(files string) bool {
+	for _, file := range strings.Split(files, ",") {
+		file = strings.TrimSpace(file)
+		if file == "" {
+			continue
+		}
+		if _, err := os.Stat(file); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// orphanedContainers returns the containers left behind by deleted compose projects
+func (m FullModel) orphanedContainers() []docker.ContainerInfo {
+	var orphans []docker.ContainerInfo
+	for _, c := range m.containers {
+		if m.orphans[c.ID] {
+			orphans = append(orphans, c)
+		}
+	}
+	return orphans
+}
+
+// removeOrphans asks for confirmation, then removes every orphaned container
+func (m *FullModel) removeOrphans() tea.Cmd {
+	orphans := m.orphanedContainers()
+	items := make([]string, len(orphans))
+	for i, c := range orphans {
+		items[i] = fmt.Sprintf("%s (project %s, %s)", c.Name, c.Labels[composeLabelProject], c.State)
+	}
+
+	return m.confirmBulk("remove-orphans", "Remove orphaned compose containers", items, func(m *FullModel) tea.Cmd {
+		m.statusMsg = fmt.Sprintf("Removing %d orphaned containers...", len(orphans))
+		return func() tea.Msg {
+			var failed []string
+			for _, c := range orphans {
+				if err := m.docker.RemoveContainer(m.ctx, c.ID, true); err != nil {
+					failed = append(failed, fmt.Sprintf("%s: %v", c.Name, err))
+				}
+			}
+			if len(failed) > 0 {
+				return fullActionResultMsg{
+					success: false,
+					message: fmt.Sprintf("Removed %d of %d orphaned containers, failed: %s", len(orphans)-len(failed), len(orphans), strings.Join(failed, "; ")),
+					action:  "remove",
+				}
+			}
+			return fullActionResultMsg{
+				success: true,
+				message: fmt.Sprintf("Removed %d orphaned containers", len(orphans)),
+				action:  "remove",
+			}
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// picker is a small overlay that lets the user choose one entry from a list
+type picker struct {
+	active   bool
+	title    string
+	items    []string
+	cursor   int
+	onSelect func(m *FullModel, index int) tea.Cmd
+}
+
+// openPicker shows the picker overlay with the given items
+func (m *FullModel) openPicker(title string, items []string, cursor int, onSelect func(m *FullModel, index int) tea.Cmd) {
+	if cursor < 0 || cursor >= len(items) {
+		cursor = 0
+	}
+	m.picker = picker{
+		active:   true,
+		title:    title,
+		items:    items,
+		cursor:   cursor,
+		onSelect: onSelect,
+	}
+}
+
+// handlePickerKey processes key presses while the picker overlay is open
+func (m *FullModel) handlePickerKey(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, DefaultFullKeyMap.Up):
+		if m.picker.cursor > 0 {
+			m.picker.cursor--
+		}
+	case key.Matches(msg, DefaultFullKeyMap.Down):
+		if m.picker.cursor < len(m.picker.items)-1 {
+			m.picker.cursor++
+		}
+	case msg.String() == "enter":
+		selected := m.picker
+		m.picker = picker{}
+		if selected.onSelect != nil && len(selected.items) > 0 {
+			return selected.onSelect(m, selected.cursor)
+		}
+	case key.Matches(msg, DefaultFullKeyMap.Back), key.Matches(msg, DefaultFullKeyMap.Quit):
+		m.picker = picker{}
+		m.statusMsg = "Cancelled"
+	}
+	return nil
+}
+
+// renderPicker renders the picker overlay
+func (m FullModel) renderPicker() string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Accent)
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(m.styles.SelectedText).
+		Background(m.styles.SelectedBackground).
+		Bold(true)
+	hintStyle := lipgloss.NewStyle().Foreground(m.styles.Muted).Italic(true)
+
+	sb.WriteString(titleStyle.Render(m.picker.title))
+	sb.WriteString("\n\n")
+
+	if len(m.picker.items) == 0 {
+		sb.WriteString("  (nothing to choose from)\n")
+	}
+	for i, item := range m.picker.items {
+		if i == m.picker.cursor {
+			sb.WriteString(selectedStyle.Render("> " + item))
+		} else {
+			sb.WriteString("  " + item)
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render("↑/↓ to move, enter to select, esc to cancel"))
+
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.styles.Border).
+		Padding(1, 2)
+
+	return boxStyle.Render(sb.String())
+}
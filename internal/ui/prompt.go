@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// prompt is a single-line text input shown above the footer
+type prompt struct {
+	active   bool
+	label    string
+	input    textinput.Model
+	onSubmit func(m *FullModel, value string) tea.Cmd
+	onChange func(m *FullModel, value string) // called as the user types, if set
+	onCancel func(m *FullModel)               // called when the prompt is dismissed, if set
+	validate func(value string) string        // returns why the value can't be submitted, if set
+	err      string                           // the validation error shown under the input
+}
+
+// openPrompt asks the user for a line of text and calls onSubmit with the answer
+func (m *FullModel) openPrompt(label, initial string, onSubmit func(m *FullModel, value string) tea.Cmd) tea.Cmd {
+	input := textinput.New()
+	input.Prompt = ""
+	input.SetValue(initial)
+	input.CursorEnd()
+	input.Width = 40
+
+	m.prompt = prompt{
+		active:   true,
+		label:    label,
+		input:    input,
+		onSubmit: onSubmit,
+	}
+	return m.prompt.input.Focus()
+}
+
+// handlePromptKey processes key presses while the prompt is open
+func (m *FullModel) handlePromptKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "enter":
+		if m.prompt.validate != nil {
+			if err := m.prompt.validate(m.prompt.input.Value()); err != "" {
+				m.prompt.err = err
+				return nil
+			}
+		}
+		submitted := m.prompt
+		m.prompt = prompt{}
+		if submitted.onSubmit != nil {
+			return submitted.onSubmit(m, submitted.input.Value())
+		}
+		return nil
+	case "esc", "ctrl+c":
+		cancelled := m.prompt
+		m.prompt = prompt{}
+		m.statusMsg = "Cancelled"
+		if cancelled.onCancel != nil {
+			cancelled.onCancel(m)
+		}
+		return nil
+	}
+
+	previous := m.prompt.input.Value()
+	var cmd tea.Cmd
+	m.prompt.input, cmd = m.prompt.input.Update(msg)
+	if m.prompt.input.Value() != previous {
+		m.prompt.err = ""
+		if m.prompt.onChange != nil {
+			m.prompt.onChange(m, m.prompt.input.Value())
+		}
+	}
+	return cmd
+}
+
+// renderPrompt renders the prompt line
+func (m FullModel) renderPrompt() string {
+	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Accent)
+	line := labelStyle.Render(m.prompt.label+" ") + m.prompt.input.View()
+	if m.prompt.err != "" {
+		errorStyle := lipgloss.NewStyle().Foreground(m.styles.Error)
+		line += "\n" + errorStyle.Render("✗ "+m.prompt.err)
+	}
+	return line
+}
@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// pruneCandidatesMsg lists what a prune would remove, looked up so the
+// confirmation can show exactly which resources are affected
+type pruneCandidatesMsg struct {
+	action string // confirmation setting, e.g. "prune-images"
+	title  string
+	items  []string
+	err    error
+	run    func(m *FullModel) tea.Cmd
+}
+
+// pruneStep removes one kind of unused resource, returning the bytes reclaimed
+type pruneStep struct {
+	name  string
+	prune func(m *FullModel) (uint64, error)
+}
+
+// Prune steps, in the order they run when pruning everything. Containers go
+// first, so the images and volumes they held become unused.
+var (
+	pruneContainersStep = pruneStep{"stopped containers", func(m *FullModel) (uint64, error) {
+		return m.docker.PruneContainers(m.ctx)
+	}}
+	pruneDanglingImagesStep = pruneStep{"dangling images", func(m *FullModel) (uint64, error) {
+		return m.docker.PruneImages(m.ctx, true)
+	}}
+	pruneUnusedImagesStep = pruneStep{"unused images", func(m *FullModel) (uint64, error) {
+		return m.docker.PruneImages(m.ctx, false)
+	}}
+	pruneVolumesStep = pruneStep{"unused volumes", func(m *FullModel) (uint64, error) {
+		return m.docker.PruneVolumes(m.ctx)
+	}}
+)
+
+// pruneTab prunes the unused resources of the current tab after confirmation.
+// Images can be pruned dangling-only or all unused.
+func (m *FullModel) pruneTab() tea.Cmd {
+	switch m.currentTab {
+	case ContainersTab:
+		var items []string
+		for _, c := range m.containers {
+			switch strings.ToLower(c.State) {
+			case "exited", "created", "dead":
+				items = append(items, fmt.Sprintf("%s (%s, %s)", c.Name, c.Image, c.State))
+			}
+		}
+		return m.confirmBulk("prune-containers", "Prune stopped containers", items, runPrune(pruneContainersStep))
+
+	case ImagesTab:
+		choices := []string{"Dangling images only", "All unused images"}
+		m.openPicker("Prune images", choices, 0, func(m *FullModel, index int) tea.Cmd {
+			dangling := index == 0
+			m.statusMsg = "Looking for unused images..."
+			return func() tea.Msg {
+				images, err := m.docker.UnusedImages(m.ctx, dangling)
+				msg := pruneCandidatesMsg{action: "prune-images", title: "Prune unused images", err: err, run: runPrune(pruneUnusedImagesStep)}
+				if dangling {
+					msg.title = "Prune dangling images"
+					msg.run = runPrune(pruneDanglingImagesStep)
+				}
+				for _, img := range images {
+					msg.items = append(msg.items, imageLabel(img))
+				}
+				return msg
+			}
+		})
+		return nil
+
+	case VolumesTab:
+		m.statusMsg = "Looking for unused volumes..."
+		return func() tea.Msg {
+			volumes, err := m.docker.UnusedVolumes(m.ctx)
+			msg := pruneCandidatesMsg{action: "prune-volumes", title: "Prune unused volumes", err: err, run: runPrune(pruneVolumesStep)}
+			for _, v := range volumes {
+				msg.items = append(msg.items, v.Name)
+			}
+			return msg
+		}
+	}
+
+	m.statusMsg = "Nothing to prune on this tab"
+	return nil
+}
+
+// pruneEverything prunes stopped containers, unused images and unused volumes
+// at once after confirmation
+func (m *FullModel) pruneEverything() tea.Cmd {
+	var stopped []string
+	for _, c := range m.containers {
+		switch strings.ToLower(c.State) {
+		case "exited", "created", "dead":
+			stopped = append(stopped, fmt.Sprintf("container %s", c.Name))
+		}
+	}
+
+	m.statusMsg = "Looking for unused resources..."
+	return func() tea.Msg {
+		msg := pruneCandidatesMsg{
+			action: "prune-all",
+			title:  "Prune all unused containers, images and volumes",
+			items:  stopped,
+			run:    runPrune(pruneContainersStep, pruneUnusedImagesStep, pruneVolumesStep),
+		}
+
+		images, err := m.docker.UnusedImages(m.ctx, false)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		for _, img := range images {
+			msg.items = append(msg.items, "image "+imageLabel(img))
+		}
+
+		volumes, err := m.docker.UnusedVolumes(m.ctx)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		for _, v := range volumes {
+			msg.items = append(msg.items, "volume "+v.Name)
+		}
+		return msg
+	}
+}
+
+// handlePruneCandidates asks to confirm a prune once its candidates are known
+func (m *FullModel) handlePruneCandidates(msg pruneCandidatesMsg) tea.Cmd {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", msg.err)
+		return nil
+	}
+	return m.confirmBulk(msg.action, msg.title, msg.items, msg.run)
+}
+
+// runPrune returns a confirmed action running the given prune steps in order,
+// reporting the space each reclaimed and the total
+func runPrune(steps ...pruneStep) func(m *FullModel) tea.Cmd {
+	return func(m *FullModel) tea.Cmd {
+		m.statusMsg = "Pruning..."
+		return func() tea.Msg {
+			var total uint64
+			var parts []string
+			for _, step := range steps {
+				reclaimed, err := step.prune(m)
+				if err != nil {
+					return pruneDoneMsg{result: fullActionResultMsg{
+						success: false,
+						message: fmt.Sprintf("Failed to prune %s after reclaiming %s: %v", step.name, formatBytes(int64(total)), err),
+					}}
+				}
+				total += reclaimed
+				parts = append(parts, fmt.Sprintf("%s %s", step.name, formatBytes(int64(reclaimed))))
+			}
+
+			message := fmt.Sprintf("Pruned %s, reclaimed %s", steps[0].name, formatBytes(int64(total)))
+			if len(steps) > 1 {
+				message = fmt.Sprintf("Reclaimed %s (%s)", formatBytes(int64(total)), strings.Join(parts, ", "))
+			}
+			return pruneDoneMsg{result: fullActionResultMsg{success: true, message: message, action: "prune"}}
+		}
+	}
+}
+
+// imageLabel describes an image by its tags, or its ID when it has none
+func imageLabel(img docker.ImageInfo) string {
+	name := strings.Join(img.RepoTags, ", ")
+	if name == "" || name == "<none>:<none>" {
+		name = "<none> " + img.ID
+	}
+	return fmt.Sprintf("%s (%s)", name, formatBytes(img.Size))
+}
@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reinitializedMsg reports the result of recreating the Docker client
+type reinitializedMsg struct {
+	err       error
+	reconnect bool // after Docker was down, rather than on a hard refresh
+}
+
+// WithEventListener gives the model the listener it restarts on a hard refresh
+func (m FullModel) WithEventListener(listener *EventListener) FullModel {
+	m.events = listener
+	return m
+}
+
+// reinitialize recreates the Docker client, without quitting the app
+func (m FullModel) reinitialize() tea.Msg {
+	ctx, cancel := context.WithTimeout(m.ctx, 10*time.Second)
+	defer cancel()
+
+	return reinitializedMsg{err: m.docker.Reconnect(ctx)}
+}
+
+// reconnect is reinitialize once Docker answers again after an outage
+func (m FullModel) reconnect() tea.Msg {
+	msg := m.reinitialize().(reinitializedMsg)
+	msg.reconnect = true
+	return msg
+}
+
+// handleReinitialized re-subscribes to events and reloads everything once the
+// Docker client has been recreated
+func (m *FullModel) handleReinitialized(msg reinitializedMsg) tea.Cmd {
+	if msg.err != nil {
+		// Keep checking for Docker to come back, unless that's already going on
+		var cmd tea.Cmd
+		if !m.dockerDown() {
+			cmd = m.startConnectionCheck()
+		}
+		m.dockerConnected = false
+		m.dockerErr = msg.err
+		m.statusMsg = fmt.Sprintf("Error reinitializing: %v", msg.err)
+		return cmd
+	}
+
+	m.restartEvents()
+	m.dockerConnected = true
+	m.dockerErr = nil
+	if msg.reconnect {
+		// Keep whatever was open; only the lists need reloading
+		m.statusMsg = "Reconnected to Docker"
+		return m.fetchAll()
+	}
+
+	m.stopComposeTail()
+	m.currentMode = ListMode
+	m.statusMsg = "Reinitialized the Docker connection"
+
+	return m.fetchAll()
+}
+
+// restartEvents subscribes to the events again through the current Docker
+// client. The listener drops its previous subscription, so they never pile up.
+func (m *FullModel) restartEvents() {
+	if m.events == nil {
+		return
+	}
+	m.eventStream = eventStreamReconnecting
+	m.events.Restart()
+}
+
+// fetchAll reloads every resource list and the system info
+func (m FullModel) fetchAll() tea.Cmd {
+	return tea.Batch(
+		m.fetchContainers,
+		m.fetchImages,
+		m.fetchVolumes,
+		m.fetchNetworks,
+		m.fetchComposeProjects,
+		func() tea.Msg {
+			return m.fetchSystemInfo()
+		},
+	)
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// Fields of the registry login form
+const (
+	loginFormRegistry = iota
+	loginFormUsername
+	loginFormPassword
+	loginFormRemember
+)
+
+// Choices of the registry login form's remember field
+const (
+	rememberSession = "this session"
+	rememberHelper  = "credential helper"
+)
+
+// openRegistryLogin opens the form for logging in to a registry
+func (m *FullModel) openRegistryLogin() tea.Cmd {
+	fields := []formField{
+		loginFormRegistry: textField("Registry", "e.g. ghcr.io", "docker.io"),
+		loginFormUsername: textField("Username", "", ""),
+		loginFormPassword: passwordField("Password"),
+		loginFormRemember: choiceField("Remember for", rememberSession, rememberHelper),
+	}
+	cmd := m.openResourceForm("Log in to a registry", fields, validateRegistryLogin, registryLogin)
+	m.resourceForm.verb = "log in"
+	return cmd
+}
+
+// validateRegistryLogin checks that every field of the login form is filled in
+func validateRegistryLogin(values []string) []string {
+	errs := make([]string, len(values))
+	if values[loginFormRegistry] == "" {
+		errs[loginFormRegistry] = "a registry is required, docker.io for Docker Hub"
+	}
+	if values[loginFormUsername] == "" {
+		errs[loginFormUsername] = "a username is required"
+	}
+	if values[loginFormPassword] == "" {
+		errs[loginFormPassword] = "a password or access token is required"
+	}
+	return errs
+}
+
+// registryLogin logs in with the submitted form, saving the credentials
+// with the Docker credential helper if asked to
+func registryLogin(m *FullModel, values []string) tea.Cmd {
+	server := values[loginFormRegistry]
+	username, password := values[loginFormUsername], values[loginFormPassword]
+	save := values[loginFormRemember] == rememberHelper
+
+	m.statusMsg = fmt.Sprintf("Logging in to %s as %s...", server, username)
+	return func() tea.Msg {
+		if _, err := m.docker.RegistryLogin(m.ctx, server, username, password); err != nil {
+			return fullActionResultMsg{success: false, message: err.Error()}
+		}
+		if !save {
+			return fullActionResultMsg{success: true, message: fmt.Sprintf("Logged in to %s as %s for this session", server, username)}
+		}
+		if err := docker.SaveRegistryLogin(m.ctx, server); err != nil {
+			return fullActionResultMsg{success: true, message: fmt.Sprintf("Logged in to %s as %s for this session only, it couldn't be saved: %v", server, username, err)}
+		}
+		return fullActionResultMsg{success: true, message: fmt.Sprintf("Logged in to %s as %s, saved with the credential helper", server, username)}
+	}
+}
@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// promptRename asks for a new name for the selected container
+func (m *FullModel) promptRename() tea.Cmd {
+	if m.selectedID == "" {
+		m.statusMsg = "No container selected"
+		return nil
+	}
+
+	id, oldName := m.selectedID, m.selectedName
+	cmd := m.openPrompt(fmt.Sprintf("Rename %s to:", oldName), oldName, func(m *FullModel, value string) tea.Cmd {
+		name := strings.TrimSpace(value)
+		if name == oldName {
+			m.statusMsg = "Name unchanged"
+			return nil
+		}
+
+		// Keep the cursor on the container once the list reloads
+		m.pendingSelection = id
+		m.statusMsg = fmt.Sprintf("Renaming %s to %s...", oldName, name)
+		return func() tea.Msg {
+			if err := m.docker.RenameContainer(m.ctx, id, name); err != nil {
+				return fullActionResultMsg{success: false, message: err.Error(), action: "rename"}
+			}
+			return fullActionResultMsg{success: true, message: fmt.Sprintf("Renamed %s to %s", oldName, name), action: "rename"}
+		}
+	})
+	m.prompt.validate = func(value string) string {
+		if !containerNamePattern.MatchString(strings.TrimSpace(value)) {
+			return "names may only contain letters, digits, _ . and -, start with a letter or digit, and be at least 2 characters"
+		}
+		return ""
+	}
+	return cmd
+}
+
+// restorePendingSelection moves the container cursor back to the container
+// an action was applied to, wherever it ended up after the list reloaded
+func (m *FullModel) restorePendingSelection() {
+	if m.pendingSelection == "" {
+		return
+	}
+	for i, c := range m.visibleContainers() {
+		if c.ID == m.pendingSelection {
+			m.containerTable.SetCursor(i)
+			if m.currentTab == ContainersTab && m.currentMode == ListMode {
+				m.updateSelection()
+			}
+			break
+		}
+	}
+	m.pendingSelection = ""
+}
@@ -0,0 +1,237 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// resourceForm is a small overlay form for creating simple resources such
+// as networks and volumes. Fields are either free text or a fixed set of
+// choices.
+type resourceForm struct {
+	active bool
+	title  string
+	fields []formField
+	focus  int
+	// validate returns an error message per field, empty where the value is fine
+	validate func(values []string) []string
+	submit   func(m *FullModel, values []string) tea.Cmd
+	verb     string // what submitting does, "create" if empty
+}
+
+// formField is one field of a resourceForm
+type formField struct {
+	label   string
+	input   textinput.Model
+	choices []string // for choice fields, cycled with space or ←/→
+	choice  int
+	err     string
+}
+
+// textField returns a free text field with a placeholder hint and an initial value
+func textField(label, hint, value string) formField {
+	input := textinput.New()
+	input.Prompt = ""
+	input.Placeholder = hint
+	input.Width = 40
+	input.SetValue(value)
+	input.CursorEnd()
+	return formField{label: label, input: input}
+}
+
+// passwordField returns a text field that masks what's typed into it
+func passwordField(label string) formField {
+	field := textField(label, "", "")
+	field.input.EchoMode = textinput.EchoPassword
+	field.input.EchoCharacter = '•'
+	return field
+}
+
+// choiceField returns a field offering a fixed set of choices, the first one selected
+func choiceField(label string, choices ...string) formField {
+	return formField{label: label, choices: choices}
+}
+
+// value returns the text entered or the choice selected
+func (f formField) value() string {
+	if f.choices != nil {
+		return f.choices[f.choice]
+	}
+	return strings.TrimSpace(f.input.Value())
+}
+
+// openResourceForm opens a resource form with the given fields
+func (m *FullModel) openResourceForm(title string, fields []formField, validate func(values []string) []string, submit func(m *FullModel, values []string) tea.Cmd) tea.Cmd {
+	m.resourceForm = resourceForm{
+		active:   true,
+		title:    title,
+		fields:   fields,
+		validate: validate,
+		submit:   submit,
+	}
+	return m.focusResourceField(0)
+}
+
+// focusResourceField moves the cursor to the given field
+func (m *FullModel) focusResourceField(index int) tea.Cmd {
+	form := &m.resourceForm
+	form.fields[form.focus].input.Blur()
+	form.focus = (index + len(form.fields)) % len(form.fields)
+	if form.fields[form.focus].choices != nil {
+		return nil
+	}
+	return form.fields[form.focus].input.Focus()
+}
+
+// handleResourceFormKey processes key presses while a resource form is open
+func (m *FullModel) handleResourceFormKey(msg tea.KeyMsg) tea.Cmd {
+	form := &m.resourceForm
+	field := &form.fields[form.focus]
+
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.resourceForm = resourceForm{}
+		m.statusMsg = "Cancelled"
+		return nil
+	case "tab", "down":
+		return m.focusResourceField(form.focus + 1)
+	case "shift+tab", "up":
+		return m.focusResourceField(form.focus - 1)
+	case "ctrl+s":
+		return m.submitResourceForm()
+	case "enter":
+		if form.focus == len(form.fields)-1 {
+			return m.submitResourceForm()
+		}
+		return m.focusResourceField(form.focus + 1)
+	}
+
+	if field.choices != nil {
+		switch msg.String() {
+		case " ", "right", "l":
+			field.choice = (field.choice + 1) % len(field.choices)
+		case "left", "h":
+			field.choice = (field.choice - 1 + len(field.choices)) % len(field.choices)
+		}
+		return nil
+	}
+
+	var cmd tea.Cmd
+	field.input, cmd = field.input.Update(msg)
+	field.err = ""
+	return cmd
+}
+
+// submitResourceForm validates the form and submits it, or shows the errors
+// next to the fields
+func (m *FullModel) submitResourceForm() tea.Cmd {
+	form := &m.resourceForm
+	values := make([]string, len(form.fields))
+	for i, field := range form.fields {
+		values[i] = field.value()
+	}
+
+	errs := form.validate(values)
+	for i := range form.fields {
+		form.fields[i].err = errs[i]
+	}
+	for i, err := range errs {
+		if err != "" {
+			m.statusMsg = "Fix the highlighted fields"
+			return m.focusResourceField(i)
+		}
+	}
+
+	submit := form.submit
+	m.resourceForm = resourceForm{}
+	return submit(m, values)
+}
+
+// renderResourceForm renders the open resource form
+func (m FullModel) renderResourceForm() string {
+	var sb strings.Builder
+
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.Title)
+	labelStyle := lipgloss.NewStyle().Foreground(m.styles.Text).Width(16)
+	focusStyle := labelStyle.Foreground(m.styles.Accent).Bold(true)
+	choiceStyle := lipgloss.NewStyle().Foreground(m.styles.Muted)
+	chosenStyle := lipgloss.NewStyle().Bold(true).Foreground(m.styles.SelectedText).Background(m.styles.SelectedBackground)
+	errorStyle := lipgloss.NewStyle().Foreground(m.styles.Error)
+	hintStyle := lipgloss.NewStyle().Foreground(m.styles.Muted).Italic(true)
+
+	sb.WriteString(titleStyle.Render(m.resourceForm.title))
+	sb.WriteString("\n\n")
+
+	for i, field := range m.resourceForm.fields {
+		style := labelStyle
+		if i == m.resourceForm.focus {
+			style = focusStyle
+		}
+		sb.WriteString(style.Render(field.label))
+		if field.choices != nil {
+			for j, choice := range field.choices {
+				if j == field.choice {
+					sb.WriteString(chosenStyle.Render(" " + choice + " "))
+				} else {
+					sb.WriteString(choiceStyle.Render(" " + choice + " "))
+				}
+			}
+		} else {
+			sb.WriteString(field.input.View())
+		}
+		sb.WriteString("\n")
+		if field.err != "" {
+			sb.WriteString(labelStyle.Render(""))
+			sb.WriteString(errorStyle.Render("✗ " + field.err))
+			sb.WriteString("\n")
+		}
+	}
+
+	verb := m.resourceForm.verb
+	if verb == "" {
+		verb = "create"
+	}
+	sb.WriteString("\n")
+	sb.WriteString(hintStyle.Render("tab/↑/↓ move • space/←/→ change choice • enter next field • ctrl+s " + verb + " • esc cancel"))
+	return sb.String()
+}
+
+// resourceCreatedMsg reports a created resource together with the reloaded
+// list of its tab, so the result stays in the status bar rather than being
+// replaced by the list summary
+type resourceCreatedMsg struct {
+	result fullActionResultMsg
+	list   tea.Msg // the reloaded list, nil if creating failed
+	tab    Tab
+	id     string // resource to select, by name for volumes and without "sha256:" for images
+}
+
+// selectResource moves the cursor of a tab to the given resource
+func (m *FullModel) selectResource(tab Tab, id string) {
+	switch tab {
+	case ImagesTab:
+		for i, img := range m.visibleImages() {
+			if strings.HasPrefix(id, img.ID) {
+				m.imageTable.SetCursor(i)
+			}
+		}
+	case VolumesTab:
+		for i, v := range m.visibleVolumes() {
+			if v.Name == id {
+				m.volumeTable.SetCursor(i)
+			}
+		}
+	case NetworksTab:
+		for i, n := range m.visibleNetworks() {
+			if strings.HasPrefix(id, n.ID) {
+				m.networkTable.SetCursor(i)
+			}
+		}
+	}
+	if m.currentTab == tab && m.currentMode == ListMode {
+		m.updateSelection()
+	}
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/docker/docker/api/types/container"
+)
+
+// restartPolicies are the restart policies offered for a container
+var restartPolicies = []struct {
+	name, use string
+}{
+	{"no", "never restart it"},
+	{"on-failure", "restart it when it exits with an error"},
+	{"always", "restart it whenever it stops, and when Docker starts"},
+	{"unless-stopped", "like always, unless it was stopped by hand"},
+}
+
+// restartPolicyLabel describes a restart policy for the inspect header
+func restartPolicyLabel(policy container.RestartPolicy) string {
+	switch {
+	case policy.Name == "":
+		return "no"
+	case !policy.IsOnFailure():
+		return string(policy.Name)
+	case policy.MaximumRetryCount == 0:
+		return "on-failure, retrying forever"
+	default:
+		return fmt.Sprintf("on-failure, up to %d retries", policy.MaximumRetryCount)
+	}
+}
+
+// pickRestartPolicy asks for a new restart policy for the inspected
+// container, and for on-failure how many retries it gets
+func (m *FullModel) pickRestartPolicy() {
+	if m.selectedID == "" {
+		m.statusMsg = "No container selected"
+		return
+	}
+
+	items := make([]string, len(restartPolicies))
+	cursor := 0
+	for i, policy := range restartPolicies {
+		items[i] = fmt.Sprintf("%-15s %s", policy.name, policy.use)
+		if container.RestartPolicyMode(policy.name) == m.restartPolicy.Name {
+			cursor = i
+		}
+	}
+
+	id, name, current := m.selectedID, m.selectedName, m.restartPolicy
+	m.openPicker(fmt.Sprintf("Restart policy of %s (now %s)", name, restartPolicyLabel(current)), items, cursor, func(m *FullModel, index int) tea.Cmd {
+		policy := restartPolicies[index].name
+		if policy != string(container.RestartPolicyOnFailure) {
+			return m.setRestartPolicy(id, name, policy, 0)
+		}
+
+		cmd := m.openPrompt("Give up after how many failed restarts (0 never gives up):", strconv.Itoa(current.MaximumRetryCount), func(m *FullModel, value string) tea.Cmd {
+			retries, _ := strconv.Atoi(strings.TrimSpace(value))
+			return m.setRestartPolicy(id, name, policy, retries)
+		})
+		m.prompt.validate = func(value string) string {
+			if retries, err := strconv.Atoi(strings.TrimSpace(value)); err != nil || retries < 0 {
+				return "enter a number of retries, 0 to keep retrying"
+			}
+			return ""
+		}
+		return cmd
+	})
+}
+
+// setRestartPolicy applies a restart policy, then refreshes the inspect view
+// so it shows the new one
+func (m *FullModel) setRestartPolicy(id, name, policy string, maxRetries int) tea.Cmd {
+	label := restartPolicyLabel(container.RestartPolicy{Name: container.RestartPolicyMode(policy), MaximumRetryCount: maxRetries})
+	m.statusMsg = fmt.Sprintf("Setting the restart policy of %s to %s...", name, label)
+	return tea.Sequence(func() tea.Msg {
+		if err := m.docker.SetRestartPolicy(m.ctx, id, policy, maxRetries); err != nil {
+			return fullActionResultMsg{success: false, message: fmt.Sprintf("Error: %v", err)}
+		}
+		return fullActionResultMsg{success: true, message: fmt.Sprintf("Restart policy of %s is now %s", name, label)}
+	}, refreshInspect)
+}
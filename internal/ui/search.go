@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// viewportSearch holds the state of a search within the viewport content.
+// It is shared by the logs and inspect views.
+type viewportSearch struct {
+	query         string
+	caseSensitive bool
+	matches       []int // line numbers containing at least one match
+	current       int   // index into matches
+}
+
+// setViewportContent sets the viewport content, wrapping long lines if that's
+// on for the current mode and highlighting search matches if a search is active
+func (m *FullModel) setViewportContent(content string) {
+	m.viewportContent = content
+	if m.lineWrapOn() {
+		content = wrapLines(content, m.wrapWidth(), m.currentMode == InspectMode)
+	}
+	if m.search.query == "" {
+		m.viewport.SetContent(content)
+		return
+	}
+
+	highlighted, matches := m.highlightMatches(content, m.search.query, m.search.caseSensitive)
+	m.search.matches = matches
+	if m.search.current >= len(matches) {
+		m.search.current = 0
+	}
+	m.viewport.SetContent(highlighted)
+}
+
+// highlightMatches highlights every match of pattern in content and returns
+// the numbers of the lines that contain a match
+func (m FullModel) highlightMatches(content, pattern string, caseSensitive bool) (string, []int) {
+	re := compileSearchPattern(pattern, caseSensitive)
+	matchStyle := lipgloss.NewStyle().Foreground(m.styles.Header).Background(m.styles.Warning)
+
+	lines := strings.Split(content, "\n")
+	var matches []int
+	for i, line := range lines {
+		// Search the visible text so styling escape codes can't produce false matches
+		plain := ansi.Strip(line)
+		if !re.MatchString(plain) {
+			continue
+		}
+		matches = append(matches, i)
+		lines[i] = re.ReplaceAllStringFunc(plain, func(s string) string {
+			return matchStyle.Render(s)
+		})
+	}
+
+	return strings.Join(lines, "\n"), matches
+}
+
+// startSearch searches the current viewport content and jumps to the first match
+func (m *FullModel) startSearch(query string) {
+	m.search = viewportSearch{query: query, caseSensitive: m.search.caseSensitive}
+	m.setViewportContent(m.viewportContent)
+
+	if query == "" {
+		m.statusMsg = "Search cleared"
+		return
+	}
+	if len(m.search.matches) == 0 {
+		m.statusMsg = fmt.Sprintf("No matches for %q", query)
+		return
+	}
+	m.jumpToMatch(0)
+}
+
+// toggleSearchCase switches between case-insensitive and case-sensitive
+// matching, re-running the current search
+func (m *FullModel) toggleSearchCase() {
+	m.search.caseSensitive = !m.search.caseSensitive
+	mode := "Case-insensitive search"
+	if m.search.caseSensitive {
+		mode = "Case-sensitive search"
+	}
+
+	if m.search.query == "" {
+		m.statusMsg = mode
+		return
+	}
+	m.startSearch(m.search.query)
+	if len(m.search.matches) > 0 {
+		m.statusMsg = mode
+	}
+}
+
+// clearSearch removes the search highlighting from the viewport
+func (m *FullModel) clearSearch() {
+	if m.search.query == "" {
+		return
+	}
+	m.search = viewportSearch{}
+	m.setViewportContent(m.viewportContent)
+}
+
+// nextMatch moves to the next (or previous, for a negative step) search match
+func (m *FullModel) nextMatch(step int) {
+	if len(m.search.matches) == 0 {
+		return
+	}
+	count := len(m.search.matches)
+	m.jumpToMatch(((m.search.current+step)%count + count) % count)
+}
+
+// jumpToMatch scrolls the viewport so the given match is visible
+func (m *FullModel) jumpToMatch(index int) {
+	m.search.current = index
+	m.viewport.SetYOffset(m.search.matches[index])
+	m.statusMsg = fmt.Sprintf("%s to navigate, %s to toggle case sensitivity", keyPair(DefaultFullKeyMap.NextMatch, DefaultFullKeyMap.PrevMatch), DefaultFullKeyMap.MatchCase.Help().Key)
+}
+
+// searchSummary describes the active search for the footer
+func (m FullModel) searchSummary() string {
+	if m.search.query == "" {
+		return ""
+	}
+	summary := fmt.Sprintf("/%s: ", m.search.query)
+	if len(m.search.matches) == 0 {
+		summary += "no matches"
+	} else {
+		summary += fmt.Sprintf("match %d of %d", m.search.current+1, len(m.search.matches))
+	}
+	if m.search.caseSensitive {
+		summary += " (case-sensitive)"
+	}
+	return summary
+}
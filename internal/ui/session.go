@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"log"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// restoreSession opens the tab that was open at the last quit, when
+// restoreSession is set. The resource selected on it is selected again once
+// the tab's list loads.
+func (m *FullModel) restoreSession() {
+	if !m.config.RestoreSession {
+		return
+	}
+	for tab, name := range tabStateKeys {
+		if name == m.state.Tab {
+			m.currentTab = tab
+			m.sessionSelection = m.state.Selection
+			return
+		}
+	}
+}
+
+// restoreSessionSelection moves the cursor of the restored tab to the resource
+// selected at the last quit, or to the first row if it's gone or filtered out
+func (m *FullModel) restoreSessionSelection(tab Tab) {
+	if m.sessionSelection == "" || tab != m.currentTab {
+		return
+	}
+
+	cursor := 0
+	for i, target := range m.rowTargets[tab] {
+		if target.name == m.sessionSelection {
+			cursor = i
+			break
+		}
+	}
+	m.getCurrentTable().SetCursor(cursor)
+	m.sessionSelection = ""
+	if m.currentMode == ListMode {
+		m.updateSelection()
+	}
+}
+
+// saveSession records the current tab and the resource selected on it, for
+// restoreSession to reopen them on the next start
+func (m *FullModel) saveSession() {
+	if !m.config.RestoreSession {
+		return
+	}
+
+	m.state.Tab = tabStateKeys[m.currentTab]
+	m.state.Selection = ""
+	if targets, cursor := m.rowTargets[m.currentTab], m.getCurrentTable().Cursor(); m.currentTab != EventsTab && cursor >= 0 && cursor < len(targets) {
+		m.state.Selection = targets[cursor].name
+	}
+	// Nothing is shown once quitting, so a failure can only go to the app log
+	if err := m.state.Save(); err != nil {
+		log.Printf("Failed to save the session: %v", err)
+	}
+}
+
+// quit saves the session and ends the program. Every way of quitting goes
+// through it, whatever view is open.
+func (m *FullModel) quit() tea.Cmd {
+	m.statusMsg = "Quitting..."
+	m.saveSession()
+	return tea.Quit
+}
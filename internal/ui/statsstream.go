@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// systemInfoEverySamples is how many stats samples (about a second each) pass
+// between refreshes of the system info while monitoring
+const systemInfoEverySamples = 5
+
+// containerStatsStream streams the stats of the container being monitored.
+// The stream goroutine only writes to updates; everything else is owned by Update.
+type containerStatsStream struct {
+	name    string
+	cancel  context.CancelFunc
+	updates <-chan docker.ContainerStats
+	samples int
+}
+
+// statsStreamMsg delivers an update from a stats stream: the opened stream, a
+// sample, a note shown instead of stats, or the end of the stream
+type statsStreamMsg struct {
+	stream  *containerStatsStream
+	updates <-chan docker.ContainerStats
+	stats   *docker.ContainerStats
+	note    string
+	err     error
+	done    bool
+}
+
+// startMonitoring switches to monitor mode and starts streaming the selected
+// container's stats, replacing any stream left from a previous session
+func (m *FullModel) startMonitoring() tea.Cmd {
+	m.stopMonitoring()
+	m.currentMode = MonitorMode
+	m.statsContent = "Waiting for stats..."
+	m.setViewportContent(m.statsContent)
+
+	if m.selectedID == "" {
+		m.statsContent = "No container selected"
+		m.setViewportContent(m.statsContent)
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	stream := &containerStatsStream{name: m.selectedName, cancel: cancel}
+	m.statsStream = stream
+
+	id := m.selectedID
+	return func() tea.Msg {
+		// Stats for a container that isn't running never arrive, so don't wait for them
+		state, err := m.docker.ContainerState(ctx, id)
+		if err != nil {
+			return statsStreamMsg{stream: stream, err: err, done: true}
+		}
+		switch state {
+		case "running":
+		case "paused":
+			return statsStreamMsg{stream: stream, note: "Container is paused, so no resource usage is reported.\n\nUnpause it to resume monitoring.", done: true}
+		default:
+			return statsStreamMsg{stream: stream, note: fmt.Sprintf("Container is not running (state: %s).\n\nStart it to see live resource usage.", state), done: true}
+		}
+
+		updates, err := m.docker.StreamProcessedStats(ctx, id)
+		if err != nil {
+			return statsStreamMsg{stream: stream, err: err, done: true}
+		}
+		return statsStreamMsg{stream: stream, updates: updates}
+	}
+}
+
+// stopMonitoring cancels the stats stream, if there is one
+func (m *FullModel) stopMonitoring() {
+	if m.statsStream != nil {
+		m.statsStream.cancel()
+		m.statsStream = nil
+	}
+}
+
+// waitForStats waits for the next sample from the stream
+func waitForStats(stream *containerStatsStream) tea.Cmd {
+	return func() tea.Msg {
+		stats, ok := <-stream.updates
+		if !ok {
+			return statsStreamMsg{stream: stream, done: true}
+		}
+		return statsStreamMsg{stream: stream, stats: &stats}
+	}
+}
+
+// handleStatsStream shows the latest stats and keeps listening
+func (m *FullModel) handleStatsStream(msg statsStreamMsg) tea.Cmd {
+	// Ignore updates from a stream that has since been stopped
+	if msg.stream != m.statsStream {
+		return nil
+	}
+	stream := msg.stream
+
+	switch {
+	case msg.err != nil:
+		m.statsStream = nil
+		m.statusMsg = fmt.Sprintf("Error: %v", msg.err)
+		return nil
+	case msg.note != "":
+		m.statsStream = nil
+		m.statsContent = msg.note
+		m.setViewportContent(m.statsContent)
+		return nil
+	case msg.done:
+		m.statsStream = nil
+		m.statusMsg = fmt.Sprintf("Stats stream for %s has ended (%s to restart)", stream.name, DefaultFullKeyMap.Refresh.Help().Key)
+		return nil
+	case msg.updates != nil:
+		stream.updates = msg.updates
+		return waitForStats(stream)
+	}
+
+	m.statsContent = m.renderStats(*msg.stats)
+	m.setViewportContent(m.statsContent)
+	m.statusMsg = fmt.Sprintf("Monitoring %s", stream.name)
+
+	cmds := []tea.Cmd{waitForStats(stream)}
+	stream.samples++
+	if stream.samples%systemInfoEverySamples == 0 {
+		cmds = append(cmds, m.fetchSystemInfo)
+	}
+	return tea.Batch(cmds...)
+}
+
+// renderStats formats a stats sample for the monitor view
+func (m FullModel) renderStats(stats docker.ContainerStats) string {
+	var sb strings.Builder
+
+	// Format CPU usage with bar
+	cpuBar := m.createUsageBar(stats.CPUPercentage, 50)
+
+	// Format memory usage with bar
+	memBar := m.createUsageBar(stats.MemoryPercentage, 50)
+
+	// Create header
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(m.styles.Accent)
+
+	// CPU section
+	sb.WriteString(headerStyle.Render("CPU Usage:"))
+	sb.WriteString(fmt.Sprintf("\n%.2f%%\n", stats.CPUPercentage))
+	sb.WriteString(cpuBar)
+	sb.WriteString("\n\n")
+
+	// Memory section
+	sb.WriteString(headerStyle.Render("Memory Usage:"))
+	sb.WriteString(fmt.Sprintf("\n%.2f%% (%s / %s)\n",
+		stats.MemoryPercentage,
+		formatBytes(stats.MemoryUsage),
+		formatBytes(stats.MemoryLimit)))
+	sb.WriteString(memBar)
+	sb.WriteString("\n\n")
+
+	// Network I/O
+	sb.WriteString(headerStyle.Render("Network I/O:"))
+	sb.WriteString(fmt.Sprintf("\n📥 RX: %s / 📤 TX: %s\n\n",
+		formatBytes(stats.NetworkRx),
+		formatBytes(stats.NetworkTx)))
+
+	// Block I/O
+	sb.WriteString(headerStyle.Render("Block I/O:"))
+	sb.WriteString(fmt.Sprintf("\n📄 Read: %s / 📝 Write: %s\n",
+		formatBytes(stats.BlockRead),
+		formatBytes(stats.BlockWrite)))
+
+	return sb.String()
+}
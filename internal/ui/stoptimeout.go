@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// promptStopTimeout asks how long the selected container gets to exit
+// before it's killed, then stops it. The default comes from the config's
+// stopTimeoutSeconds; slow-stopping containers such as databases may need more.
+func (m *FullModel) promptStopTimeout() tea.Cmd {
+	if m.selectedID == "" {
+		m.statusMsg = "No container selected"
+		return nil
+	}
+
+	id, name := m.selectedID, m.selectedName
+	inspecting := m.currentMode == InspectMode
+	label := fmt.Sprintf("Stop %s, killing it after (seconds):", name)
+	cmd := m.openPrompt(label, strconv.Itoa(m.config.StopTimeoutSeconds), func(m *FullModel, value string) tea.Cmd {
+		timeout, _ := strconv.Atoi(strings.TrimSpace(value))
+		m.stops.recordFor(containerStopKey(id), time.Duration(timeout)*time.Second)
+		m.statusMsg = fmt.Sprintf("Stopping %s, waiting up to %ds...", name, timeout)
+
+		stop := func() tea.Msg {
+			if err := m.docker.StopContainer(m.ctx, id, timeout); err != nil {
+				return fullActionResultMsg{success: false, message: err.Error()}
+			}
+			return fullActionResultMsg{success: true, message: fmt.Sprintf("Stopped %s", name), action: "stop"}
+		}
+		if inspecting {
+			return tea.Batch(stop, func() tea.Msg {
+				return afterActionMsg{action: "inspect"}
+			})
+		}
+		return stop
+	})
+	m.prompt.validate = func(value string) string {
+		if timeout, err := strconv.Atoi(strings.TrimSpace(value)); err != nil || timeout < 0 {
+			return "enter a number of seconds, 0 to kill it right away"
+		}
+		return ""
+	}
+	return cmd
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/klejdi94/docker-tea/internal/config"
+	"github.com/klejdi94/docker-tea/internal/ui/views"
+)
+
+// pickTheme lets the user switch to another built-in theme. Colors set in
+// the config file still apply on top of it.
+func (m *FullModel) pickTheme() {
+	names := config.ThemeNames()
+	items := make([]string, len(names))
+	for i, name := range names {
+		items[i] = name
+		if name == m.styles.Name {
+			items[i] += " (current)"
+		}
+	}
+
+	m.openPicker("Switch theme", items, slices.Index(names, m.styles.Name), func(m *FullModel, index int) tea.Cmd {
+		theme := m.config.Theme
+		theme.Name = names[index]
+		m.applyTheme(theme)
+		m.statusMsg = fmt.Sprintf("Switched to the %s theme", names[index])
+		return nil
+	})
+}
+
+// applyTheme rebuilds the styles from a theme and redraws what was already
+// rendered with the old ones
+func (m *FullModel) applyTheme(theme config.Theme) {
+	m.styles = views.NewStyles(theme)
+	views.SetStyles(m.styles)
+
+	m.spinner.Style = lipgloss.NewStyle().Foreground(m.styles.Special)
+	m.viewport.Style = m.viewport.Style.BorderForeground(m.styles.Border)
+	for _, t := range []*table.Model{&m.containerTable, &m.imageTable, &m.volumeTable, &m.networkTable, &m.composeTable, &m.topTable} {
+		t.SetStyles(m.tableStyles())
+	}
+
+	// The other views pick up the new colors when they next refresh
+	if m.currentMode == InspectMode {
+		switch m.inspectView {
+		case inspectViewDefault:
+			m.setViewportContent(m.renderInspectContent())
+		case inspectViewLayers:
+			m.setViewportContent(m.renderImageLayers())
+		case inspectViewConfig, inspectViewPs:
+			m.setViewportContent(m.renderComposeInspect())
+		}
+	}
+}
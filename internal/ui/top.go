@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// topColumns are the process columns shown, each with the titles different
+// ps implementations use for it
+var topColumns = []struct {
+	title  string
+	names  []string
+	width  int
+	expand bool // takes the remaining width
+}{
+	{"PID", []string{"PID"}, 8, false},
+	{"USER", []string{"USER", "UID"}, 12, false},
+	{"%CPU", []string{"%CPU", "C"}, 6, false},
+	{"COMMAND", []string{"COMMAND", "CMD", "ARGS"}, 40, true},
+}
+
+// containerTopMsg carries the processes of a container, or a note shown
+// instead when it has none to list
+type containerTopMsg struct {
+	rows [][]string // titles first, then one row per process
+	note string
+	err  error
+}
+
+// fetchContainerTop lists the processes of the selected container
+func (m FullModel) fetchContainerTop() tea.Msg {
+	// Only running containers have processes to list
+	state, err := m.docker.ContainerState(m.ctx, m.selectedID)
+	if err != nil {
+		return containerTopMsg{err: err}
+	}
+	if state != "running" && state != "paused" {
+		return containerTopMsg{note: fmt.Sprintf("Container is not running (state: %s), so it has no processes.\n\nStart it to see what runs inside.", state)}
+	}
+
+	rows, err := m.docker.ContainerTop(m.ctx, m.selectedID)
+	if err != nil {
+		return containerTopMsg{err: err}
+	}
+	return containerTopMsg{rows: rows}
+}
+
+// toggleContainerTop shows the processes of the inspected container, or
+// goes back to the inspect output
+func (m *FullModel) toggleContainerTop() tea.Cmd {
+	if m.inspectView == inspectViewTop {
+		m.inspectView = inspectViewDefault
+		m.setViewportContent(m.renderInspectContent())
+		m.viewport.GotoTop()
+		return nil
+	}
+	m.statusMsg = fmt.Sprintf("Listing processes of %s...", m.selectedName)
+	return m.fetchContainerTop
+}
+
+// handleContainerTop shows listed processes in the processes table
+func (m *FullModel) handleContainerTop(msg containerTopMsg) {
+	if m.currentMode != InspectMode || m.currentTab != ContainersTab {
+		return
+	}
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", msg.err)
+		return
+	}
+
+	m.inspectView = inspectViewTop
+	m.topNote = msg.note
+	if msg.note != "" {
+		m.setViewportContent(msg.note)
+		m.statusMsg = fmt.Sprintf("%s is not running (%s to go back)", m.selectedName, DefaultFullKeyMap.Top.Help().Key)
+		return
+	}
+
+	// Find where each shown column is in the output of ps
+	index := make([]int, len(topColumns))
+	columns := make([]table.Column, len(topColumns))
+	used := 0
+	for i, column := range topColumns {
+		index[i] = -1
+		for j, title := range msg.rows[0] {
+			for _, name := range column.names {
+				if strings.EqualFold(title, name) {
+					index[i] = j
+				}
+			}
+		}
+		columns[i] = table.Column{Title: column.title, Width: column.width}
+		used += column.width + 2
+	}
+	for i, column := range topColumns {
+		if column.expand {
+			columns[i].Width = max(column.width, m.contentWidth()-used+column.width-4)
+		}
+	}
+
+	rows := make([]table.Row, 0, len(msg.rows)-1)
+	for _, process := range msg.rows[1:] {
+		row := make(table.Row, len(topColumns))
+		for i, j := range index {
+			row[i] = "-"
+			if j >= 0 && j < len(process) {
+				row[i] = process[j]
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	// Keep the cursor in place when refreshing
+	cursor := m.topTable.Cursor()
+	m.topTable = table.New(
+		table.WithColumns(columns),
+		table.WithRows(rows),
+		table.WithHeight(max(5, m.height-18)),
+		table.WithFocused(true),
+	)
+	m.topTable.SetStyles(m.tableStyles())
+	m.topTable.SetCursor(min(cursor, max(0, len(rows)-1)))
+	m.statusMsg = fmt.Sprintf("%d processes in %s (%s to refresh, %s to go back)", len(rows), m.selectedName, DefaultFullKeyMap.Refresh.Help().Key, DefaultFullKeyMap.Top.Help().Key)
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/klejdi94/docker-tea/internal/docker"
+)
+
+// usageInterval is how often the combined usage of running containers is
+// sampled. Every sample makes one stats request per running container, so
+// it's kept well below the regular refresh rate.
+const usageInterval = 10 * time.Second
+
+// containerUsage is the combined resource usage of all running containers
+type containerUsage struct {
+	stats   docker.ContainerStats
+	sampled int // running containers whose stats could be read
+	running int // running containers when the sample was taken
+	at      time.Time
+}
+
+// usageMsg carries a new usage sample
+type usageMsg struct {
+	usage containerUsage
+}
+
+// usageTickMsg asks for the next usage sample
+type usageTickMsg struct{}
+
+// usageTick schedules the next usage sample
+func usageTick(delay time.Duration) tea.Cmd {
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return usageTickMsg{}
+	})
+}
+
+// sampleUsage samples the usage of the running containers, but only while
+// the container list is on screen, so the stats requests aren't wasted
+func (m FullModel) sampleUsage() tea.Cmd {
+	if m.currentTab != ContainersTab || m.currentMode != ListMode || m.dashboard.open {
+		return usageTick(usageInterval)
+	}
+
+	var running []string
+	for _, c := range m.containers {
+		if strings.ToLower(c.State) == "running" {
+			running = append(running, c.ID)
+		}
+	}
+
+	return func() tea.Msg {
+		stats, sampled := m.docker.AggregateStats(m.ctx, running)
+		return usageMsg{usage: containerUsage{stats: stats, sampled: sampled, running: len(running), at: time.Now()}}
+	}
+}
+
+// handleUsage stores a usage sample and schedules the next one
+func (m *FullModel) handleUsage(msg usageMsg) tea.Cmd {
+	m.usage = msg.usage
+	return usageTick(usageInterval)
+}
+
+// renderUsage renders the machine-level summary shown under the container list
+func (m FullModel) renderUsage() string {
+	running := 0
+	for _, c := range m.containers {
+		if strings.ToLower(c.State) == "running" {
+			running++
+		}
+	}
+
+	summary := fmt.Sprintf("Σ %d containers, %d running", len(m.containers), running)
+	if m.usage.at.IsZero() {
+		return summary + " | measuring usage..."
+	}
+
+	summary += fmt.Sprintf(" | CPU %.1f%% | Memory %s", m.usage.stats.CPUPercentage, formatBytes(m.usage.stats.MemoryUsage))
+	if m.usage.sampled < m.usage.running {
+		summary += fmt.Sprintf(" (%d of %d sampled)", m.usage.sampled, m.usage.running)
+	}
+	return summary
+}
@@ -8,11 +8,18 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/klejdi94/docker-tea/internal/docker"
 )
 
+// ComposeKeys are the keys of the compose actions the views point to, as
+// they're currently bound
+type ComposeKeys struct {
+	Up, Down, Pull, Restart, Container string
+}
+
 // ComposeInspect renders the compose inspection view
 func ComposeInspect(
 	selectedName, selectedPath, selectedProject, selectedProjectPath, inspectContent string,
@@ -22,18 +29,20 @@ func ComposeInspect(
 	composeContainersLoading bool,
 	containers []docker.ContainerInfo,
 	viewportWidth, viewportHeight int,
+	selectedService int,
 	ctx context.Context,
 	dockerService *docker.Service,
-) (string, []docker.ContainerInfo) {
+	keys ComposeKeys,
+) (string, []docker.ContainerInfo, []docker.ComposeServiceInfo) {
 	if composeServicesLoading {
-		return "Loading compose services...", composeContainers
+		return "Loading compose services...", composeContainers, composeServices
 	}
 
 	// StringBuilder for building the full UI
 	var sb strings.Builder
 
 	// Project header - use both variables for reliability
-	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#88c0d0"))
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Title)
 	projectName := selectedName
 	if selectedProject != "" {
 		projectName = selectedProject
@@ -102,7 +111,7 @@ func ComposeInspect(
 
 					// Create a temporary container for display
 					tempContainer := docker.ContainerInfo{
-						ID:    id[:12],
+						ID:    docker.ShortID(id, 12),
 						Name:  name,
 						Image: image,
 						State: state,
@@ -128,131 +137,8 @@ func ComposeInspect(
 		}
 	}
 
-	// Parse the compose file content (manually since the service might not be doing it correctly)
+	// Services come parsed from the active compose files
 	tmpComposeServices := composeServices
-	if composeFileContent != "" && len(tmpComposeServices) == 0 {
-		// Better parsing logic to extract only actual services
-		lines := strings.Split(composeFileContent, "\n")
-
-		// First pass: identify the services section and its direct children
-		inServices := false
-		servicesIndent := 0
-		serviceEntries := make(map[string]bool)
-
-		for _, line := range lines {
-			trimmedLine := strings.TrimSpace(line)
-			if trimmedLine == "" || strings.HasPrefix(trimmedLine, "#") {
-				continue // Skip empty lines and comments
-			}
-
-			// Count leading spaces to determine indentation level
-			indent := 0
-			for _, char := range line {
-				if char == ' ' {
-					indent++
-				} else if char == '\t' {
-					indent += 4 // Count tabs as 4 spaces
-				} else {
-					break
-				}
-			}
-
-			// Detect services section
-			if strings.HasPrefix(trimmedLine, "services:") {
-				inServices = true
-				servicesIndent = indent
-				continue
-			}
-
-			// If outside services or not deeper indentation, skip
-			if !inServices || indent <= servicesIndent {
-				if trimmedLine != "" && !strings.HasPrefix(trimmedLine, "#") {
-					// We've exited the services section
-					if indent <= servicesIndent {
-						inServices = false
-					}
-				}
-				continue
-			}
-
-			// This is a direct child of services (should be a service name)
-			if indent == servicesIndent+2 && strings.HasSuffix(trimmedLine, ":") {
-				serviceName := strings.TrimSuffix(trimmedLine, ":")
-				serviceEntries[serviceName] = true
-			}
-		}
-
-		// Second pass: extract service properties
-		for serviceName := range serviceEntries {
-			// Default service with minimal info
-			service := docker.ComposeServiceInfo{
-				Name: serviceName,
-			}
-
-			// Extract more details if possible
-			inServiceDef := false
-			serviceIndent := 0
-
-			for _, line := range lines {
-				trimmedLine := strings.TrimSpace(line)
-				if trimmedLine == "" || strings.HasPrefix(trimmedLine, "#") {
-					continue
-				}
-
-				// Count leading spaces
-				indent := 0
-				for _, char := range line {
-					if char == ' ' {
-						indent++
-					} else if char == '\t' {
-						indent += 4
-					} else {
-						break
-					}
-				}
-
-				// Find this service's definition
-				if !inServiceDef && strings.HasPrefix(trimmedLine, serviceName+":") {
-					inServiceDef = true
-					serviceIndent = indent
-					continue
-				}
-
-				// If we're not in this service definition, skip
-				if !inServiceDef {
-					continue
-				}
-
-				// If we've moved to a different section (same indent as service or less), exit
-				if indent <= serviceIndent {
-					inServiceDef = false
-					continue
-				}
-
-				// Process service properties
-				if indent == serviceIndent+2 {
-					// Direct properties of the service
-					if strings.HasPrefix(trimmedLine, "image:") {
-						service.Image = strings.TrimSpace(strings.TrimPrefix(trimmedLine, "image:"))
-					} else if strings.HasPrefix(trimmedLine, "ports:") {
-						// Skip the ports header - we'll process individually later
-					}
-				} else if indent > serviceIndent+2 {
-					// Handle lists like ports
-					if strings.HasPrefix(trimmedLine, "- ") {
-						// Check what kind of list item this is based on context
-						if strings.Contains(strings.ToLower(line), "port") {
-							port := strings.TrimSpace(strings.TrimPrefix(trimmedLine, "- "))
-							service.Ports = append(service.Ports, port)
-						}
-					}
-				}
-			}
-
-			// Add the service to our list
-			tmpComposeServices = append(tmpComposeServices, service)
-		}
-	}
 
 	// Debug info about services and containers
 	if len(tmpComposeServices) > 0 {
@@ -264,7 +150,7 @@ func ComposeInspect(
 
 	// Service list section if services were found
 	if len(tmpComposeServices) > 0 {
-		serviceHeaderStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#a3be8c"))
+		serviceHeaderStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Success)
 		sb.WriteString(serviceHeaderStyle.Render("Services:"))
 		sb.WriteString("\n")
 
@@ -273,23 +159,26 @@ func ComposeInspect(
 		imageColWidth := 25
 		portsColWidth := 30
 
-		tableHeaderStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#d8dee9"))
-		nameColStyle := lipgloss.NewStyle().Width(nameColWidth).Foreground(lipgloss.Color("#88c0d0"))
-		imageColStyle := lipgloss.NewStyle().Width(imageColWidth).Foreground(lipgloss.Color("#a3be8c"))
-		portsColStyle := lipgloss.NewStyle().Width(portsColWidth).Foreground(lipgloss.Color("#ebcb8b"))
+		tableHeaderStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Text)
+		nameColStyle := lipgloss.NewStyle().Width(nameColWidth).Foreground(styles.Title)
+		imageColStyle := lipgloss.NewStyle().Width(imageColWidth).Foreground(styles.Success)
+		portsColStyle := lipgloss.NewStyle().Width(portsColWidth).Foreground(styles.Warning)
 
-		// Render header with proper spacing
+		// Render header with proper spacing, leaving room for the selection marker
+		sb.WriteString("  ")
 		sb.WriteString(tableHeaderStyle.Render(
 			fmt.Sprintf("%-*s │ %-*s │ %-*s",
 				nameColWidth, "Name",
 				imageColWidth, "Image",
 				portsColWidth, "Ports")))
 		sb.WriteString("\n")
-		sb.WriteString(strings.Repeat("─", nameColWidth+imageColWidth+portsColWidth+6))
+		sb.WriteString(strings.Repeat("─", nameColWidth+imageColWidth+portsColWidth+8))
 		sb.WriteString("\n")
 
+		markerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Accent)
+
 		// Format each service row
-		for _, service := range tmpComposeServices {
+		for i, service := range tmpComposeServices {
 			// Truncate image name if too long
 			imageName := service.Image
 			if imageName == "" {
@@ -312,14 +201,26 @@ func ComposeInspect(
 				name = name[:nameColWidth-6] + "..."
 			}
 
+			// Mark the service that service actions (e.g. logs) apply to
+			marker := "  "
+			if i == selectedService {
+				marker = markerStyle.Render("▶ ")
+			}
+
 			// Render service row with proper alignment
-			sb.WriteString(
+			sb.WriteString(marker +
 				nameColStyle.Render(name) + " │ " +
-					imageColStyle.Render(imageName) + " │ " +
-					portsColStyle.Render(portsText) + "\n")
+				imageColStyle.Render(imageName) + " │ " +
+				portsColStyle.Render(portsText) + "\n")
 		}
 	}
 
+	// Details of the selected service
+	if selectedService >= 0 && selectedService < len(tmpComposeServices) {
+		sb.WriteString("\n")
+		sb.WriteString(composeServiceSummary(tmpComposeServices[selectedService]))
+	}
+
 	// Define icons
 	const (
 		IconRunning    = "🟢 "
@@ -331,12 +232,12 @@ func ComposeInspect(
 	// Container section header if containers were found
 	if len(tmpComposeContainers) > 0 {
 		sb.WriteString("\n")
-		containerHeaderStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#b48ead"))
+		containerHeaderStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Special)
 		sb.WriteString(containerHeaderStyle.Render("Containers:"))
 		sb.WriteString("\n")
 
 		// Table header for containers
-		headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#d8dee9"))
+		headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Text)
 		sb.WriteString(headerStyle.Render("ID │ Name │ Status │ Image"))
 		sb.WriteString("\n")
 		sb.WriteString(strings.Repeat("─", viewportWidth))
@@ -344,16 +245,16 @@ func ComposeInspect(
 
 		// Row styles
 		rowStyle := lipgloss.NewStyle()
-		idColStyle := lipgloss.NewStyle().Width(12).Foreground(lipgloss.Color("#88c0d0"))
-		nameColStyle := lipgloss.NewStyle().Width(30).Foreground(lipgloss.Color("#a3be8c"))
+		idColStyle := lipgloss.NewStyle().Width(12).Foreground(styles.Title)
+		nameColStyle := lipgloss.NewStyle().Width(30).Foreground(styles.Success)
 		stateColStyle := lipgloss.NewStyle().Width(12)
-		imageColStyle := lipgloss.NewStyle().Width(40).Foreground(lipgloss.Color("#ebcb8b"))
+		imageColStyle := lipgloss.NewStyle().Width(40).Foreground(styles.Warning)
 
 		// Status styles
-		runningStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#a3be8c"))
-		stoppedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#bf616a"))
-		pausedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ebcb8b"))
-		restartingStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#b48ead"))
+		runningStyle := lipgloss.NewStyle().Foreground(styles.Running)
+		stoppedStyle := lipgloss.NewStyle().Foreground(styles.Stopped)
+		pausedStyle := lipgloss.NewStyle().Foreground(styles.Paused)
+		restartingStyle := lipgloss.NewStyle().Foreground(styles.Special)
 
 		// Add each container as a row
 		for i, container := range tmpComposeContainers {
@@ -400,8 +301,8 @@ func ComposeInspect(
 
 		// Add container navigation help
 		sb.WriteString("\n")
-		helpStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#aaaaaa")).Italic(true)
-		sb.WriteString(helpStyle.Render("💡 Press 'c' + container number (1-9) to switch to Containers tab and focus on that container"))
+		helpStyle := lipgloss.NewStyle().Foreground(styles.Muted).Italic(true)
+		sb.WriteString(helpStyle.Render(fmt.Sprintf("💡 Press '%s' + container number (1-9) to switch to Containers tab and focus on that container", keys.Container)))
 		sb.WriteString("\n")
 	} else if composeContainersLoading {
 		// Show loading message if containers are still loading
@@ -411,17 +312,17 @@ func ComposeInspect(
 	} else {
 		// Show message if no containers are found
 		sb.WriteString("\n")
-		errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#bf616a"))
+		errorStyle := lipgloss.NewStyle().Foreground(styles.Error)
 		sb.WriteString(errorStyle.Render("No containers found for this compose project."))
 		sb.WriteString("\n\n")
 
 		// Add suggestions for troubleshooting
-		tipStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#ebcb8b")).Italic(true)
+		tipStyle := lipgloss.NewStyle().Foreground(styles.Warning).Italic(true)
 		sb.WriteString(tipStyle.Render("Tips:"))
 		sb.WriteString("\n")
 		sb.WriteString("- Check if containers are running with 'docker ps'")
 		sb.WriteString("\n")
-		sb.WriteString("- Try pressing 'u' to start the Docker Compose project")
+		sb.WriteString(fmt.Sprintf("- Try pressing '%s' to start the Docker Compose project", keys.Up))
 		sb.WriteString("\n")
 		sb.WriteString("- Verify the container name matches the project name pattern")
 		sb.WriteString("\n")
@@ -429,7 +330,7 @@ func ComposeInspect(
 
 	// YAML content section
 	sb.WriteString("\n")
-	yamlHeaderStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#5e81ac"))
+	yamlHeaderStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Accent)
 
 	// If we have directly read the file content, display it
 	if composeFileContent != "" {
@@ -447,9 +348,13 @@ func ComposeInspect(
 		sb.WriteString(inspectContent)
 	}
 
-	return sb.String(), tmpComposeContainers
+	return sb.String(), tmpComposeContainers, tmpComposeServices
 }
 
+// composePsTimeout bounds the docker compose ps run when a project's
+// containers can't be found from their labels
+const composePsTimeout = 30 * time.Second
+
 // FetchComposeContainers finds containers belonging to a compose project
 func FetchComposeContainers(
 	ctx context.Context,
@@ -540,8 +445,11 @@ func FetchComposeContainers(
 
 	// If we still have no containers, try using the Docker Compose CLI
 	if len(composeContainers) == 0 {
-		// Try using docker compose ps to get containers directly
-		cmd := exec.Command("docker", "compose", "--project-name", projectName, "ps", "--format", "json")
+		// Try using docker compose ps to get containers directly, giving up
+		// if compose stalls
+		psCtx, cancel := context.WithTimeout(ctx, composePsTimeout)
+		defer cancel()
+		cmd := exec.CommandContext(psCtx, "docker", "compose", "--project-name", projectName, "ps", "--format", "json")
 		output, err := cmd.CombinedOutput()
 		if err == nil && len(output) > 0 {
 			// Try to parse as JSON
@@ -552,8 +460,7 @@ func FetchComposeContainers(
 						// Found container ID, try to find it in our main list
 						for _, existingContainer := range containers {
 							if strings.HasPrefix(existingContainer.ID, id) ||
-								(len(id) >= 12 && len(existingContainer.ID) >= 12 &&
-									strings.HasPrefix(existingContainer.ID, id[:12])) {
+								(len(id) >= 12 && strings.HasPrefix(existingContainer.ID, docker.ShortID(id, 12))) {
 								// Add the container if not already in the list
 								alreadyAdded := false
 								for _, added := range composeContainers {
@@ -611,17 +518,18 @@ func ComposeServiceDetails(
 	projectPath string,
 	serviceName string,
 	viewportWidth int,
+	keys ComposeKeys,
 ) string {
 	var sb strings.Builder
 
 	// Define styles
-	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#00ADD8")).MarginBottom(1)
-	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFDD00")).MarginTop(1)
-	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFFFFF"))
-	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#AAAAAA"))
-	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
-	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFAA00"))
-	successStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00"))
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Title).MarginBottom(1)
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Highlight).MarginTop(1)
+	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Emphasis)
+	valueStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	errorStyle := lipgloss.NewStyle().Foreground(styles.Error)
+	warnStyle := lipgloss.NewStyle().Foreground(styles.Warning)
+	successStyle := lipgloss.NewStyle().Foreground(styles.Success)
 
 	// Add the header
 	sb.WriteString(headerStyle.Render(fmt.Sprintf("Docker Compose Service: %s", serviceName)))
@@ -641,12 +549,10 @@ func ComposeServiceDetails(
 		sb.WriteString("3. Verify that you have the necessary permissions to access the Docker daemon\n")
 
 		// Try to get the compose file content
-		cmd := exec.Command("cat", filepath.Join(projectPath, "docker-compose.yml"))
-		output, err := cmd.CombinedOutput()
+		output, err := os.ReadFile(filepath.Join(projectPath, "docker-compose.yml"))
 		if err != nil {
 			// Try alternate filename
-			cmd = exec.Command("cat", filepath.Join(projectPath, "compose.yaml"))
-			output, err = cmd.CombinedOutput()
+			output, err = os.ReadFile(filepath.Join(projectPath, "compose.yaml"))
 		}
 
 		if err == nil && len(output) > 0 {
@@ -747,7 +653,7 @@ func ComposeServiceDetails(
 				statusIndicator = "⏸️" // Pause symbol for paused
 			}
 
-			sb.WriteString(fmt.Sprintf("• %s %s (%s)\n", statusIndicator, name, containerID[:12]))
+			sb.WriteString(fmt.Sprintf("• %s %s (%s)\n", statusIndicator, name, docker.ShortID(containerID, 12)))
 		}
 	}
 
@@ -763,8 +669,8 @@ func ComposeServiceDetails(
 
 	// Memory usage
 	sb.WriteString(labelStyle.Render("Memory: "))
-	memoryUsageStr := formatBytes(serviceDetails.Memory)
-	memoryLimitStr := formatBytes(serviceDetails.MemoryLimit)
+	memoryUsageStr := FormatBytes(serviceDetails.Memory)
+	memoryLimitStr := FormatBytes(serviceDetails.MemoryLimit)
 	memoryPercentage := 0.0
 	if serviceDetails.MemoryLimit > 0 {
 		memoryPercentage = float64(serviceDetails.Memory) / float64(serviceDetails.MemoryLimit) * 100.0
@@ -776,24 +682,51 @@ func ComposeServiceDetails(
 	sb.WriteString("\n")
 	sb.WriteString(sectionStyle.Render("Actions:"))
 	sb.WriteString("\n")
-	sb.WriteString("• Press 'u' to start the service\n")
-	sb.WriteString("• Press 'd' to stop the service\n")
-	sb.WriteString("• Press 'p' to pull the service image\n")
-	sb.WriteString("• Press 'r' to restart the service\n")
+	sb.WriteString(fmt.Sprintf("• Press '%s' to start the service\n", keys.Up))
+	sb.WriteString(fmt.Sprintf("• Press '%s' to stop the service\n", keys.Down))
+	sb.WriteString(fmt.Sprintf("• Press '%s' to pull the service image\n", keys.Pull))
+	sb.WriteString(fmt.Sprintf("• Press '%s' to restart the service\n", keys.Restart))
 
 	return sb.String()
 }
 
-// Helper function to format bytes to human-readable format
-func formatBytes(bytes int64) string {
-	const unit = 1024
-	if bytes < unit {
-		return fmt.Sprintf("%d B", bytes)
+// composeServiceSummary renders the dependencies, networks, volumes and
+// environment of a compose service
+func composeServiceSummary(service docker.ComposeServiceInfo) string {
+	var sb strings.Builder
+
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Success)
+	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Text).Width(14)
+	valueStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+
+	sb.WriteString(headerStyle.Render(fmt.Sprintf("Service %s:", service.Name)))
+	sb.WriteString("\n")
+
+	list := func(label string, values []string) {
+		sb.WriteString(labelStyle.Render(label))
+		if len(values) == 0 {
+			sb.WriteString(valueStyle.Render("-"))
+		} else {
+			sb.WriteString(valueStyle.Render(strings.Join(values, ", ")))
+		}
+		sb.WriteString("\n")
 	}
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
-		div *= unit
-		exp++
+	list("Depends on", service.DependsOn)
+	list("Networks", service.Networks)
+	list("Volumes", service.Volumes)
+
+	sb.WriteString(labelStyle.Render("Environment"))
+	if len(service.Environment) == 0 {
+		sb.WriteString(valueStyle.Render("-"))
+		sb.WriteString("\n")
 	}
-	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+	for i, variable := range service.Environment {
+		if i > 0 {
+			sb.WriteString(labelStyle.Render(""))
+		}
+		sb.WriteString(valueStyle.Render(variable))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
 }
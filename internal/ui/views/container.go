@@ -0,0 +1,363 @@
+package views
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/go-connections/nat"
+)
+
+// composeLabelPrefix is the prefix Docker Compose uses for the labels it attaches to containers
+const composeLabelPrefix = "com.docker.compose."
+
+// ContainerSummary renders a human-friendly summary of a container's inspect JSON.
+// It returns an empty string if the content can't be parsed as container inspect data.
+func ContainerSummary(inspectContent string) string {
+	var info container.InspectResponse
+	if err := json.Unmarshal([]byte(inspectContent), &info); err != nil || info.ContainerJSONBase == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Highlight)
+
+	// Ports section
+	sb.WriteString(sectionStyle.Render("Ports:"))
+	sb.WriteString("\n")
+	var ports nat.PortMap
+	if info.NetworkSettings != nil {
+		ports = info.NetworkSettings.Ports
+	}
+	sb.WriteString(renderPortMap(ports))
+	sb.WriteString("\n")
+
+	// Resource limits section
+	sb.WriteString(sectionStyle.Render("Resource Limits:"))
+	sb.WriteString("\n")
+	sb.WriteString(renderLimits(info.HostConfig))
+	sb.WriteString("\n")
+
+	// Labels section
+	sb.WriteString(sectionStyle.Render("Labels:"))
+	sb.WriteString("\n")
+	if info.Config == nil || len(info.Config.Labels) == 0 {
+		sb.WriteString("  (none)\n")
+	} else {
+		sb.WriteString(renderLabels(info.Config.Labels))
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(sectionStyle.Render("Raw JSON:"))
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// renderPortMap renders a container's port mappings, one container port per
+// line with the host addresses it's published on
+func renderPortMap(ports nat.PortMap) string {
+	if len(ports) == 0 {
+		return "  (none)\n"
+	}
+
+	keyStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Emphasis)
+	valueStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+
+	var containerPorts []nat.Port
+	for port := range ports {
+		containerPorts = append(containerPorts, port)
+	}
+	nat.Sort(containerPorts, func(a, b nat.Port) bool {
+		if a.Int() != b.Int() {
+			return a.Int() < b.Int()
+		}
+		return a.Proto() < b.Proto()
+	})
+
+	var sb strings.Builder
+	for _, port := range containerPorts {
+		var published []string
+		for _, binding := range ports[port] {
+			published = append(published, fmt.Sprintf("%s:%s", hostIPLabel(binding.HostIP), binding.HostPort))
+		}
+		target := valueStyle.Render("not published")
+		if len(published) > 0 {
+			target = valueStyle.Render(strings.Join(dedupe(published), ", "))
+		}
+		sb.WriteString(fmt.Sprintf("    %s -> %s\n", keyStyle.Render(string(port)), target))
+	}
+	return sb.String()
+}
+
+// FormatPorts formats a container's ports the way `docker ps` does, e.g.
+// 0.0.0.0:8080->80/tcp, with duplicates (such as the IPv6 twin of an IPv4
+// binding) collapsed
+func FormatPorts(ports []container.Port) []string {
+	sorted := append([]container.Port(nil), ports...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].PrivatePort != sorted[j].PrivatePort {
+			return sorted[i].PrivatePort < sorted[j].PrivatePort
+		}
+		return sorted[i].PublicPort < sorted[j].PublicPort
+	})
+
+	formatted := make([]string, 0, len(sorted))
+	for _, port := range sorted {
+		if port.PublicPort == 0 {
+			formatted = append(formatted, fmt.Sprintf("%d/%s", port.PrivatePort, port.Type))
+			continue
+		}
+		formatted = append(formatted, fmt.Sprintf("%s:%d->%d/%s", hostIPLabel(port.IP), port.PublicPort, port.PrivatePort, port.Type))
+	}
+	return dedupe(formatted)
+}
+
+// hostIPLabel shows the IPv6 wildcard like the IPv4 one, so a port published
+// on both reads as a single binding
+func hostIPLabel(ip string) string {
+	switch ip {
+	case "", "::":
+		return "0.0.0.0"
+	}
+	if strings.Contains(ip, ":") {
+		return "[" + ip + "]"
+	}
+	return ip
+}
+
+// dedupe removes repeated strings, keeping the first occurrence of each
+func dedupe(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := values[:0:0]
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+// renderLimits renders the memory and CPU limits of a container, flagging
+// the ones that are unlimited since such containers can starve the host
+func renderLimits(hostConfig *container.HostConfig) string {
+	keyStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Emphasis)
+	valueStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	unlimitedStyle := lipgloss.NewStyle().Foreground(styles.Warning)
+
+	memory := unlimitedStyle.Render("unlimited (can use all host memory)")
+	cpu := unlimitedStyle.Render("unlimited (can use all host CPUs)")
+
+	if hostConfig != nil {
+		if hostConfig.Memory > 0 {
+			memory = valueStyle.Render(FormatBytes(hostConfig.Memory))
+		}
+
+		switch {
+		case hostConfig.NanoCPUs > 0:
+			cpu = valueStyle.Render(fmt.Sprintf("%.2f CPUs", float64(hostConfig.NanoCPUs)/1e9))
+		case hostConfig.CPUQuota > 0:
+			period := hostConfig.CPUPeriod
+			if period == 0 {
+				period = 100000 // Docker's default CFS period in microseconds
+			}
+			cpu = valueStyle.Render(fmt.Sprintf("%.2f CPUs", float64(hostConfig.CPUQuota)/float64(period)))
+		case hostConfig.CPUShares > 0:
+			cpu = valueStyle.Render(fmt.Sprintf("%d shares (relative weight, no hard limit)", hostConfig.CPUShares))
+		}
+	}
+
+	return fmt.Sprintf("    %s = %s\n    %s = %s\n", keyStyle.Render("memory"), memory, keyStyle.Render("cpu"), cpu)
+}
+
+// renderLabels renders container labels with the compose-related ones grouped first
+func renderLabels(labels map[string]string) string {
+	var sb strings.Builder
+
+	keyStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Emphasis)
+	valueStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+	composeHeaderStyle := lipgloss.NewStyle().Italic(true).Foreground(styles.Title)
+	composeKeyStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Title)
+
+	// Split compose labels from the rest so they can be highlighted as a group
+	var composeKeys, otherKeys []string
+	for k := range labels {
+		if strings.HasPrefix(k, composeLabelPrefix) {
+			composeKeys = append(composeKeys, k)
+		} else {
+			otherKeys = append(otherKeys, k)
+		}
+	}
+	sort.Strings(composeKeys)
+	sort.Strings(otherKeys)
+
+	if len(composeKeys) > 0 {
+		sb.WriteString(composeHeaderStyle.Render("  Docker Compose"))
+		sb.WriteString("\n")
+		for _, k := range composeKeys {
+			sb.WriteString(fmt.Sprintf("    %s = %s\n",
+				composeKeyStyle.Render(strings.TrimPrefix(k, composeLabelPrefix)),
+				valueStyle.Render(labels[k])))
+		}
+	}
+
+	if len(otherKeys) > 0 {
+		if len(composeKeys) > 0 {
+			sb.WriteString(composeHeaderStyle.Render("  Other"))
+			sb.WriteString("\n")
+		}
+		for _, k := range otherKeys {
+			sb.WriteString(fmt.Sprintf("    %s = %s\n", keyStyle.Render(k), valueStyle.Render(labels[k])))
+		}
+	}
+
+	return sb.String()
+}
+
+// ContainerImageID extracts the image ID a container was created from
+func ContainerImageID(inspectContent string) string {
+	var info container.InspectResponse
+	if err := json.Unmarshal([]byte(inspectContent), &info); err != nil || info.ContainerJSONBase == nil {
+		return ""
+	}
+	return info.Image
+}
+
+// ContainerRestartPolicy reads a container's restart policy from its inspect
+// JSON, reporting false if there's none to read
+func ContainerRestartPolicy(inspectContent string) (container.RestartPolicy, bool) {
+	var info container.InspectResponse
+	if err := json.Unmarshal([]byte(inspectContent), &info); err != nil || info.ContainerJSONBase == nil || info.HostConfig == nil {
+		return container.RestartPolicy{}, false
+	}
+	return info.HostConfig.RestartPolicy, true
+}
+
+// EnvComparison renders the container's environment variables, marking which
+// ones were set at run time and which were inherited from the image
+func EnvComparison(containerContent, imageContent string) string {
+	var containerInfo container.InspectResponse
+	if err := json.Unmarshal([]byte(containerContent), &containerInfo); err != nil || containerInfo.Config == nil {
+		return "Unable to read the container's environment."
+	}
+
+	var imageInfo image.InspectResponse
+	if err := json.Unmarshal([]byte(imageContent), &imageInfo); err != nil {
+		return "Unable to read the image's environment."
+	}
+
+	imageEnv := make(map[string]string)
+	if imageInfo.Config != nil {
+		for _, kv := range imageInfo.Config.Env {
+			k, v, _ := strings.Cut(kv, "=")
+			imageEnv[k] = v
+		}
+	}
+
+	var sb strings.Builder
+
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Highlight)
+	runtimeStyle := lipgloss.NewStyle().Foreground(styles.Success)
+	overrideStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Warning)
+	inheritedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+
+	sb.WriteString(sectionStyle.Render("Environment (container vs image defaults):"))
+	sb.WriteString("\n\n")
+
+	if len(containerInfo.Config.Env) == 0 {
+		sb.WriteString("  (no environment variables)\n")
+	}
+
+	var runtimeCount, overrideCount int
+	for _, kv := range containerInfo.Config.Env {
+		k, v, _ := strings.Cut(kv, "=")
+		imageValue, inImage := imageEnv[k]
+
+		switch {
+		case !inImage:
+			runtimeCount++
+			sb.WriteString(runtimeStyle.Render(fmt.Sprintf("  + %s=%s", k, v)))
+			sb.WriteString("  (set at run time)")
+		case imageValue != v:
+			overrideCount++
+			sb.WriteString(overrideStyle.Render(fmt.Sprintf("  ~ %s=%s", k, v)))
+			sb.WriteString(fmt.Sprintf("  (image default: %s)", imageValue))
+		default:
+			sb.WriteString(inheritedStyle.Render(fmt.Sprintf("    %s=%s", k, v)))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(fmt.Sprintf("%d set at run time, %d overriding the image, %d inherited\n",
+		runtimeCount, overrideCount, len(containerInfo.Config.Env)-runtimeCount-overrideCount))
+
+	return sb.String()
+}
+
+// maxDiffPaths is how many paths of each kind FilesystemDiff lists before
+// summarizing the rest
+const maxDiffPaths = 500
+
+// FilesystemDiff renders the changes a container made to its filesystem,
+// grouped into added, changed and deleted paths
+func FilesystemDiff(changes []container.FilesystemChange) string {
+	groups := map[container.ChangeType][]string{}
+	for _, change := range changes {
+		groups[change.Kind] = append(groups[change.Kind], change.Path)
+	}
+
+	var sb strings.Builder
+
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Highlight)
+	mutedStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+
+	sb.WriteString(sectionStyle.Render("Filesystem changes (container vs image):"))
+	sb.WriteString("\n\n")
+
+	if len(changes) == 0 {
+		sb.WriteString("  (no changes)\n")
+		return sb.String()
+	}
+
+	kinds := []struct {
+		kind   container.ChangeType
+		title  string
+		marker string
+		style  lipgloss.Style
+	}{
+		{container.ChangeAdd, "Added", "+", lipgloss.NewStyle().Foreground(styles.Success)},
+		{container.ChangeModify, "Changed", "~", lipgloss.NewStyle().Foreground(styles.Warning)},
+		{container.ChangeDelete, "Deleted", "-", lipgloss.NewStyle().Foreground(styles.Error)},
+	}
+	for _, k := range kinds {
+		paths := groups[k.kind]
+		if len(paths) == 0 {
+			continue
+		}
+		sort.Strings(paths)
+
+		sb.WriteString(k.style.Bold(true).Render(fmt.Sprintf("%s (%d)", k.title, len(paths))))
+		sb.WriteString("\n")
+		for _, path := range paths[:min(len(paths), maxDiffPaths)] {
+			sb.WriteString(k.style.Render(fmt.Sprintf("  %s %s", k.marker, path)))
+			sb.WriteString("\n")
+		}
+		if len(paths) > maxDiffPaths {
+			sb.WriteString(mutedStyle.Render(fmt.Sprintf("  ... and %d more", len(paths)-maxDiffPaths)))
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("%d added, %d changed, %d deleted\n",
+		len(groups[container.ChangeAdd]), len(groups[container.ChangeModify]), len(groups[container.ChangeDelete])))
+
+	return sb.String()
+}
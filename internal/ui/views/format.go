@@ -0,0 +1,40 @@
+package views
+
+import "fmt"
+
+// Size unit systems supported by FormatBytes
+const (
+	SizeUnitsIEC = "iec" // binary units (KiB, MiB), 1024-based
+	SizeUnitsSI  = "si"  // decimal units (kB, MB), 1000-based
+)
+
+// sizeUnits is the unit system used when formatting sizes
+var sizeUnits = SizeUnitsIEC
+
+// SetSizeUnits selects the unit system used by FormatBytes
+func SetSizeUnits(units string) {
+	if units == SizeUnitsSI {
+		sizeUnits = SizeUnitsSI
+		return
+	}
+	sizeUnits = SizeUnitsIEC
+}
+
+// FormatBytes formats a byte count in human readable form, using the
+// configured unit system
+func FormatBytes(bytes int64) string {
+	unit, prefixes, suffix := int64(1024), "KMGTPE", "iB"
+	if sizeUnits == SizeUnitsSI {
+		unit, prefixes, suffix = 1000, "kMGTPE", "B"
+	}
+
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := unit, 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %c%s", float64(bytes)/float64(div), prefixes[exp], suffix)
+}
@@ -0,0 +1,89 @@
+package views
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/docker/docker/api/types/network"
+)
+
+// NetworkEndpoint is a container connected to a network
+type NetworkEndpoint struct {
+	ContainerID string
+	Name        string
+	IPv4Address string
+}
+
+// NetworkContainers lists the containers connected to a network, sorted by
+// name, from its inspect JSON
+func NetworkContainers(inspectContent string) []NetworkEndpoint {
+	var info network.Inspect
+	if err := json.Unmarshal([]byte(inspectContent), &info); err != nil {
+		return nil
+	}
+
+	endpoints := make([]NetworkEndpoint, 0, len(info.Containers))
+	for id, endpoint := range info.Containers {
+		endpoints = append(endpoints, NetworkEndpoint{
+			ContainerID: id,
+			Name:        endpoint.Name,
+			IPv4Address: endpoint.IPv4Address,
+		})
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		return endpoints[i].Name < endpoints[j].Name
+	})
+	return endpoints
+}
+
+// NetworkSummary renders the containers connected to a network, numbered and
+// marking the one at cursor, above the inspect JSON. It returns an empty
+// string if the content can't be parsed as network inspect data.
+func NetworkSummary(inspectContent string, cursor int) string {
+	var info network.Inspect
+	if err := json.Unmarshal([]byte(inspectContent), &info); err != nil || info.ID == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	sectionStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Highlight)
+	selectedStyle := lipgloss.NewStyle().Bold(true).Foreground(styles.Emphasis)
+	valueStyle := lipgloss.NewStyle().Foreground(styles.Muted)
+
+	endpoints := NetworkContainers(inspectContent)
+	title := "Connected Containers:"
+	if len(endpoints) > 0 {
+		title = fmt.Sprintf("Connected Containers (1-%d jumps to one):", min(9, len(endpoints)))
+	}
+	sb.WriteString(sectionStyle.Render(title))
+	sb.WriteString("\n")
+	if len(endpoints) == 0 {
+		sb.WriteString("  (none)\n")
+	}
+	for i, endpoint := range endpoints {
+		address := endpoint.IPv4Address
+		if address == "" {
+			address = "no address"
+		}
+		// Only the first nine can be jumped to with a number key
+		number := "  "
+		if i < 9 {
+			number = fmt.Sprintf("%d.", i+1)
+		}
+		if i == cursor {
+			sb.WriteString(fmt.Sprintf("> %s %s %s\n", number, selectedStyle.Render(endpoint.Name), valueStyle.Render(address)))
+		} else {
+			sb.WriteString(fmt.Sprintf("  %s %s %s\n", number, endpoint.Name, valueStyle.Render(address)))
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(sectionStyle.Render("Details:"))
+	sb.WriteString("\n")
+
+	return sb.String()
+}
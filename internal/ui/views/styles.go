@@ -0,0 +1,64 @@
+package views
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/klejdi94/docker-tea/internal/config"
+)
+
+// Styles holds the colors the UI is drawn with, taken from a theme
+type Styles struct {
+	Name               string
+	Title              lipgloss.Color
+	Accent             lipgloss.Color
+	Text               lipgloss.Color
+	Muted              lipgloss.Color
+	Border             lipgloss.Color
+	Emphasis           lipgloss.Color
+	Highlight          lipgloss.Color
+	Special            lipgloss.Color
+	Success            lipgloss.Color
+	Warning            lipgloss.Color
+	Error              lipgloss.Color
+	SelectedText       lipgloss.Color
+	SelectedBackground lipgloss.Color
+	Header             lipgloss.Color
+	StatusBar          lipgloss.Color
+	Running            lipgloss.Color
+	Stopped            lipgloss.Color
+	Paused             lipgloss.Color
+}
+
+// NewStyles builds the styles of a theme, filling in the colors it leaves
+// out from the built-in theme it's based on
+func NewStyles(theme config.Theme) Styles {
+	theme = theme.Resolve()
+	return Styles{
+		Name:               theme.Name,
+		Title:              lipgloss.Color(theme.TitleColor),
+		Accent:             lipgloss.Color(theme.AccentColor),
+		Text:               lipgloss.Color(theme.TextColor),
+		Muted:              lipgloss.Color(theme.MutedColor),
+		Border:             lipgloss.Color(theme.BorderColor),
+		Emphasis:           lipgloss.Color(theme.EmphasisColor),
+		Highlight:          lipgloss.Color(theme.HighlightColor),
+		Special:            lipgloss.Color(theme.SpecialColor),
+		Success:            lipgloss.Color(theme.SuccessColor),
+		Warning:            lipgloss.Color(theme.WarningColor),
+		Error:              lipgloss.Color(theme.ErrorColor),
+		SelectedText:       lipgloss.Color(theme.SelectedTextColor),
+		SelectedBackground: lipgloss.Color(theme.SelectedBackground),
+		Header:             lipgloss.Color(theme.HeaderColor),
+		StatusBar:          lipgloss.Color(theme.StatusBarColor),
+		Running:            lipgloss.Color(theme.ContainerRunning),
+		Stopped:            lipgloss.Color(theme.ContainerStopped),
+		Paused:             lipgloss.Color(theme.ContainerPaused),
+	}
+}
+
+// styles are the styles the views are rendered with
+var styles = NewStyles(config.Theme{})
+
+// SetStyles selects the styles the views are rendered with
+func SetStyles(s Styles) {
+	styles = s
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Fields of the volume creation form
+const (
+	volumeFormName = iota
+	volumeFormDriver
+)
+
+// openVolumeForm opens the form for creating a volume
+func (m *FullModel) openVolumeForm() tea.Cmd {
+	fields := []formField{
+		volumeFormName:   textField("Name", "optional, blank for an anonymous volume", ""),
+		volumeFormDriver: textField("Driver", "e.g. local", "local"),
+	}
+	return m.openResourceForm("New volume", fields, validateVolumeForm, createVolume)
+}
+
+// validateVolumeForm checks the volume name, if any, and driver
+func validateVolumeForm(values []string) []string {
+	errs := make([]string, len(values))
+	if name := values[volumeFormName]; name != "" && !containerNamePattern.MatchString(name) {
+		errs[volumeFormName] = "may only contain letters, digits, _ . and -, and must start with a letter or digit"
+	}
+	if values[volumeFormDriver] == "" {
+		errs[volumeFormDriver] = "a driver is required"
+	}
+	return errs
+}
+
+// createVolume creates a volume from the submitted form and selects it in the list
+func createVolume(m *FullModel, values []string) tea.Cmd {
+	name, driver := values[volumeFormName], values[volumeFormDriver]
+
+	if name == "" {
+		m.statusMsg = "Creating an anonymous volume..."
+	} else {
+		m.statusMsg = fmt.Sprintf("Creating volume %s...", name)
+	}
+	return func() tea.Msg {
+		created, err := m.docker.CreateVolume(m.ctx, name, driver, nil)
+		if err != nil {
+			return resourceCreatedMsg{result: fullActionResultMsg{success: false, message: fmt.Sprintf("Error: %v", err)}}
+		}
+		return resourceCreatedMsg{
+			result: fullActionResultMsg{success: true, message: fmt.Sprintf("Created volume %s (%s driver)", created, driver)},
+			list:   m.fetchVolumes(),
+			tab:    VolumesTab,
+			id:     created,
+		}
+	}
+}